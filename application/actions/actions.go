@@ -125,6 +125,16 @@ func renderOk(c buffalo.Context, v interface{}) error {
 	return c.Render(http.StatusOK, r.JSON(v))
 }
 
+// renderList renders a list of records. If the query asked for a page, the records are wrapped in a ListResponse
+// with the Meta of the page. Otherwise, all the records are rendered as a bare array, as the list endpoints did before
+// paging was added.
+func renderList(c buffalo.Context, query api.Query, meta api.Meta, list interface{}) error {
+	if !query.IsPaged() {
+		return renderOk(c, list)
+	}
+	return renderOk(c, api.ListResponse{Meta: meta, Data: list})
+}
+
 func getUUIDFromParam(c buffalo.Context, param string) (uuid.UUID, error) {
 	s := c.Param(param)
 	id := uuid.FromStringOrNil(s)
@@ -51,15 +51,19 @@ import (
 const idRegex = `/{id:[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[1-5][a-fA-F0-9]{3}-[8|9|aA|bB][a-fA-F0-9]{3}-[a-fA-F0-9]{12}}`
 
 const (
-	stewardPath         = "/steward"
-	batchesPath         = "/batches"
-	claimsPath          = "/" + domain.TypeClaim
-	claimFilesPath      = "/" + domain.TypeClaimFile
-	claimItemsPath      = "/" + domain.TypeClaimItem
-	filesPath           = "/" + domain.TypeFile
-	itemsPath           = "/" + domain.TypeItem
-	policiesPath        = "/" + domain.TypePolicy
-	policyDependentPath = "/" + domain.TypePolicyDependent
+	stewardPath          = "/steward"
+	batchesPath          = "/" + domain.TypeBatch
+	claimsPath           = "/" + domain.TypeClaim
+	claimAdjustmentsPath = "/" + domain.TypeClaimAdjustment
+	claimFilesPath       = "/" + domain.TypeClaimFile
+	claimItemsPath       = "/" + domain.TypeClaimItem
+	deductibleRulesPath  = "/" + domain.TypeDeductibleRule
+	filesPath            = "/" + domain.TypeFile
+	itemsPath            = "/" + domain.TypeItem
+	policiesPath         = "/" + domain.TypePolicy
+	policyDependentPath  = "/" + domain.TypePolicyDependent
+	premiumRatesPath     = "/" + domain.TypePremiumRate
+	reportsPath          = "/reports"
 )
 
 // ENV is used to help switch settings based on where the
@@ -123,6 +127,7 @@ func App() *buffalo.App {
 
 		// Wraps each request in a transaction.
 		app.Use(popmw.Transaction(models.DB))
+		app.Use(premiumRateCache)
 
 		app.GET("/", HomeHandler)
 		app.GET("/status", statusHandler)
@@ -137,6 +142,7 @@ func App() *buffalo.App {
 		usersGroup.PUT("/me", usersMeUpdate)
 		usersGroup.POST("/me/files", usersMeFilesAttach)
 		usersGroup.GET(idRegex, usersView)
+		usersGroup.PUT(idRegex+"/"+api.ResourceApprovalLimit, usersApprovalLimit)
 
 		auth := app.Group("/auth")
 		auth.Middleware.Skip(AuthN, authRequest, authCallback, authDestroy)
@@ -147,14 +153,32 @@ func App() *buffalo.App {
 
 		// accounting batches
 		batchesGroup := app.Group(batchesPath)
-		batchesGroup.Middleware.Skip(AuthZ, batchesGetLatest, batchesApprove, batchesAnnual) // AuthZ is implemented in the handler
+		// AuthZ is implemented in the handler
+		batchesGroup.Middleware.Skip(AuthZ, batchesGetLatest, batchesApprove, batchesAnnualPreview, batchesAnnual)
 		batchesGroup.GET("/latest", batchesGetLatest)
 		batchesGroup.POST("/approve", batchesApprove)
-		batchesGroup.GET("/annual", batchesAnnual)
+		batchesGroup.GET("/annual", batchesAnnualPreview)
+		batchesGroup.POST("/annual", batchesAnnual)
+		batchesGroup.GET("/", batchesList)
+		batchesGroup.GET(idRegex, batchesView)
+		batchesGroup.GET(idRegex+"/"+api.ResourceFile, batchesFile)
+		batchesGroup.POST(idRegex+"/"+api.ResourceApprove, batchesApproveByID)
+		batchesGroup.POST(idRegex+"/"+api.ResourceReverse, batchesReverse)
+
+		// reports
+		reportsGroup := app.Group(reportsPath)
+		// AuthZ is implemented in the handler
+		reportsGroup.Middleware.Skip(AuthZ, reportsLossRatio)
+		reportsGroup.GET("/"+api.ResourceLossRatio, reportsLossRatio)
 
 		stewardGroup := app.Group(stewardPath)
-		stewardGroup.Middleware.Skip(AuthZ, stewardListRecentObjects) // AuthZ is implemented in the handler
+		// AuthZ is implemented in the handler
+		stewardGroup.Middleware.Skip(AuthZ, stewardListRecentObjects, stewardQueue, stewardClaimsSearch,
+			stewardItemsSearch)
 		stewardGroup.GET("/"+api.ResourceRecent, stewardListRecentObjects)
+		stewardGroup.GET("/"+api.ResourceQueue, stewardQueue)
+		stewardGroup.GET("/"+domain.TypeClaim, stewardClaimsSearch)
+		stewardGroup.GET("/"+domain.TypeItem, stewardItemsSearch)
 
 		// claims
 		claimsGroup := app.Group(claimsPath)
@@ -169,12 +193,25 @@ func App() *buffalo.App {
 		claimsGroup.POST(idRegex+"/"+api.ResourceReceipt, claimsRequestReceipt)
 		claimsGroup.POST(idRegex+"/"+api.ResourceApprove, claimsApprove)
 		claimsGroup.POST(idRegex+"/"+api.ResourceDeny, claimsDeny)
+		claimsGroup.POST(idRegex+"/"+api.ResourceAppeal, claimsAppeal)
+		claimsGroup.POST(idRegex+"/"+api.ResourceWithdraw, claimsWithdraw)
+		claimsGroup.POST(idRegex+"/"+api.ResourceAssignment, claimsAssign)
+		claimsGroup.DELETE(idRegex+"/"+api.ResourceAssignment, claimsUnassign)
+		claimsGroup.POST(idRegex+"/"+api.ResourceDisbursements, claimsDisburse)
+		claimsGroup.GET(idRegex+"/"+api.ResourceAdjustments, claimAdjustmentsList)
+		claimsGroup.GET(idRegex+"/"+api.ResourceRecoveries, claimRecoveriesList)
+		claimsGroup.GET(idRegex+"/"+api.ResourceHistory, claimsHistory)
+		claimsGroup.POST(idRegex+"/"+api.ResourceRecoveries, claimRecoveriesCreate)
+
+		claimAdjustmentsGroup := app.Group(claimAdjustmentsPath)
+		claimAdjustmentsGroup.POST(idRegex+"/"+api.ResourceApprove, claimAdjustmentsApprove)
 
 		claimFilesGroup := app.Group(claimFilesPath)
 		claimFilesGroup.DELETE(idRegex, claimFilesDelete)
 
 		claimItemsGroup := app.Group(claimItemsPath)
 		claimItemsGroup.PUT(idRegex, claimItemsUpdate)
+		claimItemsGroup.POST(idRegex+"/"+api.ResourceAdjustments, claimAdjustmentsCreate)
 
 		// config
 		configGroup := app.Group("/config")
@@ -184,6 +221,12 @@ func App() *buffalo.App {
 		configGroup.GET("/item-categories", itemCategoriesList)
 		configGroup.GET("/entity-codes", entityCodesList)
 
+		// deductible rules
+		deductibleRulesGroup := app.Group(deductibleRulesPath)
+		deductibleRulesGroup.GET("/", deductibleRulesList)
+		deductibleRulesGroup.POST("/", deductibleRulesCreate)
+		deductibleRulesGroup.PUT(idRegex, deductibleRulesUpdate)
+
 		// dependent
 		depsGroup := app.Group(policyDependentPath)
 		depsGroup.PUT(idRegex, dependentsUpdate)
@@ -191,10 +234,13 @@ func App() *buffalo.App {
 
 		// item
 		itemsGroup := app.Group(itemsPath)
+		itemsGroup.GET(idRegex+"/"+api.ResourceHistory, itemsHistory)
 		itemsGroup.POST(idRegex+"/"+api.ResourceSubmit, itemsSubmit)
 		itemsGroup.POST(idRegex+"/"+api.ResourceRevision, itemsRevision)
 		itemsGroup.POST(idRegex+"/"+api.ResourceApprove, itemsApprove)
 		itemsGroup.POST(idRegex+"/"+api.ResourceDeny, itemsDeny)
+		itemsGroup.POST(idRegex+"/"+api.ResourceAssignment, itemsAssign)
+		itemsGroup.DELETE(idRegex+"/"+api.ResourceAssignment, itemsUnassign)
 		itemsGroup.PUT(idRegex, itemsUpdate)
 		itemsGroup.DELETE(idRegex, itemsRemove)
 
@@ -210,8 +256,16 @@ func App() *buffalo.App {
 		policiesGroup.POST(idRegex+itemsPath, itemsCreate)
 		policiesGroup.GET(idRegex+claimsPath, policiesClaimsList)
 		policiesGroup.POST(idRegex+claimsPath, claimsCreate)
+		policiesGroup.GET(idRegex+"/"+api.ResourceLedger, policiesLedger)
 		policiesGroup.GET(idRegex+"/members", policiesListMembers)
+		policiesGroup.GET(idRegex+"/"+api.ResourceHistory, policiesHistory)
 		policiesGroup.POST(idRegex+"/members", policiesInviteMember)
+
+		// premium rates
+		premiumRatesGroup := app.Group(premiumRatesPath)
+		premiumRatesGroup.GET("/", premiumRatesList)
+		premiumRatesGroup.POST("/", premiumRatesCreate)
+		premiumRatesGroup.DELETE(idRegex, premiumRatesDelete)
 	}
 
 	listeners.RegisterListener()
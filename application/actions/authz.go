@@ -16,13 +16,17 @@ import (
 func AuthZ(next buffalo.Handler) buffalo.Handler {
 	return func(c buffalo.Context) error {
 		authableResources := map[string]models.Authable{
+			domain.TypeBatch:           &models.Batch{},
 			domain.TypeClaim:           &models.Claim{},
+			domain.TypeClaimAdjustment: &models.ClaimAdjustment{},
 			domain.TypeClaimFile:       &models.ClaimFile{},
 			domain.TypeClaimItem:       &models.ClaimItem{},
+			domain.TypeDeductibleRule:  &models.DeductibleRule{},
 			domain.TypeItem:            &models.Item{},
 			domain.TypePolicy:          &models.Policy{},
 			domain.TypePolicyDependent: &models.PolicyDependent{},
 			domain.TypePolicyUser:      &models.PolicyUser{},
+			domain.TypePremiumRate:     &models.PremiumRate{},
 			domain.TypeUser:            &models.User{},
 		}
 
@@ -3,30 +3,85 @@ package actions
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gobuffalo/buffalo"
 
 	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/fin"
 	"github.com/silinternational/cover-api/models"
 )
 
+const batchChecksumHeader = "X-Checksum-SHA256"
+
 // swagger:operation GET /batches/latest Batches BatchesLatest
 //
 // BatchesLatest
 //
-// return the latest batch of ledger entries
+// return a batch of the ledger entries that have not been entered into the
+// accounting record. By default, the batch covers last month. A different
+// month can be selected by fiscal year and period, or a custom date range
+// can be given. Each call saves a snapshot of the batch, which can be
+// downloaded again later or approved. If the latest pending snapshot of the
+// period has the same content, it is returned instead of saving a new one.
+//
+// The batch is validated before export. If the journal would not balance or
+// an account is missing, the batch is not exported and an error is returned.
+// Use the dry_run parameter to get the validation report instead of the file.
 //
 // ---
+// parameters:
+//   - name: dry_run
+//     in: query
+//     required: false
+//     description: if "true", return the batch validation report instead of generating the batch
+//     type: boolean
+//   - name: fiscal_year
+//     in: query
+//     required: false
+//     description: year of the batch period, as written in the batch header. Requires fiscal_period.
+//     type: integer
+//   - name: fiscal_period
+//     in: query
+//     required: false
+//     description: fiscal period (1-12) of the batch, as written in the batch header. Requires fiscal_year.
+//     type: integer
+//   - name: start
+//     in: query
+//     required: false
+//     description: first day (YYYY-MM-DD) of a custom batch period. Requires end.
+//     type: string
+//   - name: end
+//     in: query
+//     required: false
+//     description: last day (YYYY-MM-DD) of a custom batch period, inclusive. Requires start.
+//     type: string
+//   - name: provider
+//     in: query
+//     required: false
+//     description: accounting export format, one of "sage", "quickbooks", or "journal". Defaults to the
+//       FINANCE_PROVIDER configuration.
+//     type: string
 // responses:
 //   '200':
-//     description: the latest batch of ledger entries
+//     description: the latest batch of ledger entries, or the validation report if dry_run is true
+//     headers:
+//       X-Checksum-SHA256:
+//         type: string
+//         description: hex-encoded SHA-256 hash of the batch file
+//     schema:
+//       "$ref": "#/definitions/BatchValidationReport"
 //     content:
 //       text/csv:
 //         schema:
 //           type: string
 //           format: text
+//       text/plain:
+//         schema:
+//           type: string
+//           format: text
 func batchesGetLatest(c buffalo.Context) error {
 	actor := models.CurrentUser(c)
 	if !actor.IsAdmin() {
@@ -34,12 +89,20 @@ func batchesGetLatest(c buffalo.Context) error {
 		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
 	}
 
+	providerType, err := getFinanceProviderType(c)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	startDate, endDate, err := getBatchPeriod(c)
+	if err != nil {
+		return reportError(c, err)
+	}
+
 	tx := models.Tx(c)
 
-	now := time.Now().UTC()
-	firstDay := domain.BeginningOfLastMonth(now)
 	var le models.LedgerEntries
-	if err := le.AllForMonth(tx, firstDay); err != nil {
+	if err := le.AllForPeriod(tx, startDate, endDate); err != nil {
 		return err
 	}
 
@@ -47,10 +110,21 @@ func batchesGetLatest(c buffalo.Context) error {
 		return c.Render(http.StatusNoContent, nil)
 	}
 
-	csvData := le.ToCsv(firstDay)
-	filename := fmt.Sprintf("batch_%s.csv", firstDay.Format("2006-01"))
+	report := le.ValidateForExport(providerType, startDate)
+	if c.Param("dry_run") == "true" {
+		return renderOk(c, report)
+	}
+	if err := validateBatchReport(report); err != nil {
+		return reportError(c, err)
+	}
+
+	filename := "batch_" + batchPeriodName(startDate, endDate)
+	batch, err := models.NewBatch(c, providerType, api.BatchTypePeriod, startDate, endDate, filename, le)
+	if err != nil {
+		return reportError(c, err)
+	}
 
-	return renderCsv(c, filename, csvData)
+	return renderBatch(c, batch)
 }
 
 // swagger:operation POST /batches/approve Batches BatchesApprove
@@ -58,9 +132,36 @@ func batchesGetLatest(c buffalo.Context) error {
 // BatchesApprove
 //
 // Mark the last batch as accepted. Call this only after the recent batch has
-// been fully loaded into the accounting record.
+// been fully loaded into the accounting record. Only the ledger entries in the
+// most recently generated snapshot of the batch period are reconciled. The
+// period defaults to last month and is selected using the same parameters as
+// BatchesLatest. A period cannot be approved if it overlaps an approved
+// period, unless that approval was reversed. Annual renewal batches are not
+// compared with monthly or custom periods, and are approved with
+// BatchesApproveByID.
 //
 // ---
+// parameters:
+//   - name: fiscal_year
+//     in: query
+//     required: false
+//     description: year of the batch period, as written in the batch header. Requires fiscal_period.
+//     type: integer
+//   - name: fiscal_period
+//     in: query
+//     required: false
+//     description: fiscal period (1-12) of the batch, as written in the batch header. Requires fiscal_year.
+//     type: integer
+//   - name: start
+//     in: query
+//     required: false
+//     description: first day (YYYY-MM-DD) of a custom batch period. Requires end.
+//     type: string
+//   - name: end
+//     in: query
+//     required: false
+//     description: last day (YYYY-MM-DD) of a custom batch period, inclusive. Requires start.
+//     type: string
 // responses:
 //   '200':
 //     description: batch approval confirmation details
@@ -73,37 +174,102 @@ func batchesApprove(c buffalo.Context) error {
 		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
 	}
 
+	startDate, endDate, err := getBatchPeriod(c)
+	if err != nil {
+		return reportError(c, err)
+	}
+
 	tx := models.Tx(c)
 
-	now := time.Now().UTC()
-	firstDay := domain.BeginningOfLastMonth(now)
-	var le models.LedgerEntries
-	if err := le.AllForMonth(tx, firstDay); err != nil {
-		return err
+	var batch models.Batch
+	if err := batch.FindLatestPending(tx, api.BatchTypePeriod, startDate, endDate); err != nil {
+		if domain.IsOtherThanNoRows(err) {
+			return reportError(c, err)
+		}
+		err = fmt.Errorf("no pending batch found for %s", batchPeriodName(startDate, endDate))
+		return reportError(c, api.NewAppError(err, api.ErrorBatchNotFound, api.CategoryUser))
+	}
+
+	return approveBatch(c, &batch)
+}
+
+// swagger:operation GET /batches/annual Batches BatchesAnnualPreview
+//
+// BatchesAnnualPreview
+//
+// Preview the policy renewals for a year. Computes the renewal premium that would be billed for each item, policy
+// and entity code, without creating any ledger entries.
+//
+// ---
+// parameters:
+//   - name: year
+//     in: query
+//     required: false
+//     description: renewal year, defaults to the current year
+//     type: integer
+// responses:
+//   '200':
+//     description: the renewal premium that would be billed
+//     schema:
+//       "$ref": "#/definitions/AnnualRenewalPreview"
+func batchesAnnualPreview(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("user not allowed to preview annual batch data")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
 	}
 
-	if err := le.Reconcile(c); err != nil {
+	year := time.Now().UTC().Year()
+	if y := c.Param("year"); y != "" {
+		var err error
+		year, err = strconv.Atoi(y)
+		if err != nil || year < 1 {
+			return reportError(c, newBatchPeriodError(fmt.Errorf("invalid year '%s'", y)))
+		}
+	}
+
+	preview, err := models.PreviewAnnualCoverage(models.Tx(c), year)
+	if err != nil {
 		return reportError(c, err)
 	}
 
-	return renderOk(c, api.BatchApproveResponse{NumberOfRecordsApproved: len(le)})
+	return renderOk(c, preview)
 }
 
-// swagger:operation GET /batches/annual Batches BatchesAnnual
+// swagger:operation POST /batches/annual Batches BatchesAnnual
 //
 // BatchesAnnual
 //
-// Get the billing detail for current year's policy renewals
+// Process the current year's policy renewals and get the billing detail. A
+// renewal ledger entry is created for each covered item that has not been
+// paid through the current year. The batch is validated before export, and
+// an error is returned if the journal would not balance or an account is
+// missing.
 //
 // ---
+// parameters:
+//   - name: provider
+//     in: query
+//     required: false
+//     description: accounting export format, one of "sage", "quickbooks", or "journal". Defaults to the
+//       FINANCE_PROVIDER configuration.
+//     type: string
 // responses:
 //   '200':
 //     description: the current year policy renewal ledger entries
+//     headers:
+//       X-Checksum-SHA256:
+//         type: string
+//         description: hex-encoded SHA-256 hash of the batch file
 //     content:
 //       text/csv:
 //         schema:
 //           type: string
 //           format: text
+//       text/plain:
+//         schema:
+//           type: string
+//           format: text
 func batchesAnnual(c buffalo.Context) error {
 	actor := models.CurrentUser(c)
 	if !actor.IsAdmin() {
@@ -111,6 +277,11 @@ func batchesAnnual(c buffalo.Context) error {
 		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
 	}
 
+	providerType, err := getFinanceProviderType(c)
+	if err != nil {
+		return reportError(c, err)
+	}
+
 	tx := models.Tx(c)
 
 	currentYear := time.Now().UTC().Year()
@@ -129,16 +300,287 @@ func batchesAnnual(c buffalo.Context) error {
 	}
 
 	date := time.Date(currentYear, 1, 1, 0, 0, 0, 0, time.UTC)
-	csvData := le.ToCsv(date)
-	filename := fmt.Sprintf("renewal_%d.csv", currentYear)
-	return renderCsv(c, filename, csvData)
+
+	if err := validateBatchReport(le.ValidateForExport(providerType, date)); err != nil {
+		return reportError(c, err)
+	}
+
+	filename := fmt.Sprintf("renewal_%d", currentYear)
+	batch, err := models.NewBatch(c, providerType, api.BatchTypeRenewal, date,
+		time.Date(currentYear, 12, 31, 0, 0, 0, 0, time.UTC), filename, le)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderBatch(c, batch)
+}
+
+// swagger:operation GET /batches Batches BatchesList
+//
+// BatchesList
+//
+// List all previously generated batches, most recent first
+//
+// ---
+// responses:
+//   '200':
+//     description: a list of Batches
+//     schema:
+//       "$ref": "#/definitions/Batches"
+func batchesList(c buffalo.Context) error {
+	tx := models.Tx(c)
+
+	var batches models.Batches
+	if err := batches.All(tx); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, batches.ConvertToAPI())
+}
+
+// swagger:operation GET /batches/{id} Batches BatchesView
+//
+// BatchesView
+//
+// View the details of a previously generated batch
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: batch ID
+// responses:
+//   '200':
+//     description: a Batch
+//     schema:
+//       "$ref": "#/definitions/Batch"
+func batchesView(c buffalo.Context) error {
+	batch := getReferencedBatchFromCtx(c)
+	return renderOk(c, batch.ConvertToAPI())
+}
+
+// swagger:operation GET /batches/{id}/file Batches BatchesFile
+//
+// BatchesFile
+//
+// Download the file of a previously generated batch, exactly as it was
+// originally generated
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: batch ID
+// responses:
+//   '200':
+//     description: the batch file
+//     headers:
+//       X-Checksum-SHA256:
+//         type: string
+//         description: hex-encoded SHA-256 hash of the batch file
+//     content:
+//       text/csv:
+//         schema:
+//           type: string
+//           format: text
+//       text/plain:
+//         schema:
+//           type: string
+//           format: text
+func batchesFile(c buffalo.Context) error {
+	batch := getReferencedBatchFromCtx(c)
+	if err := batch.LoadContent(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+	return renderBatch(c, *batch)
+}
+
+// swagger:operation POST /batches/{id}/approve Batches BatchesApproveByID
+//
+// BatchesApproveByID
+//
+// Mark a specific batch as accepted. Call this only after the batch file has
+// been fully loaded into the accounting record. Only the ledger entries in the
+// batch snapshot are reconciled.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: batch ID
+// responses:
+//   '200':
+//     description: batch approval confirmation details
+//     schema:
+//       "$ref": "#/definitions/BatchApproveResponse"
+func batchesApproveByID(c buffalo.Context) error {
+	batch := getReferencedBatchFromCtx(c)
+	return approveBatch(c, batch)
+}
+
+// swagger:operation POST /batches/{id}/reverse Batches BatchesReverse
+//
+// BatchesReverse
+//
+// Reverse the approval of a batch. The reconciled ledger entries in the batch
+// are marked as not entered so they can be included in a new batch, and claims
+// paid by the batch are returned to Approved. If the batch was already posted
+// in the accounting record, compensating ledger entries are created to cancel
+// out the original entries.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: batch ID
+//   - name: batch reverse input
+//     in: body
+//     description: batch reverse input object
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/BatchReverseInput"
+// responses:
+//   '200':
+//     description: batch reversal confirmation details
+//     schema:
+//       "$ref": "#/definitions/BatchReverseResponse"
+func batchesReverse(c buffalo.Context) error {
+	batch := getReferencedBatchFromCtx(c)
+
+	var input api.BatchReverseInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	n, err := batch.Reverse(c, input)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, api.BatchReverseResponse{NumberOfRecordsReversed: n})
+}
+
+func approveBatch(c buffalo.Context, batch *models.Batch) error {
+	n, err := batch.Approve(c)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, api.BatchApproveResponse{NumberOfRecordsApproved: n})
+}
+
+// getReferencedBatchFromCtx pulls the models.Batch resource from context that was put there
+// by the AuthZ middleware
+func getReferencedBatchFromCtx(c buffalo.Context) *models.Batch {
+	batch, ok := c.Value(domain.TypeBatch).(*models.Batch)
+	if !ok {
+		panic("batch not found in context")
+	}
+	return batch
+}
+
+// getFinanceProviderType returns the accounting export provider type given in the "provider" query parameter,
+// or the configured default if none was given
+func getFinanceProviderType(c buffalo.Context) (string, error) {
+	providerType := c.Param("provider")
+	if providerType == "" {
+		providerType = domain.Env.FinanceProvider
+	}
+
+	if !fin.IsValidProviderType(providerType) {
+		err := fmt.Errorf("invalid finance provider type '%s'", providerType)
+		return "", api.NewAppError(err, api.ErrorBatchInvalidProvider, api.CategoryUser)
+	}
+	return providerType, nil
+}
+
+// getBatchPeriod returns the first and last day of the batch period given by the request parameters. The period
+// may be given as a fiscal year and period or as a start and end date. If neither is given, the period is last month.
+func getBatchPeriod(c buffalo.Context) (time.Time, time.Time, error) {
+	fiscalYear, fiscalPeriod := c.Param("fiscal_year"), c.Param("fiscal_period")
+	start, end := c.Param("start"), c.Param("end")
+
+	switch {
+	case fiscalYear != "" || fiscalPeriod != "":
+		year, err := strconv.Atoi(fiscalYear)
+		if err != nil {
+			return time.Time{}, time.Time{}, newBatchPeriodError(fmt.Errorf("invalid fiscal_year '%s'", fiscalYear))
+		}
+		period, err := strconv.Atoi(fiscalPeriod)
+		if err != nil {
+			return time.Time{}, time.Time{}, newBatchPeriodError(fmt.Errorf("invalid fiscal_period '%s'", fiscalPeriod))
+		}
+		startDate, err := fin.FiscalPeriodStart(year, period)
+		if err != nil {
+			return time.Time{}, time.Time{}, newBatchPeriodError(err)
+		}
+		return startDate, domain.EndOfMonth(startDate), nil
+
+	case start != "" || end != "":
+		startDate, err := time.Parse(domain.DateFormat, start)
+		if err != nil {
+			return time.Time{}, time.Time{}, newBatchPeriodError(fmt.Errorf("invalid start date '%s'", start))
+		}
+		endDate, err := time.Parse(domain.DateFormat, end)
+		if err != nil {
+			return time.Time{}, time.Time{}, newBatchPeriodError(fmt.Errorf("invalid end date '%s'", end))
+		}
+		if endDate.Before(startDate) {
+			return time.Time{}, time.Time{}, newBatchPeriodError(fmt.Errorf("end date is before start date"))
+		}
+		return startDate, endDate, nil
+	}
+
+	startDate := domain.BeginningOfLastMonth(time.Now().UTC())
+	startDate = time.Date(startDate.Year(), startDate.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return startDate, domain.EndOfMonth(startDate), nil
+}
+
+// validateBatchReport returns an error if the validation report has any problems that would make the exported
+// journal invalid
+func validateBatchReport(report api.BatchValidationReport) error {
+	if report.IsValid {
+		return nil
+	}
+
+	var n int
+	for _, p := range report.Problems {
+		if !p.IsWarning {
+			n++
+		}
+	}
+	err := fmt.Errorf("batch has %d validation error(s), use dry_run=true to get the validation report", n)
+	return api.NewAppError(err, api.ErrorBatchValidation, api.CategoryUser)
+}
+
+func newBatchPeriodError(err error) error {
+	return api.NewAppError(err, api.ErrorBatchInvalidPeriod, api.CategoryUser)
+}
+
+// batchPeriodName returns a name for the batch period, using only the month if the period is a full month
+func batchPeriodName(startDate, endDate time.Time) string {
+	if startDate.Day() == 1 && endDate.Equal(domain.EndOfMonth(startDate)) {
+		return startDate.Format("2006-01")
+	}
+	return startDate.Format(domain.DateFormat) + "_" + endDate.Format(domain.DateFormat)
+}
+
+// renderBatch writes the batch file as an attachment, with a checksum header so that two downloads of the same
+// batch can be compared
+func renderBatch(c buffalo.Context, batch models.Batch) error {
+	c.Response().Header().Set(batchChecksumHeader, batch.Checksum)
+	return renderFile(c, batch.Filename, batch.ContentType, batch.Content)
 }
 
-func renderCsv(c buffalo.Context, filename string, csvData []byte) error {
+func renderFile(c buffalo.Context, filename, contentType string, data []byte) error {
 	response := c.Response()
-	response.Header().Set("Content-Type", "text/csv")
-	response.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; %s"`, filename))
-	_, err := response.Write(csvData)
+	response.Header().Set("Content-Type", contentType)
+	response.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	_, err := response.Write(data)
 	if err != nil {
 		return err
 	}
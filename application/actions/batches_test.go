@@ -12,6 +12,7 @@ import (
 
 	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/fin"
 	"github.com/silinternational/cover-api/models"
 )
 
@@ -20,9 +21,14 @@ func (as *ActionSuite) Test_BatchesLatest() {
 	normalUser := f.Users[0]
 	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
 
+	lastMonth := domain.BeginningOfLastMonth(time.Now().UTC())
+	fiscalPeriod := (int(lastMonth.Month())-domain.Env.FiscalStartMonth+12)%12 + 1
+	twoMonthsAgo := domain.BeginningOfLastMonth(lastMonth)
+
 	tests := []struct {
 		name       string
 		actor      models.User
+		query      string
 		wantRows   int // rows in CSV, including header rows
 		wantStatus int
 		wantInBody []string
@@ -45,11 +51,59 @@ func (as *ActionSuite) Test_BatchesLatest() {
 			wantStatus: http.StatusOK,
 			wantRows:   5, // 2 header rows, 1 summary row, 1 transaction row, 1 balance row
 		},
+		{
+			name:       "quickbooks provider",
+			actor:      stewardUser,
+			query:      "provider=" + fin.ProviderTypeQuickBooks,
+			wantStatus: http.StatusOK,
+			wantRows:   6, // 3 header rows, 1 transaction row, 1 balance row, 1 end row
+		},
+		{
+			name:       "invalid provider",
+			actor:      stewardUser,
+			query:      "provider=bogus",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorBatchInvalidProvider.String()},
+		},
+		{
+			name:       "dry run",
+			actor:      stewardUser,
+			query:      "dry_run=true",
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"is_valid":true`, `"number_of_entries":1`},
+		},
+		{
+			name:       "fiscal period",
+			actor:      stewardUser,
+			query:      fmt.Sprintf("fiscal_year=%d&fiscal_period=%d", lastMonth.Year(), fiscalPeriod),
+			wantStatus: http.StatusOK,
+			wantRows:   5,
+		},
+		{
+			name:       "date range with no entries",
+			actor:      stewardUser,
+			query:      "start=" + twoMonthsAgo.Format(domain.DateFormat) + "&end=" + twoMonthsAgo.Format(domain.DateFormat),
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "invalid fiscal period",
+			actor:      stewardUser,
+			query:      "fiscal_year=2021&fiscal_period=13",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorBatchInvalidPeriod.String()},
+		},
+		{
+			name:       "end before start",
+			actor:      stewardUser,
+			query:      "start=2021-03-31&end=2021-03-01",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorBatchInvalidPeriod.String()},
+		},
 	}
 
 	for _, tt := range tests {
 		as.T().Run(tt.name, func(t *testing.T) {
-			req := as.JSON(batchesPath + "/latest")
+			req := as.JSON(batchesPath + "/latest?" + tt.query)
 			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
 			res := req.Get()
 
@@ -60,7 +114,7 @@ func (as *ActionSuite) Test_BatchesLatest() {
 				as.Contains(body, s)
 			}
 
-			if res.Code != http.StatusOK {
+			if res.Code != http.StatusOK || tt.wantRows == 0 {
 				return
 			}
 
@@ -75,6 +129,8 @@ func (as *ActionSuite) Test_BatchesApprove() {
 	normalUser := f.Users[0]
 	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
 
+	as.createBatchFixture(stewardUser)
+
 	tests := []struct {
 		name       string
 		actor      models.User
@@ -100,6 +156,12 @@ func (as *ActionSuite) Test_BatchesApprove() {
 			wantStatus: http.StatusOK,
 			want:       1,
 		},
+		{
+			name:       "no pending batch",
+			actor:      stewardUser,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorBatchNotFound.String()},
+		},
 	}
 
 	for _, tt := range tests {
@@ -126,7 +188,7 @@ func (as *ActionSuite) Test_BatchesApprove() {
 			as.Equal(tt.want, response.NumberOfRecordsApproved, "incorrect number of approved records")
 
 			var le models.LedgerEntries
-			as.NoError(as.DB.Where("item_id = ?", f.Items[1].ID).All(&le))
+			as.NoError(as.DB.Where("item_id = ? AND type = ?", f.Items[1].ID, models.LedgerEntryTypeNewCoverage).All(&le))
 			as.Equal(1, len(le), "something is not right with the test setup")
 			for i := range le {
 				as.True(le[i].DateEntered.Valid, "ledger entry DateEntered was not set")
@@ -178,7 +240,7 @@ func (as *ActionSuite) Test_BatchesAnnual() {
 		as.T().Run(tt.name, func(t *testing.T) {
 			req := as.JSON(batchesPath + "/annual")
 			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
-			res := req.Get()
+			res := req.Post(nil)
 
 			body := res.Body.String()
 			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
@@ -197,6 +259,268 @@ func (as *ActionSuite) Test_BatchesAnnual() {
 	}
 }
 
+func (as *ActionSuite) Test_BatchesAnnualPreview() {
+	year := time.Now().UTC().Year()
+
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{ItemsPerPolicy: 3})
+
+	f.Items[0].PaidThroughYear = year
+	models.UpdateItemStatus(as.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+	models.UpdateItemStatus(as.DB, f.Items[1], api.ItemCoverageStatusApproved, "")
+
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		query      string
+		wantStatus int
+		wantInBody []string
+		wantItems  int
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			wantStatus: http.StatusUnauthorized,
+			wantInBody: []string{api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "invalid year",
+			actor:      stewardUser,
+			query:      "?year=next",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorBatchInvalidPeriod.String()},
+		},
+		{
+			name:       "current year",
+			actor:      stewardUser,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{f.Items[1].ID.String()},
+			wantItems:  1,
+		},
+		{
+			name:       "next year",
+			actor:      stewardUser,
+			query:      fmt.Sprintf("?year=%d", year+1),
+			wantStatus: http.StatusOK,
+			wantItems:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(batchesPath + "/annual" + tt.query)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			for _, s := range tt.wantInBody {
+				as.Contains(body, s)
+			}
+
+			if res.Code != http.StatusOK {
+				return
+			}
+
+			var preview api.AnnualRenewalPreview
+			as.NoError(json.Unmarshal([]byte(body), &preview))
+			as.Equal(tt.wantItems, preview.NumberOfItems, "incorrect NumberOfItems")
+		})
+	}
+
+	var entries models.LedgerEntries
+	as.NoError(entries.FindCurrentRenewals(as.DB, year))
+	as.Equal(0, len(entries), "preview should not create renewal ledger entries")
+}
+
+func (as *ActionSuite) Test_BatchesFile() {
+	f := as.createFixturesForBatches()
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	batch := as.createBatchFixture(stewardUser)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			wantStatus: http.StatusUnauthorized,
+			wantInBody: []string{api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "steward",
+			actor:      stewardUser,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{string(batch.Content)},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("%s/%s/%s", batchesPath, batch.ID, api.ResourceFile)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			for _, s := range tt.wantInBody {
+				as.Contains(body, s)
+			}
+
+			if res.Code != http.StatusOK {
+				return
+			}
+
+			as.Equal(batch.ContentType, res.Header().Get("Content-Type"))
+			as.Contains(res.Header().Get("Content-Disposition"), batch.Filename)
+			as.Equal(batch.Checksum, res.Header().Get(batchChecksumHeader))
+		})
+	}
+}
+
+func (as *ActionSuite) Test_BatchesList() {
+	f := as.createFixturesForBatches()
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	batch := as.createBatchFixture(stewardUser)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "steward",
+			actor:      stewardUser,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{batch.ID.String(), batch.Filename},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(batchesPath)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			for _, s := range tt.wantInBody {
+				as.Contains(body, s)
+			}
+		})
+	}
+}
+
+func (as *ActionSuite) Test_BatchesReverse() {
+	f := as.createFixturesForBatches()
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	pendingBatch := as.createBatchFixture(stewardUser)
+	approvedBatch := as.createBatchFixture(stewardUser)
+	_, err := approvedBatch.Approve(models.CreateTestContext(stewardUser))
+	as.NoError(err)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		batch      models.Batch
+		want       int // reversed records
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			batch:      approvedBatch,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "not approved",
+			actor:      stewardUser,
+			batch:      pendingBatch,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorBatchNotApproved.String()},
+		},
+		{
+			name:       "approved",
+			actor:      stewardUser,
+			batch:      approvedBatch,
+			wantStatus: http.StatusOK,
+			want:       1,
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("%s/%s/%s", batchesPath, tt.batch.ID, api.ResourceReverse)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			res := req.Post(api.BatchReverseInput{Posted: true, Reason: "test"})
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			for _, s := range tt.wantInBody {
+				as.Contains(body, s)
+			}
+
+			if res.Code != http.StatusOK {
+				return
+			}
+
+			var response api.BatchReverseResponse
+			as.NoError(json.Unmarshal([]byte(body), &response))
+			as.Equal(tt.want, response.NumberOfRecordsReversed, "incorrect number of reversed records")
+		})
+	}
+}
+
+// createBatchFixture creates a Batch snapshot of last month's ledger entries
+func (as *ActionSuite) createBatchFixture(actor models.User) models.Batch {
+	lastMonth := domain.BeginningOfLastMonth(time.Now().UTC())
+	lastMonth = time.Date(lastMonth.Year(), lastMonth.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	var le models.LedgerEntries
+	as.NoError(le.AllForMonth(as.DB, lastMonth))
+
+	batch, err := models.NewBatch(models.CreateTestContext(actor), fin.ProviderTypeSage, api.BatchTypePeriod,
+		lastMonth, domain.EndOfMonth(lastMonth), "batch", le)
+	as.NoError(err)
+	return batch
+}
+
 func (as *ActionSuite) createFixturesForBatches() models.Fixtures {
 	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{ItemsPerPolicy: 2})
 
@@ -214,7 +538,7 @@ func (as *ActionSuite) createFixturesForBatches() models.Fixtures {
 		as.NoError(f.Items[i].Approve(ctx, false))
 
 		entry := models.LedgerEntry{}
-		as.NoError(as.DB.Where("item_id = ?", f.Items[i].ID).First(&entry))
+		as.NoError(as.DB.Where("item_id = ? AND type = ?", f.Items[i].ID, models.LedgerEntryTypeNewCoverage).First(&entry))
 		entry.DateSubmitted = datesSubmitted[i]
 		entry.DateEntered = datesEntered[i]
 		as.NoError(as.DB.Update(&entry))
@@ -0,0 +1,116 @@
+package actions
+
+import (
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /claims/{id}/adjustments ClaimAdjustments ClaimAdjustmentsList
+//
+// ClaimAdjustmentsList
+//
+// Admin lists the payout adjustments of a claim, oldest first
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim ID
+// responses:
+//   '200':
+//     description: a list of ClaimAdjustments
+//     schema:
+//       "$ref": "#/definitions/ClaimAdjustments"
+func claimAdjustmentsList(c buffalo.Context) error {
+	tx := models.Tx(c)
+	claim := getReferencedClaimFromCtx(c)
+
+	var adjustments models.ClaimAdjustments
+	if err := adjustments.FindByClaim(tx, claim.ID); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, adjustments.ConvertToAPI())
+}
+
+// swagger:operation POST /claim-items/{id}/adjustments ClaimAdjustments ClaimAdjustmentsCreate
+//
+// ClaimAdjustmentsCreate
+//
+// Admin requests a change to the payout of a claim item after the claim was approved. The change takes effect when
+// it is approved by a different admin.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim item ID
+//   - name: claim adjustment input
+//     in: body
+//     description: claim adjustment input object
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/ClaimAdjustmentInput"
+// responses:
+//   '200':
+//     description: the new ClaimAdjustment
+//     schema:
+//       "$ref": "#/definitions/ClaimAdjustment"
+func claimAdjustmentsCreate(c buffalo.Context) error {
+	claimItem := getReferencedClaimItemFromCtx(c)
+
+	var input api.ClaimAdjustmentInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	adjustment, err := models.NewClaimAdjustment(c, *claimItem, input)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, adjustment.ConvertToAPI())
+}
+
+// swagger:operation POST /claim-adjustments/{id}/approve ClaimAdjustments ClaimAdjustmentsApprove
+//
+// ClaimAdjustmentsApprove
+//
+// Admin approves a payout adjustment requested by a different admin. The payouts of the claim item and the claim
+// are updated, and a ledger entry is created for any difference from the amount already disbursed.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim adjustment ID
+// responses:
+//   '200':
+//     description: the approved ClaimAdjustment
+//     schema:
+//       "$ref": "#/definitions/ClaimAdjustment"
+func claimAdjustmentsApprove(c buffalo.Context) error {
+	adjustment := getReferencedClaimAdjustmentFromCtx(c)
+
+	if err := adjustment.Approve(c); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, adjustment.ConvertToAPI())
+}
+
+// getReferencedClaimAdjustmentFromCtx pulls the models.ClaimAdjustment resource from context that was put there
+// by the AuthZ middleware
+func getReferencedClaimAdjustmentFromCtx(c buffalo.Context) *models.ClaimAdjustment {
+	adjustment, ok := c.Value(domain.TypeClaimAdjustment).(*models.ClaimAdjustment)
+	if !ok {
+		panic("claim adjustment not found in context")
+	}
+	return adjustment
+}
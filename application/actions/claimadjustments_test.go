@@ -0,0 +1,151 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_ClaimAdjustmentsCreate() {
+	fixConfig := models.FixturesConfig{
+		NumberOfPolicies:   1,
+		ItemsPerPolicy:     1,
+		ClaimsPerPolicy:    1,
+		ClaimItemsPerClaim: 1,
+	}
+
+	fixtures := models.CreateItemFixtures(as.DB, fixConfig)
+	policy := fixtures.Policies[0]
+	policyCreator := policy.Members[0]
+
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	approvedClaim := models.UpdateClaimStatus(as.DB, policy.Claims[0], api.ClaimStatusApproved, "")
+	claimItem := approvedClaim.ClaimItems[0]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		input      api.ClaimAdjustmentInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "non-admin user",
+			actor:      policyCreator,
+			input:      api.ClaimAdjustmentInput{PayoutAmount: 1000, Reason: "receipt"},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "same amount",
+			actor:      steward,
+			input:      api.ClaimAdjustmentInput{PayoutAmount: claimItem.PayoutAmount, Reason: "receipt"},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorClaimAdjustmentAmount.String()},
+		},
+		{
+			name:       "good",
+			actor:      steward,
+			input:      api.ClaimAdjustmentInput{PayoutAmount: 1000, Reason: "receipt"},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"claim_item_id":"` + claimItem.ID.String(),
+				fmt.Sprintf(`"old_payout_amount":%d`, claimItem.PayoutAmount),
+				`"new_payout_amount":1000`,
+				`"reason":"receipt"`,
+				`"requested_by_id":"` + steward.ID.String(),
+				`"approved_at":null`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/%s/%s/%s",
+				domain.TypeClaimItem, claimItem.ID.String(), api.ResourceAdjustments)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Post(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_ClaimAdjustmentsApprove() {
+	fixConfig := models.FixturesConfig{
+		NumberOfPolicies:   1,
+		ItemsPerPolicy:     1,
+		ClaimsPerPolicy:    1,
+		ClaimItemsPerClaim: 1,
+	}
+
+	fixtures := models.CreateItemFixtures(as.DB, fixConfig)
+	policy := fixtures.Policies[0]
+	policyCreator := policy.Members[0]
+
+	admins := models.CreateAdminUsers(as.DB)
+	steward := admins[models.AppRoleSteward]
+	signator := admins[models.AppRoleSignator]
+
+	approvedClaim := models.UpdateClaimStatus(as.DB, policy.Claims[0], api.ClaimStatusApproved, "")
+	adjustment, err := models.NewClaimAdjustment(models.CreateTestContext(steward), approvedClaim.ClaimItems[0],
+		api.ClaimAdjustmentInput{PayoutAmount: 1000, Reason: "receipt"})
+	as.NoError(err)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "non-admin user",
+			actor:      policyCreator,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "requested by the same admin",
+			actor:      steward,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorClaimAdjustmentInvalidApprover.String()},
+		},
+		{
+			name:       "good",
+			actor:      signator,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"id":"` + adjustment.ID.String(),
+				`"approved_by_id":"` + signator.ID.String(),
+			},
+		},
+		{
+			name:       "already approved",
+			actor:      signator,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorClaimAdjustmentAlreadyApproved.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/%s/%s/%s",
+				domain.TypeClaimAdjustment, adjustment.ID.String(), api.ResourceApprove)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Post(nil)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
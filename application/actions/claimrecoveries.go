@@ -0,0 +1,77 @@
+package actions
+
+import (
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /claims/{id}/recoveries ClaimRecoveries ClaimRecoveriesList
+//
+// ClaimRecoveriesList
+//
+// Admin lists the money recovered for a claim, oldest first
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim ID
+// responses:
+//   '200':
+//     description: a list of ClaimRecoveries
+//     schema:
+//       "$ref": "#/definitions/ClaimRecoveries"
+func claimRecoveriesList(c buffalo.Context) error {
+	tx := models.Tx(c)
+	claim := getReferencedClaimFromCtx(c)
+
+	var recoveries models.ClaimRecoveries
+	if err := recoveries.FindByClaim(tx, claim.ID); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, recoveries.ConvertToAPI())
+}
+
+// swagger:operation POST /claims/{id}/recoveries ClaimRecoveries ClaimRecoveriesCreate
+//
+// ClaimRecoveriesCreate
+//
+// Admin records money recovered for an approved or paid claim, such as a reimbursement from a third party. A credit
+// ledger entry is created for the next batch. The total recovered cannot exceed the claim payout.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim ID
+//   - name: claim recovery input
+//     in: body
+//     description: claim recovery input object
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/ClaimRecoveryInput"
+// responses:
+//   '200':
+//     description: the new ClaimRecovery
+//     schema:
+//       "$ref": "#/definitions/ClaimRecovery"
+func claimRecoveriesCreate(c buffalo.Context) error {
+	claim := getReferencedClaimFromCtx(c)
+
+	var input api.ClaimRecoveryInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	recovery, err := claim.AddRecovery(c, input)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, recovery.ConvertToAPI())
+}
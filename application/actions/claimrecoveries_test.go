@@ -0,0 +1,100 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_ClaimRecoveriesCreate() {
+	fixConfig := models.FixturesConfig{
+		NumberOfPolicies:   1,
+		ItemsPerPolicy:     2,
+		ClaimsPerPolicy:    2,
+		ClaimItemsPerClaim: 1,
+	}
+
+	fixtures := models.CreateItemFixtures(as.DB, fixConfig)
+	policy := fixtures.Policies[0]
+	policyCreator := policy.Members[0]
+
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	review3Claim := models.UpdateClaimStatus(as.DB, policy.Claims[0], api.ClaimStatusReview3, "")
+	policy.Claims[1].TotalPayout = 5000
+	paidClaim := models.UpdateClaimStatus(as.DB, policy.Claims[1], api.ClaimStatusPaid, "")
+
+	input := api.ClaimRecoveryInput{
+		Source:       "Acme Insurance",
+		Amount:       1000,
+		RecoveryDate: time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC),
+		Notes:        "third party reimbursement",
+	}
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		claim      models.Claim
+		input      api.ClaimRecoveryInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "non-admin user",
+			actor:      policyCreator,
+			claim:      paidClaim,
+			input:      input,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "not approved",
+			actor:      steward,
+			claim:      review3Claim,
+			input:      input,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorClaimStatus.String()},
+		},
+		{
+			name:       "more than the payout",
+			actor:      steward,
+			claim:      paidClaim,
+			input:      api.ClaimRecoveryInput{Source: input.Source, Amount: 5001, RecoveryDate: input.RecoveryDate},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorClaimRecoveryAmount.String()},
+		},
+		{
+			name:       "good",
+			actor:      steward,
+			claim:      paidClaim,
+			input:      input,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"claim_id":"` + paidClaim.ID.String(),
+				`"source":"Acme Insurance"`,
+				`"amount":1000`,
+				`"notes":"third party reimbursement"`,
+				`"created_by_id":"` + steward.ID.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/%s/%s/%s",
+				domain.TypeClaim, tt.claim.ID.String(), api.ResourceRecoveries)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Post(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
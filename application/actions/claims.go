@@ -19,7 +19,8 @@ import (
 // status values. For a user, all status values are included by default.
 // For an admin (steward or signator) only the review status values are
 // included by default. Accepted status values: Draft, Review1, Review2,
-// Review3, Revision, Receipt, Approved, Paid, Denied
+// Review3, Revision, Receipt, Approved, Paid, Denied. An admin may instead
+// list the escalated claims that are within their approval limit.
 //
 // ---
 // parameters:
@@ -27,17 +28,41 @@ import (
 //   in: query
 //   required: false
 //   description: comma-separated list of status values to include
+// - name: escalated
+//   in: query
+//   required: false
+//   description: if true, list only the escalated claims the admin may approve, sorted by escalated_at by default
+// - name: limit
+//   in: query
+//   required: false
+//   description: maximum number of records in a page, from 1 to 50, default 10. If neither limit nor cursor
+//     is given, all the records are returned in an array rather than a page.
+// - name: cursor
+//   in: query
+//   required: false
+//   description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+// - name: sort
+//   in: query
+//   required: false
+//   description: field by which to sort, prefixed by "-" for descending order. One of
+//     created_at (default), updated_at, incident_date, reference_number, status, total_payout
+// - name: filter
+//   in: query
+//   required: false
+//   description: comma-separated list of pairs like "field:value". Supported fields: status, incident_type
 // responses:
 //   '200':
-//     description: a list of Claims
+//     description: all Claims, or a page with "meta" and "data" if a limit or cursor is given
 //     schema:
-//       type: array
-//       items:
-//         "$ref": "#/definitions/Claim"
+//       "$ref": "#/definitions/Claims"
 func claimsList(c buffalo.Context) error {
 	user := models.CurrentUser(c)
 
 	if user.IsAdmin() {
+		if c.Param("escalated") == "true" {
+			return claimsListEscalated(c, user)
+		}
+
 		statusParam := c.Param("status")
 		var statusList []string
 		if statusParam != "" {
@@ -57,18 +82,39 @@ func claimsListAdmin(c buffalo.Context, statuses []api.ClaimStatus) error {
 	tx := models.Tx(c)
 	var claims models.Claims
 
-	if err := claims.ByStatus(tx, statuses); err != nil {
+	query := api.NewQuery(c.Params())
+	meta, err := claims.ByStatus(tx, statuses, query)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderList(c, query, meta, claims.ConvertToAPI(tx))
+}
+
+func claimsListEscalated(c buffalo.Context, user models.User) error {
+	tx := models.Tx(c)
+	var claims models.Claims
+
+	query := api.NewQuery(c.Params())
+	meta, err := claims.EscalatedFor(tx, user, query)
+	if err != nil {
 		return reportError(c, err)
 	}
 
-	return renderOk(c, claims.ConvertToAPI(tx))
+	return renderList(c, query, meta, claims.ConvertToAPI(tx))
 }
 
 func claimsListCustomer(c buffalo.Context) error {
 	tx := models.Tx(c)
-	currentUser := models.CurrentUser(c)
-	claims := currentUser.MyClaims(models.Tx(c))
-	return renderOk(c, claims.ConvertToAPI(tx))
+	var claims models.Claims
+
+	query := api.NewQuery(c.Params())
+	meta, err := claims.ByUser(tx, models.CurrentUser(c), query)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderList(c, query, meta, claims.ConvertToAPI(tx))
 }
 
 // swagger:operation GET /policies/{id}/claims Claims PolicyClaimsList
@@ -78,21 +124,47 @@ func claimsListCustomer(c buffalo.Context) error {
 // List claims for a given policy
 //
 // ---
+// parameters:
+// - name: id
+//   in: path
+//   required: true
+//   description: policy ID
+// - name: limit
+//   in: query
+//   required: false
+//   description: maximum number of records in a page, from 1 to 50, default 10. If neither limit nor cursor
+//     is given, all the records are returned in an array rather than a page.
+// - name: cursor
+//   in: query
+//   required: false
+//   description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+// - name: sort
+//   in: query
+//   required: false
+//   description: field by which to sort, prefixed by "-" for descending order. One of
+//     created_at (default), updated_at, incident_date, reference_number, status, total_payout
+// - name: filter
+//   in: query
+//   required: false
+//   description: comma-separated list of pairs like "field:value". Supported fields: status, incident_type
 // responses:
 //   '200':
-//     description: a list of Claims
+//     description: all Claims, or a page with "meta" and "data" if a limit or cursor is given
 //     schema:
-//       type: array
-//       items:
-//         "$ref": "#/definitions/Claim"
+//       "$ref": "#/definitions/Claims"
 func policiesClaimsList(c buffalo.Context) error {
 	policy := getReferencedPolicyFromCtx(c)
 
 	tx := models.Tx(c)
+	var claims models.Claims
 
-	policy.LoadClaims(tx, false)
+	query := api.NewQuery(c.Params())
+	meta, err := claims.ByPolicy(tx, policy.ID, query)
+	if err != nil {
+		return reportError(c, err)
+	}
 
-	return renderOk(c, policy.Claims.ConvertToAPI(tx))
+	return renderList(c, query, meta, claims.ConvertToAPI(tx))
 }
 
 // swagger:operation GET /claims/{id} Claims ClaimsView
@@ -345,7 +417,10 @@ func claimsRequestReceipt(c buffalo.Context) error {
 //
 // ClaimsApprove
 //
-// Admin approves a claim.  Can be used at states "Review1","Review2","Review3".
+// Admin approves a claim.  Can be used at states "Review1","Review2","Review3". On final approval, the payout is
+// disbursed in full unless the amount of a first installment is given. If the payout is over the admin's approval
+// limit, no approval is recorded. Instead, the claim is escalated to the signators who can approve it and the
+// response status is 202.
 //
 // ---
 // parameters:
@@ -353,17 +428,84 @@ func claimsRequestReceipt(c buffalo.Context) error {
 //     in: path
 //     required: true
 //     description: claim ID
+//   - name: claim approve input
+//     in: body
+//     description: claim approve input object
+//     required: false
+//     schema:
+//       "$ref": "#/definitions/ClaimApproveInput"
 // responses:
 //   '200':
 //     description: Claim in focus
 //     schema:
 //       "$ref": "#/definitions/Claim"
+//   '202':
+//     description: Claim in focus, escalated instead of approved
+//     schema:
+//       "$ref": "#/definitions/Claim"
 func claimsApprove(c buffalo.Context) error {
 	tx := models.Tx(c)
 
 	claim := getReferencedClaimFromCtx(c)
 
-	if err := claim.Approve(c); err != nil {
+	// the input is optional
+	var input api.ClaimApproveInput
+	if c.Request().ContentLength > 0 {
+		if err := StrictBind(c, &input); err != nil {
+			return reportError(c, err)
+		}
+	}
+
+	wasEscalated := claim.EscalatedAt.Valid
+	if err := claim.Approve(c, input); err != nil {
+		return reportError(c, err)
+	}
+
+	output := claim.ConvertToAPI(tx)
+
+	// The payout was over the user's approval limit, so the claim was escalated rather than approved
+	if claim.EscalatedAt.Valid && !wasEscalated {
+		return c.Render(http.StatusAccepted, r.JSON(output))
+	}
+	return c.Render(http.StatusOK, r.JSON(output))
+}
+
+// swagger:operation POST /claims/{id}/disbursements Claims ClaimsDisburse
+//
+// ClaimsDisburse
+//
+// Admin disburses an installment of the payout of an approved claim. The amount is shared among the claim items in
+// proportion to their undisbursed payouts. The claim is marked as paid once all disbursements have been entered
+// into the accounting system.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim ID
+//   - name: claim disbursement input
+//     in: body
+//     description: claim disbursement input object
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/ClaimDisbursementInput"
+// responses:
+//   '200':
+//     description: Claim in focus
+//     schema:
+//       "$ref": "#/definitions/Claim"
+func claimsDisburse(c buffalo.Context) error {
+	tx := models.Tx(c)
+
+	claim := getReferencedClaimFromCtx(c)
+
+	var input api.ClaimDisbursementInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	if err := claim.Disburse(tx, input.Amount); err != nil {
 		return reportError(c, err)
 	}
 
@@ -412,6 +554,90 @@ func claimsDeny(c buffalo.Context) error {
 	return c.Render(http.StatusOK, r.JSON(output))
 }
 
+// swagger:operation POST /claims/{id}/withdraw Claims ClaimsWithdraw
+//
+// ClaimsWithdraw
+//
+// Member withdraws a claim that was filed by mistake. Can be used at states "Draft", "Review1", "Revision", and
+// "Receipt". Only a member of the claim's policy may withdraw it; stewards and signators may not.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim ID
+//   - name: claim withdraw input
+//     in: body
+//     description: claim withdraw input object, with an optional reason
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/ClaimStatusInput"
+// responses:
+//   '200':
+//     description: Claim in focus
+//     schema:
+//       "$ref": "#/definitions/Claim"
+func claimsWithdraw(c buffalo.Context) error {
+	tx := models.Tx(c)
+
+	claim := getReferencedClaimFromCtx(c)
+
+	var input api.ClaimStatusInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	if err := claim.Withdraw(c, input.StatusReason); err != nil {
+		return reportError(c, err)
+	}
+
+	output := claim.ConvertToAPI(tx)
+	return c.Render(http.StatusOK, r.JSON(output))
+}
+
+// swagger:operation POST /claims/{id}/appeal Claims ClaimsAppeal
+//
+// ClaimsAppeal
+//
+// Member appeals a denied claim, returning it to "Review1". A claim can be appealed once, within the configured
+// number of days after it was denied. The appeal must be reviewed by someone other than the admin who denied it.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim ID
+//   - name: claim appeal input
+//     in: body
+//     description: claim appeal input object
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/ClaimAppealInput"
+// responses:
+//   '200':
+//     description: Claim in focus
+//     schema:
+//       "$ref": "#/definitions/Claim"
+func claimsAppeal(c buffalo.Context) error {
+	tx := models.Tx(c)
+
+	claim := getReferencedClaimFromCtx(c)
+
+	var input api.ClaimAppealInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	if err := claim.Appeal(c, input); err != nil {
+		return reportError(c, err)
+	}
+
+	output := claim.ConvertToAPI(tx)
+	return c.Render(http.StatusOK, r.JSON(output))
+}
+
 // swagger:operation POST /claims/{id}/items Claims ClaimsItemsCreate
 //
 // ClaimsItemsCreate
@@ -929,7 +929,7 @@ func (as *ActionSuite) Test_ClaimsApprove() {
 		ItemsPerPolicy:      2,
 		UsersPerPolicy:      1,
 		DependentsPerPolicy: 0,
-		ClaimsPerPolicy:     4,
+		ClaimsPerPolicy:     5,
 		ClaimItemsPerClaim:  1,
 	}
 
@@ -939,6 +939,9 @@ func (as *ActionSuite) Test_ClaimsApprove() {
 
 	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
 	signator := models.CreateAdminUsers(as.DB)[models.AppRoleSignator]
+	limitedSignator := models.CreateAdminUsers(as.DB)[models.AppRoleSignator]
+	limit := api.Currency(100)
+	as.NoError(limitedSignator.SetApprovalLimit(as.DB, &limit))
 
 	draftClaim := policy.Claims[0]
 
@@ -957,6 +960,15 @@ func (as *ActionSuite) Test_ClaimsApprove() {
 	review3Claim.ReviewerID = nulls.NewUUID(steward.ID)
 	as.NoError(as.DB.Update(&review3Claim), "error updating claim fixture")
 
+	overLimitClaim := models.UpdateClaimStatus(as.DB, policy.Claims[4], api.ClaimStatusReview3, "")
+	overLimitClaim.ReviewerID = nulls.NewUUID(steward.ID)
+	overLimitClaim.TotalPayout = 2000
+	as.NoError(as.DB.Update(&overLimitClaim), "error updating claim fixture")
+	models.UpdateClaimItems(as.DB, overLimitClaim, models.UpdateClaimItemsParams{
+		PayoutOption: api.PayoutOptionFMV,
+		FMV:          2000,
+	})
+
 	tests := []struct {
 		name            string
 		actor           models.User
@@ -1028,6 +1040,17 @@ func (as *ActionSuite) Test_ClaimsApprove() {
 				`"reviewer_id":"` + signator.ID.String(),
 			},
 		},
+		{
+			name:            "review3 over the approval limit is escalated",
+			actor:           limitedSignator,
+			oldClaim:        overLimitClaim,
+			wantStatus:      http.StatusAccepted,
+			wantClaimStatus: api.ClaimStatusReview3,
+			wantInBody: []string{
+				`"status":"` + string(api.ClaimStatusReview3),
+				`"escalated_at":"` + time.Now().UTC().Format(domain.DateFormat),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -1043,7 +1066,7 @@ func (as *ActionSuite) Test_ClaimsApprove() {
 
 			as.verifyResponseData(tt.wantInBody, body, "")
 
-			if res.Code != http.StatusOK {
+			if res.Code != http.StatusOK && res.Code != http.StatusAccepted {
 				return
 			}
 
@@ -1056,6 +1079,89 @@ func (as *ActionSuite) Test_ClaimsApprove() {
 	}
 }
 
+func (as *ActionSuite) Test_ClaimsDisburse() {
+	fixConfig := models.FixturesConfig{
+		NumberOfPolicies:   1,
+		ItemsPerPolicy:     1,
+		ClaimsPerPolicy:    2,
+		ClaimItemsPerClaim: 1,
+	}
+
+	fixtures := models.CreateItemFixtures(as.DB, fixConfig)
+	policy := fixtures.Policies[0]
+	policyCreator := policy.Members[0]
+
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	review3Claim := models.UpdateClaimStatus(as.DB, policy.Claims[0], api.ClaimStatusReview3, "")
+	approvedClaim := models.UpdateClaimStatus(as.DB, policy.Claims[1], api.ClaimStatusApproved, "")
+	payout := approvedClaim.ClaimItems[0].PayoutAmount
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		claim      models.Claim
+		input      api.ClaimDisbursementInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "non-admin user",
+			actor:      policyCreator,
+			claim:      approvedClaim,
+			input:      api.ClaimDisbursementInput{Amount: 1000},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "not approved",
+			actor:      steward,
+			claim:      review3Claim,
+			input:      api.ClaimDisbursementInput{Amount: 1000},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorClaimStatus.String()},
+		},
+		{
+			name:       "more than the payout",
+			actor:      steward,
+			claim:      approvedClaim,
+			input:      api.ClaimDisbursementInput{Amount: payout + 1},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorClaimDisbursement.String()},
+		},
+		{
+			name:       "advance",
+			actor:      steward,
+			claim:      approvedClaim,
+			input:      api.ClaimDisbursementInput{Amount: 1000},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"total_disbursed":1000`},
+		},
+		{
+			name:       "settlement",
+			actor:      steward,
+			claim:      approvedClaim,
+			input:      api.ClaimDisbursementInput{},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{fmt.Sprintf(`"total_disbursed":%d`, payout)},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/%s/%s/%s",
+				domain.TypeClaim, tt.claim.ID.String(), api.ResourceDisbursements)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Post(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
 func (as *ActionSuite) Test_ClaimsDeny() {
 	fixConfig := models.FixturesConfig{
 		NumberOfPolicies:    2,
@@ -1167,3 +1273,157 @@ func (as *ActionSuite) Test_ClaimsDeny() {
 		})
 	}
 }
+
+func (as *ActionSuite) Test_ClaimsAppeal() {
+	fixConfig := models.FixturesConfig{
+		NumberOfPolicies:    2,
+		ItemsPerPolicy:      2,
+		UsersPerPolicy:      1,
+		DependentsPerPolicy: 0,
+		ClaimsPerPolicy:     2,
+		ClaimItemsPerClaim:  1,
+	}
+
+	fixtures := models.CreateItemFixtures(as.DB, fixConfig)
+	policy := fixtures.Policies[0]
+	policyCreator := policy.Members[0]
+	otherUser := fixtures.Policies[1].Members[0]
+
+	review1Claim := models.UpdateClaimStatus(as.DB, policy.Claims[0], api.ClaimStatusReview1, "")
+	deniedClaim := models.UpdateClaimStatus(as.DB, policy.Claims[1], api.ClaimStatusDenied, "no police report")
+	deniedClaim.ReviewDate = nulls.NewTime(time.Now().UTC())
+	as.NoError(as.DB.Update(&deniedClaim))
+
+	const reason = "I found the police report"
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		oldClaim   models.Claim
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "other policy user",
+			actor:      otherUser,
+			oldClaim:   deniedClaim,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "bad start status",
+			actor:      policyCreator,
+			oldClaim:   review1Claim,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorClaimStatus.String()},
+		},
+		{
+			name:       "denied to review1",
+			actor:      policyCreator,
+			oldClaim:   deniedClaim,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"status":"` + string(api.ClaimStatusReview1),
+				`"status_change":"` + models.ClaimStatusChangeAppealed + policyCreator.Name(),
+				`"appealed_at":"` + time.Now().UTC().Format(domain.DateFormat),
+				`"appeal_reason":"` + reason,
+			},
+		},
+		{
+			name:       "already appealed",
+			actor:      policyCreator,
+			oldClaim:   deniedClaim,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorClaimStatus.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/%s/%s/%s",
+				domain.TypeClaim, tt.oldClaim.ID.String(), api.ResourceAppeal)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Post(api.ClaimAppealInput{AppealReason: reason})
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_ClaimsWithdraw() {
+	fixConfig := models.FixturesConfig{
+		NumberOfPolicies:    2,
+		ItemsPerPolicy:      2,
+		UsersPerPolicy:      1,
+		DependentsPerPolicy: 0,
+		ClaimsPerPolicy:     2,
+		ClaimItemsPerClaim:  1,
+	}
+
+	fixtures := models.CreateItemFixtures(as.DB, fixConfig)
+	policy := fixtures.Policies[0]
+	policyCreator := policy.Members[0]
+	otherUser := fixtures.Policies[1].Members[0]
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	review1Claim := models.UpdateClaimStatus(as.DB, policy.Claims[0], api.ClaimStatusReview1, "")
+	approvedClaim := models.UpdateClaimStatus(as.DB, policy.Claims[1], api.ClaimStatusApproved, "")
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		oldClaim   models.Claim
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "other policy user",
+			actor:      otherUser,
+			oldClaim:   review1Claim,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			oldClaim:   review1Claim,
+			wantStatus: http.StatusForbidden,
+			wantInBody: []string{api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "bad start status",
+			actor:      policyCreator,
+			oldClaim:   approvedClaim,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorClaimStatus.String()},
+		},
+		{
+			name:       "review1 to withdrawn",
+			actor:      policyCreator,
+			oldClaim:   review1Claim,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"status":"` + string(api.ClaimStatusWithdrawn),
+				`"status_change":"` + models.ClaimStatusChangeWithdrawn + policyCreator.Name(),
+				`"status_reason":"filed by mistake"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/%s/%s/%s",
+				domain.TypeClaim, tt.oldClaim.ID.String(), api.ResourceWithdraw)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Post(api.ClaimStatusInput{StatusReason: "filed by mistake"})
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			as.verifyResponseData(tt.wantInBody, body, "")
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package actions
+
+import (
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /deductible-rules DeductibleRules DeductibleRulesList
+//
+// DeductibleRulesList
+//
+// List all deductible rules. Only available to admins.
+//
+// ---
+// responses:
+//   '200':
+//     description: a list of DeductibleRules
+//     schema:
+//       "$ref": "#/definitions/DeductibleRules"
+func deductibleRulesList(c buffalo.Context) error {
+	var rules models.DeductibleRules
+	if err := rules.All(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, rules.ConvertToAPI())
+}
+
+// swagger:operation POST /deductible-rules DeductibleRules DeductibleRulesCreate
+//
+// DeductibleRulesCreate
+//
+// Create a deductible rule. The rule is used for claim item payouts calculated after it is created. Only available
+// to admins.
+//
+// ---
+// parameters:
+//   - name: deductible rule
+//     in: body
+//     description: deductible rule input object
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/DeductibleRuleInput"
+// responses:
+//   '200':
+//     description: the new DeductibleRule
+//     schema:
+//       "$ref": "#/definitions/DeductibleRule"
+func deductibleRulesCreate(c buffalo.Context) error {
+	var input api.DeductibleRuleInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	rule := models.NewDeductibleRuleFromApiInput(input)
+	if err := rule.Create(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, rule.ConvertToAPI())
+}
+
+// swagger:operation PUT /deductible-rules/{id} DeductibleRules DeductibleRulesUpdate
+//
+// DeductibleRulesUpdate
+//
+// Update a deductible rule. Claim items keep the deductible recorded when their payout was calculated. Only
+// available to admins.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: deductible rule ID
+//   - name: deductible rule
+//     in: body
+//     description: deductible rule input object
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/DeductibleRuleInput"
+// responses:
+//   '200':
+//     description: the updated DeductibleRule
+//     schema:
+//       "$ref": "#/definitions/DeductibleRule"
+func deductibleRulesUpdate(c buffalo.Context) error {
+	rule := getReferencedDeductibleRuleFromCtx(c)
+
+	var input api.DeductibleRuleInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	updated := models.NewDeductibleRuleFromApiInput(input)
+	updated.ID = rule.ID
+	updated.CreatedAt = rule.CreatedAt
+	if err := updated.Update(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, updated.ConvertToAPI())
+}
+
+// getReferencedDeductibleRuleFromCtx pulls the models.DeductibleRule resource from context that was put there
+// by the AuthZ middleware
+func getReferencedDeductibleRuleFromCtx(c buffalo.Context) *models.DeductibleRule {
+	rule, ok := c.Value(domain.TypeDeductibleRule).(*models.DeductibleRule)
+	if !ok {
+		panic("deductible rule not found in context")
+	}
+	return rule
+}
@@ -0,0 +1,129 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_DeductibleRulesCreate() {
+	f := models.CreatePolicyFixtures(as.DB, models.FixturesConfig{})
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		input      api.DeductibleRuleInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			wantStatus: http.StatusUnauthorized,
+			wantInBody: []string{api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			input:      api.DeductibleRuleInput{Type: api.DeductibleTypeFlat, FlatAmount: 5000},
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "missing amount",
+			actor:      stewardUser,
+			input:      api.DeductibleRuleInput{Type: api.DeductibleTypeFlat},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorDeductibleRuleAmount.String()},
+		},
+		{
+			name:  "good",
+			actor: stewardUser,
+			input: api.DeductibleRuleInput{
+				IncidentType:  api.ClaimIncidentTypeTheft,
+				Type:          api.DeductibleTypePercentage,
+				Percentage:    0.1,
+				MinimumAmount: 2500,
+			},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"incident_type":"` + string(api.ClaimIncidentTypeTheft),
+				`"description":"10% (minimum $25.00)"`,
+			},
+		},
+		{
+			name:       "duplicate",
+			actor:      stewardUser,
+			input:      api.DeductibleRuleInput{IncidentType: api.ClaimIncidentTypeTheft, Type: api.DeductibleTypeFlat, FlatAmount: 5000},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorDeductibleRuleDuplicate.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(deductibleRulesPath)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			res := req.Post(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			for _, s := range tt.wantInBody {
+				as.Contains(body, s)
+			}
+		})
+	}
+}
+
+func (as *ActionSuite) Test_DeductibleRulesUpdate() {
+	f := models.CreatePolicyFixtures(as.DB, models.FixturesConfig{})
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	rule := models.DeductibleRule{Type: api.DeductibleTypeFlat, FlatAmount: 5000}
+	as.NoError(rule.Create(as.DB))
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		input      api.DeductibleRuleInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			input:      api.DeductibleRuleInput{Type: api.DeductibleTypeFlat, FlatAmount: 7500},
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "good",
+			actor:      stewardUser,
+			input:      api.DeductibleRuleInput{Type: api.DeductibleTypeFlat, FlatAmount: 7500},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"id":"` + rule.ID.String(), `"flat_amount":7500`},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(deductibleRulesPath + "/" + rule.ID.String())
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			res := req.Put(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			for _, s := range tt.wantInBody {
+				as.Contains(body, s)
+			}
+		})
+	}
+}
@@ -24,20 +24,42 @@ import (
 //     in: path
 //     required: true
 //     description: policy ID
+//   - name: limit
+//     in: query
+//     required: false
+//     description: maximum number of records in a page, from 1 to 50, default 10. If neither limit nor cursor
+//       is given, all the records are returned in an array rather than a page.
+//   - name: cursor
+//     in: query
+//     required: false
+//     description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+//   - name: sort
+//     in: query
+//     required: false
+//     description: field by which to sort, prefixed by "-" for descending order. One of
+//       name (default), created_at
+//   - name: filter
+//     in: query
+//     required: false
+//     description: comma-separated list of pairs like "field:value". Supported field: relationship
 // responses:
 //   '200':
-//     description: a list of PolicyDependents
+//     description: all PolicyDependents, or a page with "meta" and "data" if a limit or cursor is given
 //     schema:
-//       type: array
-//       items:
-//         "$ref": "#/definitions/PolicyDependents"
+//       "$ref": "#/definitions/PolicyDependents"
 func dependentsList(c buffalo.Context) error {
 	policy := getReferencedPolicyFromCtx(c)
 
 	tx := models.Tx(c)
-	policy.LoadDependents(tx, false)
+	var dependents models.PolicyDependents
+
+	query := api.NewQuery(c.Params())
+	meta, err := dependents.ByPolicy(tx, policy.ID, query)
+	if err != nil {
+		return reportError(c, err)
+	}
 
-	return renderOk(c, policy.Dependents.ConvertToAPI())
+	return renderList(c, query, meta, dependents.ConvertToAPI())
 }
 
 // swagger:operation POST /policies/{id}/dependents PolicyDependents PolicyDependentsCreate
@@ -0,0 +1,155 @@
+package actions
+
+import (
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /claims/{id}/history Histories ClaimsHistory
+//
+// ClaimsHistory
+//
+// gets the changes made to a claim and its claim items, newest first
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim ID
+//   - name: limit
+//     in: query
+//     required: false
+//     description: maximum number of records in a page, from 1 to 50, default 10. If neither limit nor cursor
+//       is given, all the records are returned in an array rather than a page.
+//   - name: cursor
+//     in: query
+//     required: false
+//     description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+//   - name: sort
+//     in: query
+//     required: false
+//     description: field by which to sort, prefixed by "-" for descending order. One of
+//       created_at (default descending), field_name
+//   - name: filter
+//     in: query
+//     required: false
+//     description: comma-separated list of pairs like "field:value". Supported fields: field_name, action
+// responses:
+//   '200':
+//     description: all claim history records, or a page with "meta" and "data" if a limit or cursor is given
+//     schema:
+//       "$ref": "#/definitions/ClaimHistories"
+func claimsHistory(c buffalo.Context) error {
+	tx := models.Tx(c)
+	claim := getReferencedClaimFromCtx(c)
+
+	var histories models.ClaimHistories
+	query := api.NewQuery(c.Params())
+	meta, err := histories.FindByClaim(tx, claim.ID, query)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderList(c, query, meta, histories.ConvertToAPI(tx))
+}
+
+// swagger:operation GET /items/{id}/history Histories ItemsHistory
+//
+// ItemsHistory
+//
+// gets the changes made to an item, newest first
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: item ID
+//   - name: limit
+//     in: query
+//     required: false
+//     description: maximum number of records in a page, from 1 to 50, default 10. If neither limit nor cursor
+//       is given, all the records are returned in an array rather than a page.
+//   - name: cursor
+//     in: query
+//     required: false
+//     description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+//   - name: sort
+//     in: query
+//     required: false
+//     description: field by which to sort, prefixed by "-" for descending order. One of
+//       created_at (default descending), field_name
+//   - name: filter
+//     in: query
+//     required: false
+//     description: comma-separated list of pairs like "field:value". Supported fields: field_name, action
+// responses:
+//   '200':
+//     description: all item history records, or a page with "meta" and "data" if a limit or cursor is given
+//     schema:
+//       "$ref": "#/definitions/PolicyHistories"
+func itemsHistory(c buffalo.Context) error {
+	tx := models.Tx(c)
+	item := getReferencedItemFromCtx(c)
+
+	var histories models.PolicyHistories
+	query := api.NewQuery(c.Params())
+	meta, err := histories.FindByItem(tx, item.ID, query)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderList(c, query, meta, histories.ConvertToAPI(tx))
+}
+
+// swagger:operation GET /policies/{id}/history Histories PoliciesHistory
+//
+// PoliciesHistory
+//
+// gets the changes made to a policy and its items, newest first
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: policy ID
+//   - name: limit
+//     in: query
+//     required: false
+//     description: maximum number of records in a page, from 1 to 50, default 10. If neither limit nor cursor
+//       is given, all the records are returned in an array rather than a page.
+//   - name: cursor
+//     in: query
+//     required: false
+//     description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+//   - name: sort
+//     in: query
+//     required: false
+//     description: field by which to sort, prefixed by "-" for descending order. One of
+//       created_at (default descending), field_name
+//   - name: filter
+//     in: query
+//     required: false
+//     description: comma-separated list of pairs like "field:value". Supported fields: field_name, action
+// responses:
+//   '200':
+//     description: all policy history records, or a page with "meta" and "data" if a limit or cursor is given
+//     schema:
+//       "$ref": "#/definitions/PolicyHistories"
+func policiesHistory(c buffalo.Context) error {
+	tx := models.Tx(c)
+	policy := getReferencedPolicyFromCtx(c)
+
+	var histories models.PolicyHistories
+	query := api.NewQuery(c.Params())
+	meta, err := histories.FindByPolicy(tx, policy.ID, query)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderList(c, query, meta, histories.ConvertToAPI(tx))
+}
@@ -0,0 +1,136 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_ClaimsHistory() {
+	fixtures := models.CreateClaimHistoryFixtures_RecentClaimStatusChanges(as.DB)
+	chFixes := fixtures.ClaimHistories
+	member := fixtures.Policies[0].Members[0]
+	otherUser := models.CreateUserFixtures(as.DB, 1).Users[0]
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+	claim := fixtures.Claims[1]
+
+	tests := []struct {
+		name          string
+		actor         models.User
+		query         string
+		wantStatus    int
+		wantInBody    []string
+		notWantInBody string
+	}{
+		{
+			name:          "other user",
+			actor:         otherUser,
+			wantStatus:    http.StatusNotFound,
+			notWantInBody: chFixes[7].ID.String(),
+		},
+		{
+			name:       "member",
+			actor:      member,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`[{"id":"` + chFixes[7].ID.String(),
+				`"claim_id":"` + claim.ID.String(),
+				`"field_name":"` + models.FieldClaimStatus,
+				`"user":{"id":"` + member.ID.String() + `","first_name":"` + member.FirstName,
+			},
+		},
+		{
+			name:       "steward first page",
+			actor:      steward,
+			query:      "?limit=3",
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"meta":{"limit":3,"total_records":4,"next_cursor":"`,
+				`"prev_cursor":""`,
+				`"data":[{"id":"` + chFixes[7].ID.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/%s/%s/history%s", domain.TypeClaim, claim.ID.String(), tt.query)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			if tt.notWantInBody != "" {
+				as.NotContains(body, tt.notWantInBody, "found unexpected string")
+			}
+			as.verifyResponseData(tt.wantInBody, body, "Claims History")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_ItemsAndPoliciesHistory() {
+	fixtures := models.CreatePolicyHistoryFixtures_RecentItemStatusChanges(as.DB)
+	phFixes := fixtures.PolicyHistories
+	member := fixtures.Policies[0].Members[0]
+	otherUser := models.CreateUserFixtures(as.DB, 1).Users[0]
+	item := fixtures.Items[1]
+	policy := fixtures.Policies[0]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		path       string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "item, other user",
+			actor:      otherUser,
+			path:       fmt.Sprintf("/%s/%s/history", domain.TypeItem, item.ID),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "item, member",
+			actor:      member,
+			path:       fmt.Sprintf("/%s/%s/history", domain.TypeItem, item.ID),
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`[{"id":"` + phFixes[7].ID.String(),
+				`"item_id":"` + item.ID.String(),
+			},
+		},
+		{
+			name:       "policy, other user",
+			actor:      otherUser,
+			path:       fmt.Sprintf("/%s/%s/history", domain.TypePolicy, policy.ID),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "policy, member",
+			actor:      member,
+			path:       fmt.Sprintf("/%s/%s/history", domain.TypePolicy, policy.ID),
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"policy_id":"` + policy.ID.String(),
+				`"item_id":null`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(tt.path)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "History")
+		})
+	}
+}
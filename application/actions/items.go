@@ -22,21 +22,42 @@ import (
 //     in: path
 //     required: true
 //     description: policy ID
+//   - name: limit
+//     in: query
+//     required: false
+//     description: maximum number of records in a page, from 1 to 50, default 10. If neither limit nor cursor
+//       is given, all the records are returned in an array rather than a page.
+//   - name: cursor
+//     in: query
+//     required: false
+//     description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+//   - name: sort
+//     in: query
+//     required: false
+//     description: field by which to sort, prefixed by "-" for descending order. One of
+//       created_at (default), updated_at, name, coverage_amount, coverage_status
+//   - name: filter
+//     in: query
+//     required: false
+//     description: comma-separated list of pairs like "field:value". Supported field: coverage_status
 // responses:
 //   '200':
-//     description: all policy items
+//     description: all policy items, or a page with "meta" and "data" if a limit or cursor is given
 //     schema:
-//       type: array
-//       items:
-//         "$ref": "#/definitions/Item"
+//       "$ref": "#/definitions/Items"
 func itemsList(c buffalo.Context) error {
 	tx := models.Tx(c)
 
 	policy := getReferencedPolicyFromCtx(c)
 
-	policy.LoadItems(tx, true)
+	var items models.Items
+	query := api.NewQuery(c.Params())
+	meta, err := items.ByPolicy(tx, policy.ID, query)
+	if err != nil {
+		return reportError(c, err)
+	}
 
-	return renderOk(c, policy.Items.ConvertToAPI(tx))
+	return renderList(c, query, meta, items.ConvertToAPI(tx))
 }
 
 // swagger:operation POST /policies/{id}/items PolicyItems PolicyItemsCreate
@@ -1,7 +1,9 @@
 package actions
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gobuffalo/buffalo"
 	"github.com/gobuffalo/nulls"
@@ -25,6 +27,15 @@ import (
 //   in: query
 //   required: false
 //   description: number of records to return, minimum 1, maximum 50, default 10
+// - name: cursor
+//   in: query
+//   required: false
+//   description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+// - name: sort
+//   in: query
+//   required: false
+//   description: field by which to sort, prefixed by "-" for descending order. One of updated_at (default descending),
+//     created_at, name
 // - name: search
 //   in: query
 //   required: false
@@ -32,7 +43,7 @@ import (
 // - name: filter
 //   in: query
 //   required: false
-//   description: comma-separated list of search pairs like "field:text". Presently, only meta-field 'active' is supported
+//   description: comma-separated list of search pairs like "field:text". Supported fields are meta-field 'active' and 'type'
 // responses:
 //   '200':
 //     description: all policies
@@ -57,12 +68,13 @@ func policiesListAdmin(c buffalo.Context) error {
 	tx := models.Tx(c)
 	var policies models.Policies
 
-	q := api.NewQuery(c.Params())
-	if err := policies.Query(tx, q); err != nil {
+	meta, err := policies.Query(tx, api.NewQuery(c.Params()))
+	if err != nil {
 		return reportError(c, err)
 	}
 
 	response := api.ListResponse{
+		Meta: meta,
 		Data: policies.ConvertToAPI(tx),
 	}
 
@@ -302,6 +314,61 @@ func policiesInviteMember(c buffalo.Context) error {
 	return c.Render(http.StatusNoContent, nil)
 }
 
+// swagger:operation GET /policies/{id}/ledger Policies PoliciesLedger
+//
+// PoliciesLedger
+//
+// gets the statement of account for a policy, with a running balance on each ledger entry
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: policy ID
+//   - name: year
+//     in: query
+//     required: false
+//     description: only include the entries submitted in this year, default is all years
+//   - name: format
+//     in: query
+//     required: false
+//     description: set to "csv" to download a printable statement in CSV format
+// responses:
+//   '200':
+//     description: the policy statement of account
+//     schema:
+//       "$ref": "#/definitions/PolicyLedger"
+func policiesLedger(c buffalo.Context) error {
+	tx := models.Tx(c)
+	policy := getReferencedPolicyFromCtx(c)
+
+	var year int
+	if y := c.Param("year"); y != "" {
+		var err error
+		year, err = strconv.Atoi(y)
+		if err != nil || year < 1 {
+			err = fmt.Errorf("invalid year '%s'", y)
+			return reportError(c, api.NewAppError(err, api.ErrorPolicyLedgerInvalidYear, api.CategoryUser))
+		}
+	}
+
+	ledger, err := policy.Ledger(tx, year)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	if c.Param("format") == "csv" {
+		filename := "statement_" + policy.ID.String()
+		if year != 0 {
+			filename += "_" + strconv.Itoa(year)
+		}
+		return renderFile(c, filename+".csv", "text/csv", models.PolicyLedgerToCsv(ledger))
+	}
+
+	return renderOk(c, ledger)
+}
+
 // getReferencedPolicyFromCtx pulls the models.Policy resource from context that was put there
 // by the AuthZ middleware
 func getReferencedPolicyFromCtx(c buffalo.Context) *models.Policy {
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 
@@ -544,3 +545,99 @@ func (as *ActionSuite) Test_PoliciesInviteMember() {
 		})
 	}
 }
+
+func (as *ActionSuite) Test_PoliciesLedger() {
+	fixtures := models.CreatePolicyFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 2, UsersPerPolicy: 1})
+	policy := fixtures.Policies[0]
+	policy.LoadMembers(as.DB, false)
+	fixtures.Policies[1].LoadMembers(as.DB, false)
+
+	entry := models.LedgerEntry{
+		PolicyID:         policy.ID,
+		RiskCategoryName: "Mobile",
+		Type:             models.LedgerEntryTypeNewCoverage,
+		PolicyType:       policy.Type,
+		Amount:           1234,
+		DateSubmitted:    time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	as.NoError(entry.Create(as.DB))
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		query      string
+		wantStatus int
+		wantInBody []string
+		wantCount  int
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			wantStatus: http.StatusUnauthorized,
+			wantInBody: []string{api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "non-policy user",
+			actor:      fixtures.Policies[1].Members[0],
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "invalid year",
+			actor:      policy.Members[0],
+			query:      "?year=abc",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorPolicyLedgerInvalidYear.String()},
+		},
+		{
+			name:       "policy user",
+			actor:      policy.Members[0],
+			wantStatus: http.StatusOK,
+			wantInBody: []string{entry.ID.String()},
+			wantCount:  1,
+		},
+		{
+			name:       "other year",
+			actor:      policy.Members[0],
+			query:      "?year=2020",
+			wantStatus: http.StatusOK,
+			wantCount:  0,
+		},
+		{
+			name:       "csv",
+			actor:      policy.Members[0],
+			query:      "?year=2021&format=csv",
+			wantStatus: http.StatusOK,
+			wantInBody: []string{"Opening Balance", "2021-03-01,NewCoverage", "12.34"},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("%s/%s/ledger%s", policiesPath, policy.ID, tt.query)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			for _, s := range tt.wantInBody {
+				as.Contains(body, s)
+			}
+
+			if res.Code != http.StatusOK {
+				return
+			}
+
+			if strings.Contains(tt.query, "format=csv") {
+				as.Equal("text/csv", res.Header().Get("Content-Type"))
+				return
+			}
+
+			var ledger api.PolicyLedger
+			as.NoError(json.Unmarshal([]byte(body), &ledger))
+			as.Equal(policy.ID, ledger.PolicyID, "incorrect PolicyID")
+			as.Equal(tt.wantCount, len(ledger.Entries), "incorrect number of entries")
+		})
+	}
+}
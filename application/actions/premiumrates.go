@@ -0,0 +1,116 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/pop/v5"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /premium-rates PremiumRates PremiumRatesList
+//
+// PremiumRatesList
+//
+// List all premium rates, most recent effective date first. Only available to admins.
+//
+// ---
+// responses:
+//   '200':
+//     description: a list of PremiumRates
+//     schema:
+//       "$ref": "#/definitions/PremiumRates"
+func premiumRatesList(c buffalo.Context) error {
+	var rates models.PremiumRates
+	if err := rates.All(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, rates.ConvertToAPI())
+}
+
+// swagger:operation POST /premium-rates PremiumRates PremiumRatesCreate
+//
+// PremiumRatesCreate
+//
+// Create a premium rate for a risk category or an item category. The rate applies to new coverage starting on
+// its effective date, and to renewals for years starting on or after its effective date. Only available to admins.
+//
+// ---
+// parameters:
+//   - name: premium rate
+//     in: body
+//     description: premium rate create input object
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/PremiumRateCreate"
+// responses:
+//   '200':
+//     description: the new PremiumRate
+//     schema:
+//       "$ref": "#/definitions/PremiumRate"
+func premiumRatesCreate(c buffalo.Context) error {
+	var input api.PremiumRateCreate
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	rate, err := models.NewPremiumRateFromApiInput(input)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	if err := rate.Create(c); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, rate.ConvertToAPI())
+}
+
+// swagger:operation DELETE /premium-rates/{id} PremiumRates PremiumRatesDelete
+//
+// PremiumRatesDelete
+//
+// Delete a premium rate that has not yet taken effect. Only available to admins.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: premium rate ID
+// responses:
+//   '204':
+//     description: OK but no content in response
+func premiumRatesDelete(c buffalo.Context) error {
+	rate := getReferencedPremiumRateFromCtx(c)
+
+	if err := rate.Destroy(models.Tx(c)); err != nil {
+		return reportError(c, err)
+	}
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// getReferencedPremiumRateFromCtx pulls the models.PremiumRate resource from context that was put there
+// by the AuthZ middleware
+func getReferencedPremiumRateFromCtx(c buffalo.Context) *models.PremiumRate {
+	rate, ok := c.Value(domain.TypePremiumRate).(*models.PremiumRate)
+	if !ok {
+		panic("premium rate not found in context")
+	}
+	return rate
+}
+
+// premiumRateCache is middleware that makes the request's transaction load the premium rates only once, since a
+// list of items needs the rates for every item
+func premiumRateCache(next buffalo.Handler) buffalo.Handler {
+	return func(c buffalo.Context) error {
+		if tx, ok := c.Value("tx").(*pop.Connection); ok {
+			c.Set("tx", models.WithPremiumRateCache(tx))
+		}
+		return next(c)
+	}
+}
@@ -0,0 +1,151 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_PremiumRatesCreate() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{})
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+	riskCategoryID := f.Items[0].RiskCategoryID
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format(domain.DateFormat)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		input      api.PremiumRateCreate
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			wantStatus: http.StatusUnauthorized,
+			wantInBody: []string{api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			input:      api.PremiumRateCreate{RiskCategoryID: &riskCategoryID, Rate: 0.03, EffectiveDate: tomorrow},
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "invalid effective date",
+			actor:      stewardUser,
+			input:      api.PremiumRateCreate{RiskCategoryID: &riskCategoryID, Rate: 0.03, EffectiveDate: "tomorrow"},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorPremiumRateEffectiveDate.String()},
+		},
+		{
+			name:       "no category",
+			actor:      stewardUser,
+			input:      api.PremiumRateCreate{Rate: 0.03, EffectiveDate: tomorrow},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorPremiumRateCategory.String()},
+		},
+		{
+			name:       "good",
+			actor:      stewardUser,
+			input:      api.PremiumRateCreate{RiskCategoryID: &riskCategoryID, Rate: 0.03, EffectiveDate: tomorrow},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"risk_category_id":"` + riskCategoryID.String(),
+				`"effective_date":"` + tomorrow,
+				`"created_by_id":"` + stewardUser.ID.String(),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(premiumRatesPath)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			res := req.Post(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			for _, s := range tt.wantInBody {
+				as.Contains(body, s)
+			}
+		})
+	}
+}
+
+func (as *ActionSuite) Test_PremiumRatesDelete() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{})
+	normalUser := f.Users[0]
+	stewardUser := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+	now := time.Now().UTC()
+
+	inEffect := models.PremiumRate{
+		RiskCategoryID: nulls.NewUUID(f.Items[0].RiskCategoryID),
+		Rate:           0.03,
+		EffectiveDate:  time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		CreatedByID:    stewardUser.ID,
+	}
+	as.NoError(as.DB.Create(&inEffect))
+
+	future := models.PremiumRate{
+		RiskCategoryID: inEffect.RiskCategoryID,
+		Rate:           0.04,
+		EffectiveDate:  inEffect.EffectiveDate.AddDate(0, 1, 0),
+		CreatedByID:    stewardUser.ID,
+	}
+	as.NoError(as.DB.Create(&future))
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		rate       models.PremiumRate
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "insufficient privileges",
+			actor:      normalUser,
+			rate:       future,
+			wantStatus: http.StatusNotFound,
+			wantInBody: []string{`"key":"` + api.ErrorNotAuthorized.String()},
+		},
+		{
+			name:       "in effect",
+			actor:      stewardUser,
+			rate:       inEffect,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{`"key":"` + api.ErrorPremiumRateInEffect.String()},
+		},
+		{
+			name:       "good",
+			actor:      stewardUser,
+			rate:       future,
+			wantStatus: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(premiumRatesPath + "/" + tt.rate.ID.String())
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			res := req.Delete()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			for _, s := range tt.wantInBody {
+				as.Contains(body, s)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package actions
+
+import (
+	"net/http"
+
+	"github.com/gobuffalo/buffalo"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation POST /items/{id}/assignment QueueAssignments ItemsAssign
+//
+// ItemsAssign
+//
+// Assign a pending item in the steward queue to an admin. If no admin is given, the item is assigned to the
+// current user.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: item ID
+//   - name: queue assignment input
+//     in: body
+//     description: queue assignment input object
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/QueueAssignmentInput"
+// responses:
+//   '200':
+//     description: the queue assignment
+//     schema:
+//       "$ref": "#/definitions/QueueAssignment"
+func itemsAssign(c buffalo.Context) error {
+	stewardID, err := bindQueueAssignmentInput(c)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	item := getReferencedItemFromCtx(c)
+	assignment, err := item.Assign(c, stewardID)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, assignment.ConvertToAPI())
+}
+
+// swagger:operation DELETE /items/{id}/assignment QueueAssignments ItemsUnassign
+//
+// ItemsUnassign
+//
+// Remove the assignment of an item in the steward queue
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: item ID
+// responses:
+//   '204':
+//     description: OK but no content in response
+func itemsUnassign(c buffalo.Context) error {
+	item := getReferencedItemFromCtx(c)
+	if err := item.Unassign(c); err != nil {
+		return reportError(c, err)
+	}
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// swagger:operation POST /claims/{id}/assignment QueueAssignments ClaimsAssign
+//
+// ClaimsAssign
+//
+// Assign a claim in the steward queue to an admin. If no admin is given, the claim is assigned to the current user.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim ID
+//   - name: queue assignment input
+//     in: body
+//     description: queue assignment input object
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/QueueAssignmentInput"
+// responses:
+//   '200':
+//     description: the queue assignment
+//     schema:
+//       "$ref": "#/definitions/QueueAssignment"
+func claimsAssign(c buffalo.Context) error {
+	stewardID, err := bindQueueAssignmentInput(c)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	claim := getReferencedClaimFromCtx(c)
+	assignment, err := claim.Assign(c, stewardID)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, assignment.ConvertToAPI())
+}
+
+// swagger:operation DELETE /claims/{id}/assignment QueueAssignments ClaimsUnassign
+//
+// ClaimsUnassign
+//
+// Remove the assignment of a claim in the steward queue
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: claim ID
+// responses:
+//   '204':
+//     description: OK but no content in response
+func claimsUnassign(c buffalo.Context) error {
+	claim := getReferencedClaimFromCtx(c)
+	if err := claim.Unassign(c); err != nil {
+		return reportError(c, err)
+	}
+	return c.Render(http.StatusNoContent, nil)
+}
+
+// bindQueueAssignmentInput returns the ID of the admin to assign, which defaults to the current user
+func bindQueueAssignmentInput(c buffalo.Context) (uuid.UUID, error) {
+	var input api.QueueAssignmentInput
+	if err := StrictBind(c, &input); err != nil {
+		return uuid.Nil, err
+	}
+
+	if input.StewardID == nil {
+		return models.CurrentUser(c).ID, nil
+	}
+	return *input.StewardID, nil
+}
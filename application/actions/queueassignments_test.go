@@ -0,0 +1,125 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_ItemsAssign() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{ItemsPerPolicy: 2})
+	admins := models.CreateAdminUsers(as.DB)
+	steward := admins[models.AppRoleSteward]
+	signator := admins[models.AppRoleSignator]
+	owner := f.Policies[0].Members[0]
+
+	pendingItem := models.UpdateItemStatus(as.DB, f.Items[0], api.ItemCoverageStatusPending, "")
+	draftItem := f.Items[1]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		item       models.Item
+		input      api.QueueAssignmentInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "owner",
+			actor:      owner,
+			item:       pendingItem,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "not in queue",
+			actor:      steward,
+			item:       draftItem,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorQueueStatus.String()},
+		},
+		{
+			name:       "self",
+			actor:      steward,
+			item:       pendingItem,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"item_id":"` + pendingItem.ID.String(),
+				`"claim_id":null`,
+				`"steward_id":"` + steward.ID.String(),
+				`"assigned_by_id":"` + steward.ID.String(),
+			},
+		},
+		{
+			name:       "already assigned",
+			actor:      signator,
+			item:       pendingItem,
+			input:      api.QueueAssignmentInput{StewardID: &signator.ID},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorQueueAlreadyAssigned.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/%s/%s/%s", domain.TypeItem, tt.item.ID.String(), api.ResourceAssignment)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Post(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "Items Assign")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_ClaimsUnassign() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{ClaimsPerPolicy: 1})
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+	owner := f.Policies[0].Members[0]
+
+	claim := models.UpdateClaimStatus(as.DB, f.Claims[0], api.ClaimStatusReview1, "")
+	_, err := claim.Assign(models.CreateTestContext(steward), steward.ID)
+	as.NoError(err)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "owner",
+			actor:      owner,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "not assigned",
+			actor:      steward,
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorQueueNotAssigned.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/%s/%s/%s", domain.TypeClaim, claim.ID.String(), api.ResourceAssignment)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Delete()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "Claims Unassign")
+		})
+	}
+}
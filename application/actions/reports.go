@@ -0,0 +1,123 @@
+package actions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/models"
+)
+
+// swagger:operation GET /reports/loss-ratio Reports ReportsLossRatio
+//
+// ReportsLossRatio
+//
+// compares premiums earned to claims paid, based on the ledger entries
+// submitted in the report date range. The totals are given for each period
+// and combination of the group_by dimensions, along with a total for the
+// whole report.
+//
+// ---
+// parameters:
+//   - name: start
+//     in: query
+//     required: false
+//     description: first day (YYYY-MM-DD) of the report. Defaults to January 1 of the current year.
+//     type: string
+//   - name: end
+//     in: query
+//     required: false
+//     description: last day (YYYY-MM-DD) of the report, inclusive. Defaults to today.
+//     type: string
+//   - name: period
+//     in: query
+//     required: false
+//     description: length of each period, one of "month" (default), "quarter", or "year"
+//     type: string
+//   - name: group_by
+//     in: query
+//     required: false
+//     description: comma-separated list of dimensions from "type", "risk_category", "entity_code", and
+//       "policy_type". Defaults to "risk_category,entity_code,policy_type".
+//     type: string
+//   - name: format
+//     in: query
+//     required: false
+//     description: set to "csv" to download the report in CSV format
+//     type: string
+// responses:
+//   '200':
+//     description: the loss ratio report
+//     schema:
+//       "$ref": "#/definitions/LossRatioReport"
+//     content:
+//       text/csv:
+//         schema:
+//           type: string
+//           format: text
+func reportsLossRatio(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("user not allowed to get the loss ratio report")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	startDate, endDate, err := getReportDates(c)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	period := c.Param("period")
+	if period == "" {
+		period = api.ReportPeriodMonth
+	}
+
+	groupBy := models.DefaultReportGroups
+	if g := c.Param("group_by"); g != "" {
+		groupBy = strings.Split(g, ",")
+	}
+
+	report, err := models.LossRatioReport(models.Tx(c), startDate, endDate, period, groupBy)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	if c.Param("format") == "csv" {
+		filename := fmt.Sprintf("loss-ratio_%s_%s.csv", report.StartDate, report.EndDate)
+		return renderFile(c, filename, "text/csv", models.LossRatioReportToCsv(report))
+	}
+
+	return renderOk(c, report)
+}
+
+// getReportDates returns the first and last day of the report given by the request parameters. By default, the
+// report covers the current year to date.
+func getReportDates(c buffalo.Context) (time.Time, time.Time, error) {
+	now := time.Now().UTC()
+	startDate := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if start := c.Param("start"); start != "" {
+		d, err := time.Parse(domain.DateFormat, start)
+		if err != nil {
+			err = fmt.Errorf("invalid start date '%s'", start)
+			return time.Time{}, time.Time{}, api.NewAppError(err, api.ErrorReportInvalidParameter, api.CategoryUser)
+		}
+		startDate = d
+	}
+
+	if end := c.Param("end"); end != "" {
+		d, err := time.Parse(domain.DateFormat, end)
+		if err != nil {
+			err = fmt.Errorf("invalid end date '%s'", end)
+			return time.Time{}, time.Time{}, api.NewAppError(err, api.ErrorReportInvalidParameter, api.CategoryUser)
+		}
+		endDate = d
+	}
+
+	return startDate, endDate, nil
+}
@@ -0,0 +1,105 @@
+package actions
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/models"
+)
+
+func (as *ActionSuite) Test_ReportsLossRatio() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{})
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+	normalUser := f.Policies[0].Members[0]
+
+	entry := models.LedgerEntry{
+		PolicyID:         f.Policies[0].ID,
+		ItemID:           nulls.NewUUID(f.Items[0].ID),
+		EntityCode:       "EC1",
+		RiskCategoryName: "Mobile",
+		Type:             models.LedgerEntryTypeNewCoverage,
+		PolicyType:       api.PolicyTypeHousehold,
+		Amount:           12345,
+		DateSubmitted:    time.Date(2021, 2, 10, 0, 0, 0, 0, time.UTC),
+	}
+	models.MustCreate(as.DB, &entry)
+
+	const dates = "?start=2021-01-01&end=2021-12-31"
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		query      string
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			query:      dates,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "user",
+			actor:      normalUser,
+			query:      dates,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			query:      dates + "&period=quarter",
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"period":"quarter"`,
+				`"period_start":"2021-01-01"`,
+				`"risk_category":"Mobile"`,
+				`"entity_code":"EC1"`,
+				`"premiums":12345`,
+				`"entry_count":1`,
+			},
+		},
+		{
+			name:       "invalid start",
+			actor:      steward,
+			query:      "?start=01/01/2021",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{string(api.ErrorReportInvalidParameter)},
+		},
+		{
+			name:       "invalid group",
+			actor:      steward,
+			query:      dates + "&group_by=country",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{string(api.ErrorReportInvalidParameter)},
+		},
+		{
+			name:       "csv",
+			actor:      steward,
+			query:      dates + "&format=csv",
+			wantStatus: http.StatusOK,
+			wantInBody: []string{"Period Start,Type", "2021-02-01,,Mobile,EC1,Household,123.45", "Total,"},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(reportsPath + "/" + api.ResourceLossRatio + tt.query)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			for _, w := range tt.wantInBody {
+				as.Contains(body, w)
+			}
+		})
+	}
+}
@@ -2,10 +2,16 @@ package actions
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gobuffalo/buffalo"
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
 
 	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
 	"github.com/silinternational/cover-api/models"
 )
 
@@ -47,3 +53,378 @@ func stewardListRecentObjects(c buffalo.Context) error {
 
 	return renderOk(c, recent)
 }
+
+// swagger:operation GET /steward/queue Steward StewardQueue
+//
+// StewardQueue
+//
+// gets the Items and Claims awaiting action by a steward or signator, oldest first, with their age since
+// submission, assignment, and whether the service level agreement has been breached
+//
+// ---
+// parameters:
+//   - name: status
+//     in: query
+//     required: false
+//     description: comma-separated list of status values to include, from Pending, Review1, Review2, and Review3
+//   - name: policy_type
+//     in: query
+//     required: false
+//     description: policy type, Household or Team
+//   - name: entity_code
+//     in: query
+//     required: false
+//     description: entity code of the policy
+//   - name: assigned_to
+//     in: query
+//     required: false
+//     description: ID of the assigned admin, or "none" for entries that are not assigned
+// responses:
+//   '200':
+//     description: a list of queue entries
+//     schema:
+//       "$ref": "#/definitions/StewardQueue"
+func stewardQueue(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("actor not allowed to perform that action on this resource")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	filter := models.QueueFilter{
+		PolicyType: api.PolicyType(c.Param("policy_type")),
+		EntityCode: c.Param("entity_code"),
+		AssignedTo: c.Param("assigned_to"),
+	}
+	if status := c.Param("status"); status != "" {
+		filter.Statuses = strings.Split(status, ",")
+	}
+
+	queue, err := models.StewardQueue(models.Tx(c), filter)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, queue)
+}
+
+// swagger:operation GET /steward/claims Steward StewardClaimsSearch
+//
+// StewardClaimsSearch
+//
+// searches the claims on all policies. Text search matches the incident description, the names of the claim's
+// items, and the names of the members of the claim's policy.
+//
+// ---
+// parameters:
+//   - name: status
+//     in: query
+//     required: false
+//     description: comma-separated list of status values to include. If omitted, draft and withdrawn claims are
+//       excluded.
+//   - name: incident_type
+//     in: query
+//     required: false
+//     description: incident type
+//   - name: incident_date_from
+//     in: query
+//     required: false
+//     description: earliest incident date to include, as YYYY-MM-DD
+//   - name: incident_date_to
+//     in: query
+//     required: false
+//     description: latest incident date to include, as YYYY-MM-DD
+//   - name: min_payout
+//     in: query
+//     required: false
+//     description: minimum total payout in cents
+//   - name: max_payout
+//     in: query
+//     required: false
+//     description: maximum total payout in cents
+//   - name: entity_code
+//     in: query
+//     required: false
+//     description: entity code of the policy
+//   - name: reference_number
+//     in: query
+//     required: false
+//     description: all or part of the claim reference number
+//   - name: search
+//     in: query
+//     required: false
+//     description: text to find in the incident description, item names, or member names
+//   - name: format
+//     in: query
+//     required: false
+//     description: set to "csv" to download all the matching claims in CSV format instead of a page. At most 10000
+//       claims may be downloaded at once.
+//   - name: limit
+//     in: query
+//     required: false
+//     description: maximum number of records in a page, from 1 to 50, default 10
+//   - name: cursor
+//     in: query
+//     required: false
+//     description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+//   - name: sort
+//     in: query
+//     required: false
+//     description: field by which to sort, prefixed by "-" for descending order. One of
+//       created_at (default), updated_at, incident_date, reference_number, status, total_payout
+// responses:
+//   '200':
+//     description: a page of Claims, or a CSV file if format is "csv"
+//     schema:
+//       type: object
+//       properties:
+//         meta:
+//           "$ref": "#/definitions/Meta"
+//         data:
+//           "$ref": "#/definitions/Claims"
+func stewardClaimsSearch(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("actor not allowed to perform that action on this resource")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	query := api.NewQuery(c.Params())
+	search, err := newClaimSearch(c, query)
+	if err != nil {
+		return reportError(c, api.NewAppError(err, api.ErrorClaimSearchInvalid, api.CategoryUser))
+	}
+
+	tx := models.Tx(c)
+	var claims models.Claims
+
+	if c.Param("format") == "csv" {
+		if err := claims.SearchAll(tx, search); err != nil {
+			return reportError(c, err)
+		}
+		csv, err := models.ClaimsToCsv(tx, claims)
+		if err != nil {
+			return reportError(c, err)
+		}
+		return renderFile(c, "claims.csv", "text/csv", csv)
+	}
+
+	meta, err := claims.Search(tx, search, query)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, api.ListResponse{Meta: meta, Data: claims.ConvertToAPI(tx)})
+}
+
+// newClaimSearch parses the claim search criteria from the request parameters
+func newClaimSearch(c buffalo.Context, query api.Query) (models.ClaimSearch, error) {
+	search := models.ClaimSearch{
+		IncidentType:    api.ClaimIncidentType(c.Param("incident_type")),
+		EntityCode:      c.Param("entity_code"),
+		ReferenceNumber: c.Param("reference_number"),
+		Text:            query.Search(),
+	}
+
+	if status := c.Param("status"); status != "" {
+		for _, s := range strings.Split(status, ",") {
+			search.Statuses = append(search.Statuses, api.ClaimStatus(s))
+		}
+	}
+
+	for param, field := range map[string]*nulls.Time{
+		"incident_date_from": &search.IncidentFrom,
+		"incident_date_to":   &search.IncidentTo,
+	} {
+		if value := c.Param(param); value != "" {
+			date, err := time.Parse(domain.DateFormat, value)
+			if err != nil {
+				return search, fmt.Errorf("invalid %s '%s'", param, value)
+			}
+			*field = nulls.NewTime(date)
+		}
+	}
+
+	for param, field := range map[string]*nulls.Int{
+		"min_payout": &search.MinPayout,
+		"max_payout": &search.MaxPayout,
+	} {
+		if value := c.Param(param); value != "" {
+			amount, err := strconv.Atoi(value)
+			if err != nil {
+				return search, fmt.Errorf("invalid %s '%s'", param, value)
+			}
+			*field = nulls.NewInt(amount)
+		}
+	}
+
+	return search, nil
+}
+
+// swagger:operation GET /steward/items Steward StewardItemsSearch
+//
+// StewardItemsSearch
+//
+// searches the items on all policies
+//
+// ---
+// parameters:
+//   - name: coverage_status
+//     in: query
+//     required: false
+//     description: comma-separated list of coverage status values to include
+//   - name: category_id
+//     in: query
+//     required: false
+//     description: item category ID
+//   - name: risk_category_id
+//     in: query
+//     required: false
+//     description: risk category ID
+//   - name: min_coverage_amount
+//     in: query
+//     required: false
+//     description: minimum coverage amount in cents
+//   - name: max_coverage_amount
+//     in: query
+//     required: false
+//     description: maximum coverage amount in cents
+//   - name: country
+//     in: query
+//     required: false
+//     description: country of the item, not case sensitive
+//   - name: in_storage
+//     in: query
+//     required: false
+//     description: true or false
+//   - name: covered_from
+//     in: query
+//     required: false
+//     description: include only items with coverage on or after this date, as YYYY-MM-DD
+//   - name: covered_to
+//     in: query
+//     required: false
+//     description: include only items with coverage on or before this date, as YYYY-MM-DD
+//   - name: format
+//     in: query
+//     required: false
+//     description: set to "csv" to download all the matching items in CSV format instead of a page. At most 10000
+//       items may be downloaded at once.
+//   - name: limit
+//     in: query
+//     required: false
+//     description: maximum number of records in a page, from 1 to 50, default 10
+//   - name: cursor
+//     in: query
+//     required: false
+//     description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+//   - name: sort
+//     in: query
+//     required: false
+//     description: field by which to sort, prefixed by "-" for descending order. One of
+//       created_at (default), updated_at, name, coverage_amount, coverage_status
+// responses:
+//   '200':
+//     description: a page of Items, or a CSV file if format is "csv"
+//     schema:
+//       type: object
+//       properties:
+//         meta:
+//           "$ref": "#/definitions/Meta"
+//         data:
+//           "$ref": "#/definitions/Items"
+func stewardItemsSearch(c buffalo.Context) error {
+	actor := models.CurrentUser(c)
+	if !actor.IsAdmin() {
+		err := fmt.Errorf("actor not allowed to perform that action on this resource")
+		return reportError(c, api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden))
+	}
+
+	search, err := newItemSearch(c)
+	if err != nil {
+		return reportError(c, api.NewAppError(err, api.ErrorItemSearchInvalid, api.CategoryUser))
+	}
+
+	tx := models.Tx(c)
+	var items models.Items
+
+	if c.Param("format") == "csv" {
+		if err := items.SearchAll(tx, search); err != nil {
+			return reportError(c, err)
+		}
+		csv, err := models.ItemsToCsv(tx, items)
+		if err != nil {
+			return reportError(c, err)
+		}
+		return renderFile(c, "items.csv", "text/csv", csv)
+	}
+
+	meta, err := items.Search(tx, search, api.NewQuery(c.Params()))
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return renderOk(c, api.ListResponse{Meta: meta, Data: items.ConvertToAPI(tx)})
+}
+
+// newItemSearch parses the item search criteria from the request parameters
+func newItemSearch(c buffalo.Context) (models.ItemSearch, error) {
+	search := models.ItemSearch{
+		Country: c.Param("country"),
+	}
+
+	if status := c.Param("coverage_status"); status != "" {
+		for _, s := range strings.Split(status, ",") {
+			search.CoverageStatuses = append(search.CoverageStatuses, api.ItemCoverageStatus(s))
+		}
+	}
+
+	for param, field := range map[string]*nulls.UUID{
+		"category_id":      &search.CategoryID,
+		"risk_category_id": &search.RiskCategoryID,
+	} {
+		if value := c.Param(param); value != "" {
+			id, err := uuid.FromString(value)
+			if err != nil {
+				return search, fmt.Errorf("invalid %s '%s'", param, value)
+			}
+			*field = nulls.NewUUID(id)
+		}
+	}
+
+	for param, field := range map[string]*nulls.Int{
+		"min_coverage_amount": &search.MinCoverageAmount,
+		"max_coverage_amount": &search.MaxCoverageAmount,
+	} {
+		if value := c.Param(param); value != "" {
+			amount, err := strconv.Atoi(value)
+			if err != nil {
+				return search, fmt.Errorf("invalid %s '%s'", param, value)
+			}
+			*field = nulls.NewInt(amount)
+		}
+	}
+
+	for param, field := range map[string]*nulls.Time{
+		"covered_from": &search.CoveredFrom,
+		"covered_to":   &search.CoveredTo,
+	} {
+		if value := c.Param(param); value != "" {
+			date, err := time.Parse(domain.DateFormat, value)
+			if err != nil {
+				return search, fmt.Errorf("invalid %s '%s'", param, value)
+			}
+			*field = nulls.NewTime(date)
+		}
+	}
+
+	if value := c.Param("in_storage"); value != "" {
+		inStorage, err := strconv.ParseBool(value)
+		if err != nil {
+			return search, fmt.Errorf("invalid in_storage '%s'", value)
+		}
+		search.InStorage = nulls.NewBool(inStorage)
+	}
+
+	return search, nil
+}
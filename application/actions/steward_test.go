@@ -3,6 +3,7 @@ package actions
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/silinternational/cover-api/api"
@@ -84,3 +85,251 @@ func (as *ActionSuite) Test_StewardListRecent() {
 		})
 	}
 }
+
+func (as *ActionSuite) Test_StewardQueue() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 2, ClaimsPerPolicy: 1})
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+	normalUser := f.Policies[0].Members[0]
+
+	pendingItem := models.UpdateItemStatus(as.DB, f.Items[0], api.ItemCoverageStatusPending, "")
+	reviewClaim := models.UpdateClaimStatus(as.DB, f.Claims[1], api.ClaimStatusReview2, "")
+
+	tests := []struct {
+		name          string
+		actor         models.User
+		query         string
+		wantStatus    int
+		wantInBody    []string
+		notWantInBody string
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "user",
+			actor:         normalUser,
+			wantStatus:    http.StatusNotFound,
+			notWantInBody: pendingItem.ID.String(),
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"type":"` + domain.TypeItem,
+				`"id":"` + pendingItem.ID.String(),
+				`"status":"` + string(api.ItemCoverageStatusPending),
+				`"type":"` + domain.TypeClaim,
+				`"id":"` + reviewClaim.ID.String(),
+				`"name":"` + reviewClaim.ReferenceNumber,
+				`"sla_breached":false`,
+				`"assigned_to_id":null`,
+			},
+		},
+		{
+			name:          "status filter",
+			actor:         steward,
+			query:         "?status=" + string(api.ClaimStatusReview1) + "," + string(api.ClaimStatusReview2),
+			wantStatus:    http.StatusOK,
+			wantInBody:    []string{`"id":"` + reviewClaim.ID.String()},
+			notWantInBody: pendingItem.ID.String(),
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(stewardPath + "/" + api.ResourceQueue + tt.query)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			if tt.notWantInBody != "" {
+				as.NotContains(body, tt.notWantInBody)
+			}
+
+			if res.Code != http.StatusOK {
+				return
+			}
+
+			as.verifyResponseData(tt.wantInBody, body, "Steward Queue")
+		})
+	}
+}
+
+func (as *ActionSuite) Test_StewardClaimsSearch() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{NumberOfPolicies: 2, ClaimsPerPolicy: 1})
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+	normalUser := f.Policies[0].Members[0]
+
+	withdrawnClaim := models.UpdateClaimStatus(as.DB, f.Claims[0], api.ClaimStatusWithdrawn, "")
+	reviewClaim := models.UpdateClaimStatus(as.DB, f.Claims[1], api.ClaimStatusReview1, "")
+
+	tests := []struct {
+		name          string
+		actor         models.User
+		query         string
+		wantStatus    int
+		wantInBody    []string
+		notWantInBody string
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "user",
+			actor:         normalUser,
+			wantStatus:    http.StatusNotFound,
+			notWantInBody: withdrawnClaim.ID.String(),
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"total_records":1`,
+				`"id":"` + reviewClaim.ID.String(),
+			},
+			notWantInBody: withdrawnClaim.ID.String(),
+		},
+		{
+			name:          "status and reference number",
+			actor:         steward,
+			query:         "?status=" + string(api.ClaimStatusReview1) + "&reference_number=" + reviewClaim.ReferenceNumber,
+			wantStatus:    http.StatusOK,
+			wantInBody:    []string{`"total_records":1`, `"id":"` + reviewClaim.ID.String()},
+			notWantInBody: withdrawnClaim.ID.String(),
+		},
+		{
+			name:          "text search",
+			actor:         steward,
+			query:         "?status=" + string(api.ClaimStatusWithdrawn) + "&search=" + withdrawnClaim.IncidentDescription,
+			wantStatus:    http.StatusOK,
+			wantInBody:    []string{`"id":"` + withdrawnClaim.ID.String()},
+			notWantInBody: reviewClaim.ID.String(),
+		},
+		{
+			name:       "invalid date",
+			actor:      steward,
+			query:      "?incident_date_from=05/01/2020",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{string(api.ErrorClaimSearchInvalid)},
+		},
+		{
+			name:       "csv",
+			actor:      steward,
+			query:      "?format=csv&status=" + string(api.ClaimStatusReview1),
+			wantStatus: http.StatusOK,
+			wantInBody: []string{"Reference Number,Status", reviewClaim.ReferenceNumber + "," + string(api.ClaimStatusReview1)},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(stewardPath + "/" + domain.TypeClaim + tt.query)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			if tt.notWantInBody != "" {
+				as.NotContains(body, tt.notWantInBody)
+			}
+
+			for _, w := range tt.wantInBody {
+				as.Contains(body, w)
+			}
+		})
+	}
+}
+
+func (as *ActionSuite) Test_StewardItemsSearch() {
+	f := models.CreateItemFixtures(as.DB, models.FixturesConfig{ItemsPerPolicy: 2})
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+	normalUser := f.Policies[0].Members[0]
+
+	draftItem := f.Items[0]
+	approvedItem := models.UpdateItemStatus(as.DB, f.Items[1], api.ItemCoverageStatusApproved, "")
+
+	tests := []struct {
+		name          string
+		actor         models.User
+		query         string
+		wantStatus    int
+		wantInBody    []string
+		notWantInBody string
+	}{
+		{
+			name:       "unauthenticated",
+			actor:      models.User{},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:          "user",
+			actor:         normalUser,
+			wantStatus:    http.StatusNotFound,
+			notWantInBody: draftItem.ID.String(),
+		},
+		{
+			name:       "steward",
+			actor:      steward,
+			wantStatus: http.StatusOK,
+			wantInBody: []string{
+				`"total_records":2`,
+				`"id":"` + draftItem.ID.String(),
+				`"id":"` + approvedItem.ID.String(),
+			},
+		},
+		{
+			name:  "coverage status and country",
+			actor: steward,
+			query: "?coverage_status=" + string(api.ItemCoverageStatusApproved) + "&country=" +
+				strings.ToUpper(approvedItem.Country),
+			wantStatus:    http.StatusOK,
+			wantInBody:    []string{`"total_records":1`, `"id":"` + approvedItem.ID.String()},
+			notWantInBody: draftItem.ID.String(),
+		},
+		{
+			name:       "invalid category",
+			actor:      steward,
+			query:      "?category_id=laptops",
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{string(api.ErrorItemSearchInvalid)},
+		},
+		{
+			name:       "csv",
+			actor:      steward,
+			query:      "?format=csv&coverage_status=" + string(api.ItemCoverageStatusApproved),
+			wantStatus: http.StatusOK,
+			wantInBody: []string{"Name,Coverage Status", approvedItem.Name + "," + string(api.ItemCoverageStatusApproved)},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON(stewardPath + "/" + domain.TypeItem + tt.query)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			if tt.notWantInBody != "" {
+				as.NotContains(body, tt.notWantInBody)
+			}
+
+			for _, w := range tt.wantInBody {
+				as.Contains(body, w)
+			}
+		})
+	}
+}
@@ -15,23 +15,41 @@ import (
 // gets the data for all Users.
 //
 // ---
+// parameters:
+//   - name: limit
+//     in: query
+//     required: false
+//     description: maximum number of records in a page, from 1 to 50, default 10. If neither limit nor cursor
+//       is given, all the records are returned in an array rather than a page.
+//   - name: cursor
+//     in: query
+//     required: false
+//     description: next_cursor or prev_cursor from the meta of a previous response, to get the next or previous page
+//   - name: sort
+//     in: query
+//     required: false
+//     description: field by which to sort, prefixed by "-" for descending order. One of
+//       last_name (default), first_name, email, created_at
+//   - name: filter
+//     in: query
+//     required: false
+//     description: comma-separated list of pairs like "field:value". Supported field: app_role
 // responses:
 //   '200':
-//     description: all users
+//     description: all users, or a page with "meta" and "data" if a limit or cursor is given
 //     schema:
-//       type: array
-//       items:
-//         "$ref": "#/definitions/User"
+//       "$ref": "#/definitions/Users"
 func usersList(c buffalo.Context) error {
 	var users models.Users
 	tx := models.Tx(c)
-	if err := users.GetAll(tx); err != nil {
-		if domain.IsOtherThanNoRows(err) {
-			return reportError(c, err)
-		}
-		return reportError(c, api.NewAppError(err, api.ErrorNoRows, api.CategoryNotFound))
+
+	query := api.NewQuery(c.Params())
+	meta, err := users.Query(tx, query)
+	if err != nil {
+		return reportError(c, err)
 	}
-	return renderOk(c, users.ConvertToAPI(tx))
+
+	return renderList(c, query, meta, users.ConvertToAPI(tx))
 }
 
 // swagger:operation GET /users/{id} Users UsersView
@@ -148,6 +166,43 @@ func usersMeFilesAttach(c buffalo.Context) error {
 	return renderUser(c, user)
 }
 
+// swagger:operation PUT /users/{id}/approval-limit Users UsersApprovalLimit
+//
+// UsersApprovalLimit
+//
+// set the maximum claim payout a steward or signator may approve. Admins may not set their own limit.
+//
+// ---
+// parameters:
+//   - name: id
+//     in: path
+//     required: true
+//     description: user ID
+//   - name: approval limit input
+//     in: body
+//     description: the approval limit, or null to remove the limit
+//     required: true
+//     schema:
+//       "$ref": "#/definitions/UserApprovalLimitInput"
+// responses:
+//   '200':
+//     description: updated User
+//     schema:
+//       "$ref": "#/definitions/User"
+func usersApprovalLimit(c buffalo.Context) error {
+	var input api.UserApprovalLimitInput
+	if err := StrictBind(c, &input); err != nil {
+		return reportError(c, err)
+	}
+
+	user := getReferencedUserFromCtx(c)
+	if err := user.SetApprovalLimit(models.Tx(c), input.ApprovalLimit); err != nil {
+		return reportError(c, err)
+	}
+
+	return renderUser(c, *user)
+}
+
 func renderUser(c buffalo.Context, user models.User) error {
 	tx := models.Tx(c)
 	return renderOk(c, user.ConvertToAPI(tx, true))
@@ -13,6 +13,84 @@ import (
 	"github.com/silinternational/cover-api/models"
 )
 
+func (as *ActionSuite) Test_UsersList() {
+	normalUser := models.CreateUserFixtures(as.DB, 2).Users[0]
+	steward := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+	steward2 := models.CreateAdminUsers(as.DB)[models.AppRoleSteward]
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		query      string
+		wantStatus int
+		wantIDs    []uuid.UUID
+		wantPaged  bool
+		wantNext   bool
+	}{
+		{
+			name:       "not an admin",
+			actor:      normalUser,
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "stewards",
+			actor:      steward,
+			query:      "?filter=app_role:" + string(models.AppRoleSteward) + "&sort=created_at",
+			wantStatus: http.StatusOK,
+			wantIDs:    []uuid.UUID{steward.ID, steward2.ID},
+		},
+		{
+			name:       "first page",
+			actor:      steward,
+			query:      "?filter=app_role:" + string(models.AppRoleSteward) + "&sort=-created_at&limit=1",
+			wantStatus: http.StatusOK,
+			wantIDs:    []uuid.UUID{steward2.ID},
+			wantPaged:  true,
+			wantNext:   true,
+		},
+		{
+			name:       "invalid sort",
+			actor:      steward,
+			query:      "?sort=staff_id",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/%s%s", domain.TypeUser, tt.query)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Get()
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+
+			if res.Code != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Meta api.Meta  `json:"meta"`
+				Data api.Users `json:"data"`
+			}
+			if tt.wantPaged {
+				as.NoError(json.Unmarshal([]byte(body), &response))
+				as.Equal(2, response.Meta.TotalRecords, "incorrect total")
+				as.Equal(tt.wantNext, response.Meta.NextCursor != "", "incorrect next cursor")
+			} else {
+				as.NoError(json.Unmarshal([]byte(body), &response.Data))
+			}
+
+			gotIDs := make([]uuid.UUID, len(response.Data))
+			for i := range response.Data {
+				gotIDs[i] = response.Data[i].ID
+			}
+			as.Equal(tt.wantIDs, gotIDs, "incorrect users")
+		})
+	}
+}
+
 func (as *ActionSuite) Test_usersMe() {
 	db := as.DB
 
@@ -277,3 +355,81 @@ func (as *ActionSuite) Test_UsersMeFilesAttach() {
 		})
 	}
 }
+
+func (as *ActionSuite) Test_UsersApprovalLimit() {
+	customer := models.CreateUserFixtures(as.DB, 1).Users[0]
+	admins := models.CreateAdminUsers(as.DB)
+	steward := admins[models.AppRoleSteward]
+	signator := admins[models.AppRoleSignator]
+	signator2 := models.CreateAdminUsers(as.DB)[models.AppRoleSignator]
+
+	limit := api.Currency(500000)
+
+	tests := []struct {
+		name       string
+		actor      models.User
+		user       models.User
+		input      api.UserApprovalLimitInput
+		wantStatus int
+		wantInBody []string
+	}{
+		{
+			name:       "customer",
+			actor:      customer,
+			user:       signator,
+			input:      api.UserApprovalLimitInput{ApprovalLimit: &limit},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "own limit",
+			actor:      signator,
+			user:       signator,
+			input:      api.UserApprovalLimitInput{ApprovalLimit: &limit},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "not an admin",
+			actor:      steward,
+			user:       customer,
+			input:      api.UserApprovalLimitInput{ApprovalLimit: &limit},
+			wantStatus: http.StatusBadRequest,
+			wantInBody: []string{api.ErrorUserApprovalLimit.String()},
+		},
+		{
+			name:       "steward may not set a signator's limit",
+			actor:      steward,
+			user:       signator,
+			input:      api.UserApprovalLimitInput{ApprovalLimit: &limit},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "good",
+			actor:      signator2,
+			user:       signator,
+			input:      api.UserApprovalLimitInput{ApprovalLimit: &limit},
+			wantStatus: http.StatusOK,
+			wantInBody: []string{`"approval_limit":500000`},
+		},
+	}
+
+	for _, tt := range tests {
+		as.T().Run(tt.name, func(t *testing.T) {
+			req := as.JSON("/users/%s/approval-limit", tt.user.ID)
+			req.Headers["Authorization"] = fmt.Sprintf("Bearer %s", tt.actor.Email)
+			req.Headers["content-type"] = "application/json"
+			res := req.Put(tt.input)
+
+			body := res.Body.String()
+			as.Equal(tt.wantStatus, res.Code, "incorrect status code returned, body: %s", body)
+			as.verifyResponseData(tt.wantInBody, body, "")
+
+			if res.Code != http.StatusOK {
+				return
+			}
+
+			var user models.User
+			as.NoError(user.FindByID(as.DB, tt.user.ID))
+			as.Equal(int(limit), user.ApprovalLimit.Int, "incorrect ApprovalLimit")
+		})
+	}
+}
@@ -19,8 +19,25 @@ const (
 	ResourceApprove    = "approve"
 	ResourceDeny       = "deny"
 	ResourceRecent     = "recent"
+	ResourceQueue      = "queue"
+	ResourceFile       = "file"
+	ResourceHistory    = "history"
+	ResourceReverse    = "reverse"
+	ResourceAppeal     = "appeal"
+	ResourceWithdraw   = "withdraw"
+
+	ResourceAdjustments   = "adjustments"
+	ResourceApprovalLimit = "approval-limit"
+	ResourceAssignment    = "assignment"
+	ResourceDisbursements = "disbursements"
+	ResourceLedger        = "ledger"
+	ResourceLossRatio     = "loss-ratio"
+	ResourceRecoveries    = "recoveries"
 )
 
+// ListResponse is a page of a list, returned by list endpoints when a limit or cursor is given. Without those, list
+// endpoints return all the records in an array.
+//
 // swagger:model
 type ListResponse struct {
 	// Meta contains pagination data
@@ -30,8 +47,22 @@ type ListResponse struct {
 	Data interface{} `json:"data"`
 }
 
-// TODO: implement Meta type to provide pagination properties
-type Meta struct{}
+// Meta contains pagination data
+//
+// swagger:model
+type Meta struct {
+	// maximum number of records in a page
+	Limit int `json:"limit"`
+
+	// total number of records on all pages
+	TotalRecords int `json:"total_records"`
+
+	// cursor to give in the next request to get the next page, or empty if this is the last page
+	NextCursor string `json:"next_cursor"`
+
+	// cursor to give in the next request to get the previous page, or empty if this is the first page
+	PrevCursor string `json:"prev_cursor"`
+}
 
 type ErrorKey string
 
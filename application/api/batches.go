@@ -1,6 +1,273 @@
 package api
 
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
 // swagger:model
 type BatchApproveResponse struct {
 	NumberOfRecordsApproved int `json:"number_of_records_approved"`
 }
+
+// swagger:model
+type BatchReverseInput struct {
+	// set to true if the batch was already posted in the accounting system, so that compensating entries are
+	// created to cancel out the original entries
+	Posted bool `json:"posted"`
+
+	// reason for the reversal
+	Reason string `json:"reason"`
+}
+
+// swagger:model
+type BatchReverseResponse struct {
+	NumberOfRecordsReversed int `json:"number_of_records_reversed"`
+}
+
+// swagger:model
+type Batches []Batch
+
+// Batch is a snapshot of the ledger entries exported to the accounting system
+//
+// swagger:model
+type Batch struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// accounting export format, e.g. "sage"
+	ProviderType string `json:"provider_type"`
+
+	// Period for a monthly or custom period batch, or Renewal for an annual renewal batch
+	Type BatchType `json:"type"`
+
+	// first day of the batch period
+	//
+	// swagger:strfmt date
+	BatchDate time.Time `json:"batch_date"`
+
+	// last day of the batch period
+	//
+	// swagger:strfmt date
+	EndDate time.Time `json:"end_date"`
+
+	// name of the generated batch file
+	Filename string `json:"filename"`
+
+	// hex-encoded SHA-256 hash of the batch file, also sent in the X-Checksum-SHA256 header of the file download
+	Checksum string `json:"checksum"`
+
+	// number of ledger entries in the batch
+	EntryCount int `json:"entry_count"`
+
+	// sum of all premium ledger entries in the batch
+	TotalPremiums Currency `json:"total_premiums"`
+
+	// sum of all claim ledger entries in the batch
+	TotalClaims Currency `json:"total_claims"`
+
+	// ID of the user who generated the batch
+	//
+	// swagger:strfmt uuid4
+	CreatedByID uuid.UUID `json:"created_by_id"`
+
+	// time the batch was generated
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+
+	// time the batch was approved, null if not yet approved
+	//
+	// swagger:strfmt date-time
+	ApprovedAt *time.Time `json:"approved_at"`
+
+	// ID of the user who approved the batch
+	//
+	// swagger:strfmt uuid4
+	ApprovedByID *uuid.UUID `json:"approved_by_id"`
+
+	// time the batch approval was reversed, null if not reversed
+	//
+	// swagger:strfmt date-time
+	ReversedAt *time.Time `json:"reversed_at"`
+
+	// ID of the user who reversed the batch approval
+	//
+	// swagger:strfmt uuid4
+	ReversedByID *uuid.UUID `json:"reversed_by_id"`
+
+	// reason given for the reversal
+	ReversalReason string `json:"reversal_reason"`
+}
+
+// BatchType
+//
+// may be one of: Period, Renewal
+//
+// swagger:model
+type BatchType string
+
+const (
+	BatchTypePeriod  = BatchType("Period")
+	BatchTypeRenewal = BatchType("Renewal")
+)
+
+// BatchProblemType
+//
+// may be one of: MissingAccount, MissingIncomeAccount, MissingRiskCategoryCC, Unbalanced, DescriptionTruncated
+//
+// swagger:model
+type BatchProblemType string
+
+const (
+	BatchProblemMissingAccount        = BatchProblemType("MissingAccount")
+	BatchProblemMissingIncomeAccount  = BatchProblemType("MissingIncomeAccount")
+	BatchProblemMissingRiskCategoryCC = BatchProblemType("MissingRiskCategoryCC")
+	BatchProblemUnbalanced            = BatchProblemType("Unbalanced")
+	BatchProblemDescriptionTruncated  = BatchProblemType("DescriptionTruncated")
+)
+
+// BatchValidationReport lists the problems found in a batch before it is exported
+//
+// swagger:model
+type BatchValidationReport struct {
+	// false if any problem is an error. Warnings do not prevent export.
+	IsValid bool `json:"is_valid"`
+
+	// number of ledger entries in the batch
+	NumberOfEntries int `json:"number_of_entries"`
+
+	// sum of all transactions in the batch, must be zero for a balanced journal
+	NetAmount Currency `json:"net_amount"`
+
+	Problems []BatchProblem `json:"problems"`
+}
+
+// AddProblem adds a problem to the report and updates IsValid
+func (b *BatchValidationReport) AddProblem(p BatchProblem) {
+	b.Problems = append(b.Problems, p)
+	b.IsValid = !b.HasErrors()
+}
+
+// HasErrors returns true if any problem in the report is an error rather than a warning
+func (b *BatchValidationReport) HasErrors() bool {
+	for _, p := range b.Problems {
+		if !p.IsWarning {
+			return true
+		}
+	}
+	return false
+}
+
+// swagger:model
+type BatchProblem struct {
+	Type BatchProblemType `json:"type"`
+
+	// true if the problem does not prevent export
+	IsWarning bool `json:"is_warning"`
+
+	// ID of the ledger entry with the problem, if the problem is specific to one entry
+	//
+	// swagger:strfmt uuid4
+	LedgerEntryID *uuid.UUID `json:"ledger_entry_id,omitempty"`
+
+	// account of the block with the problem, if the problem is specific to one account block
+	Account string `json:"account,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// AnnualRenewalPreview is the renewal premium that would be billed for a year, if the annual renewal was processed
+//
+// swagger:model
+type AnnualRenewalPreview struct {
+	// year of the renewal
+	Year int `json:"year"`
+
+	// number of items to be renewed
+	NumberOfItems int `json:"number_of_items"`
+
+	// total renewal premium of all items, including premium minimum top-ups
+	TotalPremium Currency `json:"total_premium"`
+
+	// total of the premium minimum top-ups
+	TotalPremiumMinimum Currency `json:"total_premium_minimum"`
+
+	// renewal premium of each item
+	Items []AnnualRenewalItem `json:"items"`
+
+	// renewal premium of each policy
+	Policies []AnnualRenewalPolicy `json:"policies"`
+
+	// renewal premium of each entity code
+	EntityCodes []AnnualRenewalEntityCode `json:"entity_codes"`
+}
+
+// swagger:model
+type AnnualRenewalItem struct {
+	// item ID
+	//
+	// swagger:strfmt uuid4
+	ItemID uuid.UUID `json:"item_id"`
+
+	// item name
+	Name string `json:"name"`
+
+	// policy ID
+	//
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// entity code of the policy
+	EntityCode string `json:"entity_code"`
+
+	// coverage amount of the item
+	CoverageAmount Currency `json:"coverage_amount"`
+
+	// renewal premium of the item, including any premium minimum top-up
+	Premium Currency `json:"premium"`
+
+	// premium minimum top-up charged with the item
+	PremiumMinimum Currency `json:"premium_minimum"`
+}
+
+// swagger:model
+type AnnualRenewalPolicy struct {
+	// policy ID
+	//
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// policy name
+	Name string `json:"name"`
+
+	// entity code of the policy
+	EntityCode string `json:"entity_code"`
+
+	// number of items to be renewed on the policy
+	NumberOfItems int `json:"number_of_items"`
+
+	// total renewal premium of the policy, including premium minimum top-ups
+	Premium Currency `json:"premium"`
+
+	// total premium minimum top-up of the policy
+	PremiumMinimum Currency `json:"premium_minimum"`
+}
+
+// swagger:model
+type AnnualRenewalEntityCode struct {
+	// entity code
+	EntityCode string `json:"entity_code"`
+
+	// number of items to be renewed for the entity code
+	NumberOfItems int `json:"number_of_items"`
+
+	// total renewal premium for the entity code, including premium minimum top-ups
+	Premium Currency `json:"premium"`
+
+	// total premium minimum top-up for the entity code
+	PremiumMinimum Currency `json:"premium_minimum"`
+}
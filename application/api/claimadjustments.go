@@ -0,0 +1,69 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// swagger:model
+type ClaimAdjustments []ClaimAdjustment
+
+// ClaimAdjustment is a change to the payout of a claim item after the claim was approved. An adjustment takes
+// effect when it is approved by an admin other than the one who requested it.
+//
+// swagger:model
+type ClaimAdjustment struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// claim ID
+	//
+	// swagger:strfmt uuid4
+	ClaimID uuid.UUID `json:"claim_id"`
+
+	// claim item ID
+	//
+	// swagger:strfmt uuid4
+	ClaimItemID uuid.UUID `json:"claim_item_id"`
+
+	// payout amount of the claim item before the adjustment (0.01 USD)
+	OldPayoutAmount Currency `json:"old_payout_amount"`
+
+	// payout amount of the claim item after the adjustment (0.01 USD)
+	NewPayoutAmount Currency `json:"new_payout_amount"`
+
+	// reason for the adjustment
+	Reason string `json:"reason"`
+
+	// ID of the user who requested the adjustment
+	//
+	// swagger:strfmt uuid4
+	RequestedByID uuid.UUID `json:"requested_by_id"`
+
+	// ID of the user who approved the adjustment, or null if it has not been approved
+	//
+	// swagger:strfmt uuid4
+	ApprovedByID *uuid.UUID `json:"approved_by_id"`
+
+	// date-time the adjustment was approved, or null if it has not been approved
+	//
+	// swagger:strfmt date-time
+	ApprovedAt *time.Time `json:"approved_at"`
+
+	// date-time created
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// swagger:model
+type ClaimAdjustmentInput struct {
+	// new payout amount of the claim item (0.01 USD)
+	PayoutAmount Currency `json:"payout_amount"`
+
+	// reason for the adjustment
+	Reason string `json:"reason"`
+}
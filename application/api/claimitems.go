@@ -35,6 +35,72 @@ var PayoutOptionDescriptions = map[PayoutOption]string{
 	PayoutOptionFixedFraction: "Payout is a fixed portion of the item's covered value.",
 }
 
+// PayoutValue identifies a value used to calculate a payout
+//
+// may be one of: RepairEstimate, RepairActual, RepairThreshold, ReplaceEstimate, ReplaceActual, FMV, CoverageAmount
+//
+// swagger:model
+type PayoutValue string
+
+const (
+	PayoutValueRepairEstimate  = PayoutValue("RepairEstimate")
+	PayoutValueRepairActual    = PayoutValue("RepairActual")
+	PayoutValueRepairThreshold = PayoutValue("RepairThreshold")
+	PayoutValueReplaceEstimate = PayoutValue("ReplaceEstimate")
+	PayoutValueReplaceActual   = PayoutValue("ReplaceActual")
+	PayoutValueFMV             = PayoutValue("FMV")
+	PayoutValueCoverageAmount  = PayoutValue("CoverageAmount")
+)
+
+// PayoutBreakdown explains how the payout amount of a claim item is calculated. The claimed value for the payout
+// option is limited by the repair threshold, for repairs, and by the coverage amount. The deductible is then
+// subtracted to give the payout amount.
+//
+// swagger:model
+type PayoutBreakdown struct {
+	// value claimed for the payout option (0.01 USD)
+	ClaimedValue Currency `json:"claimed_value"`
+
+	// source of the claimed value
+	ClaimedValueSource PayoutValue `json:"claimed_value_source"`
+
+	// repair threshold multiplied by the fair market value, for repairs (0.01 USD)
+	RepairThresholdValue Currency `json:"repair_threshold_value,omitempty"`
+
+	// coverage amount of the item (0.01 USD)
+	CoverageAmount Currency `json:"coverage_amount"`
+
+	// lesser of the claimed value, the repair threshold value, and the coverage amount (0.01 USD)
+	Basis Currency `json:"basis"`
+
+	// value that determined the basis
+	LimitedBy PayoutValue `json:"limited_by"`
+
+	// deductible rule applied, or null if the default deductible was applied
+	//
+	// swagger:strfmt uuid4
+	DeductibleRuleID *uuid.UUID `json:"deductible_rule_id"`
+
+	// description of the deductible applied
+	DeductibleDescription string `json:"deductible_description"`
+
+	// fraction of the basis deducted, or null if the deductible was a flat amount
+	DeductiblePercentage *float64 `json:"deductible_percentage"`
+
+	// deductible subtracted from the basis (0.01 USD)
+	Deductible Currency `json:"deductible"`
+
+	// total of the approved adjustments made to the payout after the claim was approved (0.01 USD)
+	Adjustment Currency `json:"adjustment"`
+
+	// difference between the recorded payout amount and the payout calculated from the values above, such as for a
+	// payout recorded with an earlier calculation (0.01 USD)
+	Correction Currency `json:"correction"`
+
+	// resulting payout amount (0.01 USD)
+	PayoutAmount Currency `json:"payout_amount"`
+}
+
 // swagger:model
 type ClaimItems []ClaimItem
 
@@ -82,7 +148,21 @@ type ClaimItem struct {
 	// payout amount (0.01 USD)
 	PayoutAmount Currency `json:"payout_amount,omitempty"`
 
-	// coverage amount at the time the Claim was created (0.01 USD)
+	// deductible subtracted from the payout amount (0.01 USD)
+	Deductible Currency `json:"deductible,omitempty"`
+
+	// deductible rule used to calculate the deductible, or null if the default deductible was used
+	//
+	// swagger:strfmt uuid4
+	DeductibleRuleID *uuid.UUID `json:"deductible_rule_id"`
+
+	// description of the deductible rule at the time the payout was calculated
+	DeductibleDescription string `json:"deductible_description,omitempty"`
+
+	// explanation of the payout amount
+	PayoutBreakdown PayoutBreakdown `json:"payout_breakdown"`
+
+	// coverage amount when the payout was last calculated, or when the Claim was created (0.01 USD)
 	CoverageAmount Currency `json:"coverage_amount,omitempty"`
 
 	// fair market value (0.01 USD)
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// swagger:model
+type ClaimRecoveries []ClaimRecovery
+
+// ClaimRecovery is money recovered after a claim was paid out, such as a reimbursement from a third party or the
+// value of a stolen item that was found
+//
+// swagger:model
+type ClaimRecovery struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// claim ID
+	//
+	// swagger:strfmt uuid4
+	ClaimID uuid.UUID `json:"claim_id"`
+
+	// source of the recovery, e.g. the name of the third party
+	Source string `json:"source"`
+
+	// amount recovered (0.01 USD)
+	Amount Currency `json:"amount"`
+
+	// date the recovery was received
+	//
+	// swagger:strfmt date
+	RecoveryDate time.Time `json:"recovery_date"`
+
+	// notes about the recovery
+	Notes string `json:"notes"`
+
+	// ID of the user who recorded the recovery
+	//
+	// swagger:strfmt uuid4
+	CreatedByID uuid.UUID `json:"created_by_id"`
+
+	// date-time created
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// swagger:model
+type ClaimRecoveryInput struct {
+	// source of the recovery, e.g. the name of the third party
+	Source string `json:"source"`
+
+	// amount recovered (0.01 USD)
+	Amount Currency `json:"amount"`
+
+	// date the recovery was received
+	//
+	// swagger:strfmt date
+	RecoveryDate time.Time `json:"recovery_date"`
+
+	// notes about the recovery
+	Notes string `json:"notes"`
+}
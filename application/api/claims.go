@@ -35,11 +35,17 @@ func (c ClaimIncidentType) Description() string {
 
 // ClaimStatus
 //
-// may be one of: Draft, Review1, Review2, Review3, Revision, Receipt, Approved, Paid, Denied
+// may be one of: Draft, Review1, Review2, Review3, Revision, Receipt, Approved, Paid, Denied, Withdrawn
 //
 // swagger:model
 type ClaimStatus string
 
+// IsFiled returns false for claims that were never submitted or were withdrawn by the member, which are
+// excluded from claim statistics
+func (s ClaimStatus) IsFiled() bool {
+	return s != ClaimStatusDraft && s != ClaimStatusWithdrawn
+}
+
 func (s ClaimStatus) WasReviewed() bool {
 	switch s {
 	case ClaimStatusDenied, ClaimStatusRevision, ClaimStatusReceipt,
@@ -77,15 +83,16 @@ var AllClaimIncidentTypes = []ClaimIncidentTypeStruct{
 }
 
 const (
-	ClaimStatusDraft    = ClaimStatus("Draft")
-	ClaimStatusReview1  = ClaimStatus("Review1")
-	ClaimStatusReview2  = ClaimStatus("Review2")
-	ClaimStatusReview3  = ClaimStatus("Review3")
-	ClaimStatusRevision = ClaimStatus("Revision")
-	ClaimStatusReceipt  = ClaimStatus("Receipt")
-	ClaimStatusApproved = ClaimStatus("Approved")
-	ClaimStatusPaid     = ClaimStatus("Paid")
-	ClaimStatusDenied   = ClaimStatus("Denied")
+	ClaimStatusDraft     = ClaimStatus("Draft")
+	ClaimStatusReview1   = ClaimStatus("Review1")
+	ClaimStatusReview2   = ClaimStatus("Review2")
+	ClaimStatusReview3   = ClaimStatus("Review3")
+	ClaimStatusRevision  = ClaimStatus("Revision")
+	ClaimStatusReceipt   = ClaimStatus("Receipt")
+	ClaimStatusApproved  = ClaimStatus("Approved")
+	ClaimStatusPaid      = ClaimStatus("Paid")
+	ClaimStatusDenied    = ClaimStatus("Denied")
+	ClaimStatusWithdrawn = ClaimStatus("Withdrawn")
 )
 
 // swagger:model
@@ -143,9 +150,37 @@ type Claim struct {
 	// total payout (0.01 USD)
 	TotalPayout Currency `json:"total_payout,omitempty"`
 
+	// total of all disbursements of the payout, including those not yet paid (0.01 USD)
+	TotalDisbursed Currency `json:"total_disbursed"`
+
+	// total of all money recovered after the payout, such as third party reimbursements (0.01 USD)
+	TotalRecovered Currency `json:"total_recovered"`
+
+	// total payout less the total recovered (0.01 USD)
+	NetLoss Currency `json:"net_loss"`
+
+	// number of final approvals given while in Review3
+	Approvals int `json:"approvals"`
+
+	// number of final approvals required for the payout, according to the claim workflow
+	ApprovalsRequired int `json:"approvals_required"`
+
 	// message from a reviewer detailing the revisions needed
 	StatusReason string `json:"status_reason"`
 
+	// date-time the denied claim was appealed, or null if it has not been appealed
+	//
+	// swagger:strfmt date-time
+	AppealedAt *time.Time `json:"appealed_at"`
+
+	// reason given by the member for the appeal
+	AppealReason string `json:"appeal_reason"`
+
+	// date-time the claim was escalated because its payout exceeded an approver's limit, or null if not escalated
+	//
+	// swagger:strfmt date-time
+	EscalatedAt *time.Time `json:"escalated_at"`
+
 	// list of items included in claim
 	Items ClaimItems `json:"claim_items"`
 
@@ -187,8 +222,29 @@ type ClaimUpdateInput struct {
 	IncidentDescription string `json:"incident_description"`
 }
 
+// swagger:model
+type ClaimApproveInput struct {
+	// amount of the first disbursement, if the claim is to be paid in installments. Only used for the final
+	// approval. If omitted, the full payout is disbursed. (0.01 USD)
+	Disbursement *Currency `json:"disbursement"`
+}
+
+// swagger:model
+type ClaimDisbursementInput struct {
+	// amount to disburse, or 0 to disburse the remaining balance of the payout (0.01 USD)
+	Amount Currency `json:"amount"`
+}
+
 // swagger:model
 type ClaimStatusInput struct {
 	// message from a reviewer noting the reason for the new status, e.g. detailing the revisions needed
 	StatusReason string `json:"status_reason"`
 }
+
+// swagger:model
+type ClaimAppealInput struct {
+	// reason for the appeal, e.g. a description of the new evidence
+	//
+	// required: true
+	AppealReason string `json:"appeal_reason"`
+}
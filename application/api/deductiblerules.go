@@ -0,0 +1,102 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// DeductibleType
+//
+// may be one of: Percentage, Flat
+//
+// swagger:model
+type DeductibleType string
+
+const (
+	DeductibleTypePercentage = DeductibleType("Percentage")
+	DeductibleTypeFlat       = DeductibleType("Flat")
+)
+
+// swagger:model
+type DeductibleRules []DeductibleRule
+
+// DeductibleRule determines the deductible subtracted from a claim item payout. A rule may be limited to an incident
+// type, a policy type, and a risk category. When more than one rule matches a claim item, the most specific rule is
+// used, with incident type taking precedence over policy type and policy type over risk category.
+//
+// swagger:model
+type DeductibleRule struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// claim incident type the rule applies to, or empty for all incident types
+	IncidentType ClaimIncidentType `json:"incident_type"`
+
+	// policy type the rule applies to, or empty for all policy types
+	PolicyType PolicyType `json:"policy_type"`
+
+	// risk category the rule applies to, or null for all risk categories
+	//
+	// swagger:strfmt uuid4
+	RiskCategoryID *uuid.UUID `json:"risk_category_id"`
+
+	// type of deductible
+	Type DeductibleType `json:"type"`
+
+	// fraction of the payout basis to deduct, for a Percentage rule
+	Percentage float64 `json:"percentage"`
+
+	// amount to deduct, for a Flat rule (0.01 USD)
+	FlatAmount Currency `json:"flat_amount"`
+
+	// minimum deductible, or 0 for no minimum (0.01 USD)
+	MinimumAmount Currency `json:"minimum_amount"`
+
+	// maximum deductible, or 0 for no maximum (0.01 USD)
+	MaximumAmount Currency `json:"maximum_amount"`
+
+	// human-readable description of the rule
+	Description string `json:"description"`
+
+	// date-time created
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+
+	// date-time last updated
+	//
+	// swagger:strfmt date-time
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// swagger:model
+type DeductibleRuleInput struct {
+	// claim incident type the rule applies to, or empty for all incident types
+	IncidentType ClaimIncidentType `json:"incident_type"`
+
+	// policy type the rule applies to, or empty for all policy types
+	PolicyType PolicyType `json:"policy_type"`
+
+	// risk category the rule applies to, or null for all risk categories
+	//
+	// swagger:strfmt uuid4
+	RiskCategoryID *uuid.UUID `json:"risk_category_id"`
+
+	// type of deductible
+	Type DeductibleType `json:"type"`
+
+	// fraction of the payout basis to deduct, for a Percentage rule
+	Percentage float64 `json:"percentage"`
+
+	// amount to deduct, for a Flat rule (0.01 USD)
+	FlatAmount Currency `json:"flat_amount"`
+
+	// minimum deductible, or 0 for no minimum (0.01 USD)
+	MinimumAmount Currency `json:"minimum_amount"`
+
+	// maximum deductible, or 0 for no maximum (0.01 USD)
+	MaximumAmount Currency `json:"maximum_amount"`
+}
@@ -14,10 +14,13 @@ const (
 
 	ErrorCreateFailure            = ErrorKey("ErrorCreateFailure")
 	ErrorDestroyFailure           = ErrorKey("ErrorDestroyFailure")
+	ErrorExportTooLarge           = ErrorKey("ErrorExportTooLarge")
 	ErrorGenericInternalServer    = ErrorKey("ErrorGenericInternalServer")
 	ErrorFailedToConvertToAPIType = ErrorKey("ErrorFailedToConvertToAPIType")
 	ErrorForeignKeyViolation      = ErrorKey("ErrorForeignKeyViolation")
+	ErrorInvalidCursor            = ErrorKey("ErrorInvalidCursor")
 	ErrorInvalidRequestBody       = ErrorKey("ErrorInvalidRequestBody")
+	ErrorInvalidSort              = ErrorKey("ErrorInvalidSort")
 	ErrorMissingSessionKey        = ErrorKey("ErrorMissingSessionKey")
 	ErrorMustBeAValidUUID         = ErrorKey("ErrorMustBeAValidUUID")
 	ErrorNoRows                   = ErrorKey("ErrorNoRows")
@@ -58,10 +61,29 @@ const (
 	ErrorUnableToReadFile        = ErrorKey("ErrorUnableToReadFile")
 	ErrorUnableToStoreFile       = ErrorKey("ErrorUnableToStoreFile")
 
+	// Batch
+	ErrorBatchAlreadyApproved       = ErrorKey("ErrorBatchAlreadyApproved")
+	ErrorBatchAlreadyReversed       = ErrorKey("ErrorBatchAlreadyReversed")
+	ErrorBatchInvalidPeriod         = ErrorKey("ErrorBatchInvalidPeriod")
+	ErrorBatchInvalidProvider       = ErrorKey("ErrorBatchInvalidProvider")
+	ErrorBatchNotApproved           = ErrorKey("ErrorBatchNotApproved")
+	ErrorBatchNotFound              = ErrorKey("ErrorBatchNotFound")
+	ErrorBatchPeriodAlreadyApproved = ErrorKey("ErrorBatchPeriodAlreadyApproved")
+	ErrorBatchValidation            = ErrorKey("ErrorBatchValidation")
+
 	// Claim
-	ErrorClaimFromContext      = ErrorKey("ErrorClaimFromContext")
-	ErrorClaimStatus           = ErrorKey("ErrorClaimStatus")
-	ErrorClaimMissingClaimItem = ErrorKey("ErrorClaimMissingClaimItem")
+	ErrorClaimFromContext       = ErrorKey("ErrorClaimFromContext")
+	ErrorClaimStatus            = ErrorKey("ErrorClaimStatus")
+	ErrorClaimMissingClaimItem  = ErrorKey("ErrorClaimMissingClaimItem")
+	ErrorClaimDisbursement      = ErrorKey("ErrorClaimDisbursement")
+	ErrorClaimAlreadyAppealed   = ErrorKey("ErrorClaimAlreadyAppealed")
+	ErrorClaimAppealExpired     = ErrorKey("ErrorClaimAppealExpired")
+	ErrorClaimAppealReviewer    = ErrorKey("ErrorClaimAppealReviewer")
+	ErrorClaimTransitionRole    = ErrorKey("ErrorClaimTransitionRole")
+	ErrorClaimDuplicateApproval = ErrorKey("ErrorClaimDuplicateApproval")
+	ErrorClaimApprovalLimit     = ErrorKey("ErrorClaimApprovalLimit")
+	ErrorClaimSearchInvalid     = ErrorKey("ErrorClaimSearchInvalid")
+	ErrorClaimRecoveryAmount    = ErrorKey("ErrorClaimRecoveryAmount")
 
 	// Item
 	ErrorItemFromContext              = ErrorKey("ErrorItemFromContext")
@@ -71,6 +93,7 @@ const (
 	ErrorItemInvalidCoverageEndDate   = ErrorKey("ErrorItemInvalidCoverageEndDate")
 	ErrorInvalidCategory              = ErrorKey("ErrorInvalidCategory")
 	ErrorItemHasActiveClaim           = ErrorKey("ErrorItemHasActiveClaim")
+	ErrorItemSearchInvalid            = ErrorKey("ErrorItemSearchInvalid")
 
 	// Policy
 	ErrorPolicyFromContext        = ErrorKey("ErrorPolicyFromContext")
@@ -79,11 +102,39 @@ const (
 	ErrorPolicyUpdateInvalidInput = ErrorKey("ErrorPolicyUpdateInvalidInput")
 	ErrorPolicyUserInviteCode     = ErrorKey("ErrorPolicyUserInviteCode")
 	ErrorPolicyHasNoHouseholdID   = ErrorKey("ErrorPolicyHasNoHouseholdID")
+	ErrorPolicyLedgerInvalidYear  = ErrorKey("ErrorPolicyLedgerInvalidYear")
+
+	// Queue
+	ErrorQueueAlreadyAssigned = ErrorKey("ErrorQueueAlreadyAssigned")
+	ErrorQueueInvalidSteward  = ErrorKey("ErrorQueueInvalidSteward")
+	ErrorQueueNotAssigned     = ErrorKey("ErrorQueueNotAssigned")
+	ErrorQueueStatus          = ErrorKey("ErrorQueueStatus")
+
+	// User
+	ErrorUserApprovalLimit = ErrorKey("ErrorUserApprovalLimit")
+
+	// Report
+	ErrorReportInvalidParameter = ErrorKey("ErrorReportInvalidParameter")
 
 	// PolicyDependent
 	ErrorPolicyDependentCreate = ErrorKey("ErrorPolicyDependentCreate")
 	ErrorPolicyDependentDelete = ErrorKey("ErrorPolicyDependentDelete")
 
+	// DeductibleRule
+	ErrorDeductibleRuleAmount    = ErrorKey("ErrorDeductibleRuleAmount")
+	ErrorDeductibleRuleDuplicate = ErrorKey("ErrorDeductibleRuleDuplicate")
+
+	// PremiumRate
+	ErrorPremiumRateCategory      = ErrorKey("ErrorPremiumRateCategory")
+	ErrorPremiumRateEffectiveDate = ErrorKey("ErrorPremiumRateEffectiveDate")
+	ErrorPremiumRateInEffect      = ErrorKey("ErrorPremiumRateInEffect")
+
+	// ClaimAdjustment
+	ErrorClaimAdjustmentAmount          = ErrorKey("ErrorClaimAdjustmentAmount")
+	ErrorClaimAdjustmentAlreadyApproved = ErrorKey("ErrorClaimAdjustmentAlreadyApproved")
+	ErrorClaimAdjustmentInvalidApprover = ErrorKey("ErrorClaimAdjustmentInvalidApprover")
+	ErrorClaimAdjustmentPending         = ErrorKey("ErrorClaimAdjustmentPending")
+
 	// ClaimItem
 	ErrorClaimItemCreateInvalidInput     = ErrorKey("ErrorClaimItemCreateInvalidInput")
 	ErrorClaimItemNotRepairable          = ErrorKey("ClaimItemNotRepairable")
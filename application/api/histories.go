@@ -1,6 +1,114 @@
 package api
 
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
 const (
 	HistoryActionCreate = "Create"
 	HistoryActionUpdate = "Update"
 )
+
+// swagger:model
+type ClaimHistories []ClaimHistory
+
+// ClaimHistory is a change made to a claim or one of its claim items
+//
+// swagger:model
+type ClaimHistory struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// claim ID
+	//
+	// swagger:strfmt uuid4
+	ClaimID uuid.UUID `json:"claim_id"`
+
+	// claim item ID, if the change was made to a claim item
+	//
+	// swagger:strfmt uuid4
+	ClaimItemID *uuid.UUID `json:"claim_item_id"`
+
+	// the kind of change, Create or Update
+	Action string `json:"action"`
+
+	// name of the changed field
+	FieldName string `json:"field_name"`
+
+	// value before the change
+	OldValue string `json:"old_value"`
+
+	// value after the change
+	NewValue string `json:"new_value"`
+
+	// user who made the change
+	User HistoryUser `json:"user"`
+
+	// date-time of the change
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// swagger:model
+type PolicyHistories []PolicyHistory
+
+// PolicyHistory is a change made to a policy or one of its items
+//
+// swagger:model
+type PolicyHistory struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// policy ID
+	//
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// item ID, if the change was made to an item
+	//
+	// swagger:strfmt uuid4
+	ItemID *uuid.UUID `json:"item_id"`
+
+	// the kind of change, Create or Update
+	Action string `json:"action"`
+
+	// name of the changed field
+	FieldName string `json:"field_name"`
+
+	// value before the change
+	OldValue string `json:"old_value"`
+
+	// value after the change
+	NewValue string `json:"new_value"`
+
+	// user who made the change
+	User HistoryUser `json:"user"`
+
+	// date-time of the change
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// HistoryUser identifies the user who made a change
+//
+// swagger:model
+type HistoryUser struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// first name
+	FirstName string `json:"first_name"`
+
+	// last name
+	LastName string `json:"last_name"`
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// PolicyLedger is a statement of account for a policy
+//
+// swagger:model
+type PolicyLedger struct {
+	// policy ID
+	//
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// year of the statement, or 0 if the statement includes all years
+	Year int `json:"year"`
+
+	// balance of all entries before the statement period
+	OpeningBalance Currency `json:"opening_balance"`
+
+	// balance of all entries up to the end of the statement period
+	ClosingBalance Currency `json:"closing_balance"`
+
+	// ledger entries in the statement period, oldest first
+	Entries PolicyLedgerEntries `json:"entries"`
+}
+
+// swagger:model
+type PolicyLedgerEntries []PolicyLedgerEntry
+
+// PolicyLedgerEntry is a charge or credit on a policy. Charges, such as new coverage and renewals, have a positive
+// amount. Credits, such as refunds and claim payouts, have a negative amount.
+//
+// swagger:model
+type PolicyLedgerEntry struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// type of entry, e.g. "NewCoverage", "CoverageRenewal", "CoverageRefund", or "Claim"
+	Type string `json:"type"`
+
+	// item ID, if the entry is for an item
+	//
+	// swagger:strfmt uuid4
+	ItemID *uuid.UUID `json:"item_id"`
+
+	// claim ID, if the entry is for a claim
+	//
+	// swagger:strfmt uuid4
+	ClaimID *uuid.UUID `json:"claim_id"`
+
+	// description of the entry
+	Description string `json:"description"`
+
+	// amount of the entry
+	Amount Currency `json:"amount"`
+
+	// running balance of the policy after this entry
+	Balance Currency `json:"balance"`
+
+	// date the entry was submitted
+	//
+	// swagger:strfmt date
+	DateSubmitted time.Time `json:"date_submitted"`
+
+	// date the entry was entered into the accounting system
+	//
+	// swagger:strfmt date
+	DateEntered *time.Time `json:"date_entered"`
+}
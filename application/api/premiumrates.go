@@ -0,0 +1,65 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// swagger:model
+type PremiumRates []PremiumRate
+
+// PremiumRate is the rate used to calculate the annual premium of an item, as a fraction of its coverage amount. A
+// rate applies to all items in a risk category or in an item category, starting on its effective date. A rate for
+// an item category overrides a rate for a risk category.
+//
+// swagger:model
+type PremiumRate struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// risk category the rate applies to, if not for an item category
+	//
+	// swagger:strfmt uuid4
+	RiskCategoryID *uuid.UUID `json:"risk_category_id"`
+
+	// item category the rate applies to, if not for a risk category
+	//
+	// swagger:strfmt uuid4
+	ItemCategoryID *uuid.UUID `json:"item_category_id"`
+
+	// annual premium as a fraction of the coverage amount, e.g. 0.02
+	Rate float64 `json:"rate"`
+
+	// date the rate takes effect (YYYY-MM-DD)
+	EffectiveDate string `json:"effective_date"`
+
+	// ID of the user that created the rate
+	//
+	// swagger:strfmt uuid4
+	CreatedByID uuid.UUID `json:"created_by_id"`
+
+	// time the rate was created
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// swagger:model
+type PremiumRateCreate struct {
+	// risk category the rate applies to. Exactly one of risk_category_id and item_category_id is required.
+	//
+	// swagger:strfmt uuid4
+	RiskCategoryID *uuid.UUID `json:"risk_category_id"`
+
+	// item category the rate applies to. Exactly one of risk_category_id and item_category_id is required.
+	//
+	// swagger:strfmt uuid4
+	ItemCategoryID *uuid.UUID `json:"item_category_id"`
+
+	// annual premium as a fraction of the coverage amount, e.g. 0.02
+	Rate float64 `json:"rate"`
+
+	// date the rate takes effect (YYYY-MM-DD), must not be in the past
+	EffectiveDate string `json:"effective_date"`
+}
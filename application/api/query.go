@@ -17,6 +17,18 @@ type Query struct {
 
 	// recordLimit sets the number of records returned in a single page. Minimum is 1, maximum is 50
 	recordLimit int
+
+	// cursor identifies the page of records to return. It is taken from the Meta of a previous response.
+	cursor string
+
+	// sortField is the name of the field by which to sort the records
+	sortField string
+
+	// sortDesc is true if the records are sorted in descending order
+	sortDesc bool
+
+	// paged is true if a limit or a cursor was given
+	paged bool
 }
 
 func (q Query) Limit() int {
@@ -27,7 +39,22 @@ func (q Query) Limit() int {
 	if l > 50 {
 		l = 50
 	}
-	return q.recordLimit
+	return l
+}
+
+// IsPaged returns true if a limit or a cursor was given, asking for a page of records rather than all of them
+func (q Query) IsPaged() bool {
+	return q.paged
+}
+
+func (q Query) Cursor() string {
+	return q.cursor
+}
+
+// Sort returns the name of the field by which to sort and whether the order is descending. The field is empty if
+// no sort order was requested.
+func (q Query) Sort() (string, bool) {
+	return q.sortField, q.sortDesc
 }
 
 func (q Query) Filter(key string) string {
@@ -43,6 +70,7 @@ func (q Query) Search() string {
 // Example:
 //   "filter=name:John,description:MacBook" becomes Query{filterKeys:
 //   map[string]string{"name":"John","description":"MacBook"}}
+//   "sort=-created_at" becomes Query{sortField: "created_at", sortDesc: true}
 func NewQuery(values buffalo.ParamValues) Query {
 	q := Query{recordLimit: 10, filterKeys: map[string]string{}}
 
@@ -65,5 +93,13 @@ func NewQuery(values buffalo.ParamValues) Query {
 		}
 	}
 
+	q.cursor = strings.TrimSpace(values.Get("cursor"))
+	q.paged = values.Get("limit") != "" || q.cursor != ""
+
+	if sort := strings.TrimSpace(values.Get("sort")); sort != "" {
+		q.sortDesc = strings.HasPrefix(sort, "-")
+		q.sortField = strings.TrimPrefix(sort, "-")
+	}
+
 	return q
 }
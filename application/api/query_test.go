@@ -12,8 +12,12 @@ func (ts *TestSuite) TestNewQuery() {
 		name             string
 		qs               string
 		wantLimit        int
+		wantSortField    string
+		wantSortDesc     bool
+		wantCursor       string
 		wantFilterActive string
 		wantSearchText   string
+		wantPaged        bool
 	}{
 		{
 			name:             "default",
@@ -26,6 +30,7 @@ func (ts *TestSuite) TestNewQuery() {
 			qs:               "limit=2&filter=active:true",
 			wantLimit:        2,
 			wantFilterActive: "true",
+			wantPaged:        true,
 		},
 		{
 			name:           "search",
@@ -38,6 +43,34 @@ func (ts *TestSuite) TestNewQuery() {
 			qs:               "limit= 2 &filter= active : true ",
 			wantLimit:        2,
 			wantFilterActive: "true",
+			wantPaged:        true,
+		},
+		{
+			name:      "limit too high",
+			qs:        "limit=100",
+			wantLimit: 50,
+			wantPaged: true,
+		},
+		{
+			name:      "limit too low",
+			qs:        "limit=0",
+			wantLimit: 1,
+			wantPaged: true,
+		},
+		{
+			name:          "sort ascending",
+			qs:            "sort=name",
+			wantLimit:     10,
+			wantSortField: "name",
+		},
+		{
+			name:          "sort descending and cursor",
+			qs:            "sort=-created_at&cursor= abc ",
+			wantLimit:     10,
+			wantSortField: "created_at",
+			wantSortDesc:  true,
+			wantCursor:    "abc",
+			wantPaged:     true,
 		},
 	}
 	for _, tt := range tests {
@@ -46,7 +79,12 @@ func (ts *TestSuite) TestNewQuery() {
 
 			got := NewQuery(buffalo.ParamValues(values))
 			ts.Equal(tt.wantLimit, got.Limit(), "limit is incorrect")
+			sortField, sortDesc := got.Sort()
+			ts.Equal(tt.wantSortField, sortField, "sort field is incorrect")
+			ts.Equal(tt.wantSortDesc, sortDesc, "sort order is incorrect")
+			ts.Equal(tt.wantCursor, got.Cursor(), "cursor is incorrect")
 			ts.Equal(tt.wantFilterActive, got.Filter("active"), "filter active is incorrect")
+			ts.Equal(tt.wantPaged, got.IsPaged(), "paged is incorrect")
 		})
 	}
 }
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// swagger:model
+type StewardQueue []StewardQueueEntry
+
+// StewardQueueEntry is an item or claim awaiting action by a steward or signator
+//
+// swagger:model
+type StewardQueueEntry struct {
+	// type of object, either "items" or "claims"
+	Type string `json:"type"`
+
+	// item or claim ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// item name or claim reference number
+	Name string `json:"name"`
+
+	// item coverage status or claim status
+	Status string `json:"status"`
+
+	// policy ID
+	//
+	// swagger:strfmt uuid4
+	PolicyID uuid.UUID `json:"policy_id"`
+
+	// policy type
+	PolicyType PolicyType `json:"policy_type"`
+
+	// entity code of the policy
+	EntityCode string `json:"entity_code"`
+
+	// date-time the item or claim was submitted for review
+	//
+	// swagger:strfmt date-time
+	SubmittedAt time.Time `json:"submitted_at"`
+
+	// number of hours since the item or claim was submitted
+	AgeHours int `json:"age_hours"`
+
+	// date-time by which action should be taken according to the service level agreement
+	//
+	// swagger:strfmt date-time
+	SLADueAt time.Time `json:"sla_due_at"`
+
+	// true if the service level agreement has been breached
+	SLABreached bool `json:"sla_breached"`
+
+	// ID of the admin assigned to the item or claim, or null if not assigned
+	//
+	// swagger:strfmt uuid4
+	AssignedToID *uuid.UUID `json:"assigned_to_id"`
+
+	// date-time of the assignment, or null if not assigned
+	//
+	// swagger:strfmt date-time
+	AssignedAt *time.Time `json:"assigned_at"`
+}
+
+// swagger:model
+type QueueAssignment struct {
+	// unique ID
+	//
+	// swagger:strfmt uuid4
+	ID uuid.UUID `json:"id"`
+
+	// item ID, if the assignment is for an item
+	//
+	// swagger:strfmt uuid4
+	ItemID *uuid.UUID `json:"item_id"`
+
+	// claim ID, if the assignment is for a claim
+	//
+	// swagger:strfmt uuid4
+	ClaimID *uuid.UUID `json:"claim_id"`
+
+	// ID of the assigned admin
+	//
+	// swagger:strfmt uuid4
+	StewardID uuid.UUID `json:"steward_id"`
+
+	// ID of the admin who made the assignment
+	//
+	// swagger:strfmt uuid4
+	AssignedByID uuid.UUID `json:"assigned_by_id"`
+
+	// date-time of the assignment
+	//
+	// swagger:strfmt date-time
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// swagger:model
+type QueueAssignmentInput struct {
+	// ID of the admin to assign, or null to assign the current user
+	//
+	// swagger:strfmt uuid4
+	StewardID *uuid.UUID `json:"steward_id"`
+}
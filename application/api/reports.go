@@ -0,0 +1,91 @@
+package api
+
+// Report periods
+const (
+	ReportPeriodMonth   = "month"
+	ReportPeriodQuarter = "quarter"
+	ReportPeriodYear    = "year"
+)
+
+// Report grouping dimensions
+const (
+	ReportGroupType         = "type"
+	ReportGroupRiskCategory = "risk_category"
+	ReportGroupEntityCode   = "entity_code"
+	ReportGroupPolicyType   = "policy_type"
+)
+
+// LossRatioReport compares the premiums earned to the claims paid, based on the ledger entries submitted in the
+// report date range
+//
+// swagger:model
+type LossRatioReport struct {
+	// first date of the report
+	//
+	// swagger:strfmt date
+	StartDate string `json:"start_date"`
+
+	// last date of the report
+	//
+	// swagger:strfmt date
+	EndDate string `json:"end_date"`
+
+	// length of each period in the report: month, quarter, or year
+	Period string `json:"period"`
+
+	// dimensions by which the rows are grouped, in addition to the period
+	GroupBy []string `json:"group_by"`
+
+	// one row for each period and combination of the group_by dimensions that has ledger entries
+	Rows []LossRatioReportRow `json:"rows"`
+
+	// totals for the whole report. The period and dimension fields are empty.
+	Total LossRatioReportRow `json:"total"`
+}
+
+// LossRatioReportRow holds the totals for one period and combination of dimensions. Dimensions not included in the
+// report's group_by are empty.
+//
+// swagger:model
+type LossRatioReportRow struct {
+	// first date of the period
+	//
+	// swagger:strfmt date
+	PeriodStart string `json:"period_start"`
+
+	// ledger entry type
+	Type string `json:"type"`
+
+	// risk category name
+	RiskCategory string `json:"risk_category"`
+
+	// entity code
+	EntityCode string `json:"entity_code"`
+
+	// policy type
+	PolicyType PolicyType `json:"policy_type"`
+
+	// premiums charged, including coverage changes and policy adjustments, before refunds
+	Premiums Currency `json:"premiums"`
+
+	// premiums refunded when coverage was cancelled, as a positive amount
+	Refunds Currency `json:"refunds"`
+
+	// premiums less refunds
+	NetPremiums Currency `json:"net_premiums"`
+
+	// claims paid, including adjustments, less recoveries, as a positive amount
+	Claims Currency `json:"claims"`
+
+	// claims divided by net premiums, or 0 if there are no net premiums
+	LossRatio float64 `json:"loss_ratio"`
+
+	// number of ledger entries
+	EntryCount int `json:"entry_count"`
+
+	// number of distinct items with premium or refund entries
+	ItemCount int `json:"item_count"`
+
+	// number of distinct claims with claim entries
+	ClaimCount int `json:"claim_count"`
+}
@@ -52,6 +52,9 @@ type User struct {
 
 	// File object that contains the user's photo
 	PhotoFile *File `json:"photo_file,omitempty"`
+
+	// maximum total payout the user may approve, or null if there is no limit (0.01 USD)
+	ApprovalLimit *Currency `json:"approval_limit"`
 }
 
 // app user update input
@@ -71,3 +74,11 @@ type UserFileAttachInput struct {
 	// swagger:strfmt uuid4
 	FileID uuid.UUID `json:"file_id"`
 }
+
+// input for setting a user's approval limit
+//
+// swagger:model
+type UserApprovalLimitInput struct {
+	// maximum total payout the user may approve, or null to remove the limit (0.01 USD)
+	ApprovalLimit *Currency `json:"approval_limit"`
+}
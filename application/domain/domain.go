@@ -1,8 +1,10 @@
 package domain
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"io"
@@ -71,14 +73,18 @@ const (
 
 	EventPayloadID = "id"
 
+	TypeBatch           = "batches"
 	TypeClaim           = "claims"
+	TypeClaimAdjustment = "claim-adjustments"
 	TypeClaimItem       = "claim-items"
 	TypeClaimFile       = "claim-files"
+	TypeDeductibleRule  = "deductible-rules"
 	TypeFile            = "files"
 	TypeItem            = "items"
 	TypePolicy          = "policies"
 	TypePolicyDependent = "policy-dependents"
 	TypePolicyUser      = "policy-users"
+	TypePremiumRate     = "premium-rates"
 	TypeUser            = "users"
 )
 
@@ -97,6 +103,22 @@ const (
 	Megabyte     = 1048576
 )
 
+// Premium minimum scopes
+const (
+	PremiumMinimumScopeItem   = "item"
+	PremiumMinimumScopePolicy = "policy"
+)
+
+// Accounting export providers
+const (
+	FinanceProviderSage       = "sage"
+	FinanceProviderQuickBooks = "quickbooks"
+	FinanceProviderJournal    = "journal"
+)
+
+// FinanceProviders lists the valid values of FINANCE_PROVIDER
+var FinanceProviders = []string{FinanceProviderSage, FinanceProviderQuickBooks, FinanceProviderJournal}
+
 // Event Kinds
 const (
 	EventApiUserCreated      = "api:user:created"
@@ -106,14 +128,18 @@ const (
 	EventApiItemApproved     = "api:item:approved"
 	EventApiItemDenied       = "api:item:denied"
 
-	EventApiClaimReview1     = "api:claim:review1"
-	EventApiClaimRevision    = "api:claim:revision"
-	EventApiClaimPreapproved = "api:claim:preapproved"
-	EventApiClaimReceipt     = "api:claim:receipt"
-	EventApiClaimReview2     = "api:claim:review2"
-	EventApiClaimReview3     = "api:claim:review3"
-	EventApiClaimApproved    = "api:claim:approved"
-	EventApiClaimDenied      = "api:claim:denied"
+	EventApiClaimReview1       = "api:claim:review1"
+	EventApiClaimRevision      = "api:claim:revision"
+	EventApiClaimPreapproved   = "api:claim:preapproved"
+	EventApiClaimReceipt       = "api:claim:receipt"
+	EventApiClaimReview2       = "api:claim:review2"
+	EventApiClaimReview3       = "api:claim:review3"
+	EventApiClaimApproved      = "api:claim:approved"
+	EventApiClaimDenied        = "api:claim:denied"
+	EventApiClaimAppealed      = "api:claim:appealed"
+	EventApiClaimWithdrawn     = "api:claim:withdrawn"
+	EventApiClaimEscalated     = "api:claim:escalated"
+	EventApiClaimApprovalAdded = "api:claim:approval-added"
 
 	EventApiNotificationCreated = "api:notification:created"
 
@@ -175,11 +201,26 @@ var Env struct {
 	InviteLifetimeDays int `default:"14" split_words:"true"`
 	MaxFileDelete      int `default:"10" split_words:"true"`
 
+	// ClaimAppealDays is the number of days after a claim is denied during which it may be appealed
+	ClaimAppealDays int `default:"30" split_words:"true"`
+
+	// ClaimWorkflowFile is the path of a JSON file defining the claim review workflow. If empty, the built-in
+	// workflow is used.
+	ClaimWorkflowFile string `default:"" split_words:"true"`
+
+	// QueueItemSLADays and QueueClaimSLADays are the number of days after submission within which a steward should
+	// act on an item or claim in the steward queue
+	QueueItemSLADays  int `default:"3" split_words:"true"`
+	QueueClaimSLADays int `default:"5" split_words:"true"`
+
 	// The following will be multiplied by CurrencyFactor in readEnv()
 	PolicyMaxCoverage       int `default:"50000" split_words:"true"`
 	DependentAutoApproveMax int `default:"4000" split_words:"true"`
 	PremiumMinimum          int `default:"25" split_words:"true"`
 
+	// PremiumMinimumScope determines whether the PremiumMinimum applies to each "item" or to each "policy"
+	PremiumMinimumScope string `default:"policy" split_words:"true"`
+
 	// PremiumFactor is multiplied by CoverageAmount to calculate the annual premium of an item
 	PremiumFactor         float64 `default:"0.02" split_words:"true"`
 	RepairThreshold       float64 `default:"0.7" split_words:"true"`
@@ -191,6 +232,7 @@ var Env struct {
 	FiscalStartMonth   int    `default:"1" split_words:"true"`
 	ExpenseAccount     string `required:"true" split_words:"true"`
 	ClaimIncomeAccount string `required:"true" split_words:"true"`
+	FinanceProvider    string `default:"sage" split_words:"true"`
 
 	// For CertMagic to provide TLS in container
 	CertDomainName  string `default:"" split_words:"true"`
@@ -228,6 +270,12 @@ func readEnv() {
 	Env.PolicyMaxCoverage *= CurrencyFactor
 	Env.DependentAutoApproveMax *= CurrencyFactor
 	Env.PremiumMinimum *= CurrencyFactor
+	if Env.PremiumMinimumScope != PremiumMinimumScopeItem && Env.PremiumMinimumScope != PremiumMinimumScopePolicy {
+		log.Fatal(errors.New("invalid PREMIUM_MINIMUM_SCOPE: " + Env.PremiumMinimumScope))
+	}
+	if !IsStringInSlice(Env.FinanceProvider, FinanceProviders) {
+		log.Fatal(errors.New("invalid FINANCE_PROVIDER: " + Env.FinanceProvider))
+	}
 	Env.RepairThresholdString = fmt.Sprintf("%.2g%%", Env.RepairThreshold*100)
 	Env.DeductibleString = fmt.Sprintf("%.2g%%", Env.Deductible*100)
 
@@ -503,3 +551,13 @@ func TimeBetween(t1, t2 time.Time) string {
 
 	return fmt.Sprintf("%d %s ago", n, unit)
 }
+
+// CSV returns the records, each a slice of fields, in CSV format
+func CSV(records [][]string) []byte {
+	var buf bytes.Buffer
+
+	// bytes.Buffer does not return write errors, so the csv.Writer error can be ignored
+	_ = csv.NewWriter(&buf).WriteAll(records)
+
+	return buf.Bytes()
+}
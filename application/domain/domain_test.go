@@ -332,3 +332,13 @@ func (ts *TestSuite) TestTimeBetween() {
 		})
 	}
 }
+
+func (ts *TestSuite) TestCSV() {
+	got := CSV([][]string{
+		{"Name", "Description"},
+		{"one", "with, a comma"},
+		{"two", `with "quotes"`},
+	})
+
+	ts.Equal("Name,Description\none,\"with, a comma\"\ntwo,\"with \"\"quotes\"\"\"\n", string(got))
+}
@@ -1,13 +1,24 @@
 package fin
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/silinternational/cover-api/domain"
 )
 
-const ProviderTypeSage = "sage"
+const (
+	ProviderTypeSage       = domain.FinanceProviderSage
+	ProviderTypeQuickBooks = domain.FinanceProviderQuickBooks
+	ProviderTypeJournal    = domain.FinanceProviderJournal
+)
+
+// ProviderTypes lists the valid accounting export provider types
+var ProviderTypes = domain.FinanceProviders
 
 type Transaction struct {
 	Account     string
@@ -20,6 +31,68 @@ type Transaction struct {
 type Provider interface {
 	AppendToBatch(Transaction)
 	BatchToCSV() []byte
+	ContentType() string
+	FileExtension() string
+
+	// ParseAmounts reads back the transaction amounts from batch file data written by BatchToCSV, in the order the
+	// transactions were written and with the sign convention of Transaction.Amount
+	ParseAmounts(data []byte) ([]int, error)
+}
+
+// NetAmount returns the sum of the transaction amounts. A balanced journal has a net amount of zero.
+func NetAmount(transactions []Transaction) int {
+	var net int
+	for _, t := range transactions {
+		net += t.Amount
+	}
+	return net
+}
+
+// formatAmount formats an amount in cents as a decimal number of dollars, such as "-1234.56". It uses integer math
+// so that large amounts are not rounded.
+func formatAmount(cents int) string {
+	sign := ""
+	if cents < 0 {
+		sign = "-"
+		cents = -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/domain.CurrencyFactor, cents%domain.CurrencyFactor)
+}
+
+// parseAmount is the inverse of formatAmount. It returns the amount in cents of a decimal number of dollars.
+func parseAmount(s string) (int, error) {
+	str := s
+	sign := 1
+	if strings.HasPrefix(str, "-") {
+		sign = -1
+		str = str[1:]
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) != 2 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	dollars, err := strconv.Atoi(parts[0])
+	if err != nil || dollars < 0 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	cents, err := strconv.Atoi(parts[1])
+	if err != nil || cents < 0 {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+
+	return sign * (dollars*domain.CurrencyFactor + cents), nil
+}
+
+// Checksum returns the hex-encoded SHA-256 hash of the batch file data, so that two exports can be compared
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// IsValidProviderType returns true if NewBatch supports the given provider type
+func IsValidProviderType(providerType string) bool {
+	return domain.IsStringInSlice(providerType, ProviderTypes)
 }
 
 func NewBatch(providerType string, date time.Time) Provider {
@@ -33,6 +106,19 @@ func NewBatch(providerType string, date time.Time) Provider {
 			JournalDescription: batchDesc,
 			Transactions:       nil,
 		}
+	case ProviderTypeQuickBooks:
+		return &QuickBooks{
+			Date:               date,
+			JournalDescription: batchDesc,
+			Transactions:       nil,
+		}
+	case ProviderTypeJournal:
+		return &Journal{
+			Period:             getFiscalPeriod(int(date.Month())),
+			Year:               date.Year(),
+			JournalDescription: batchDesc,
+			Transactions:       nil,
+		}
 	}
 	panic("fin: invalid provider type")
 }
@@ -40,3 +126,17 @@ func NewBatch(providerType string, date time.Time) Provider {
 func getFiscalPeriod(month int) int {
 	return (month-domain.Env.FiscalStartMonth+12)%12 + 1
 }
+
+// FiscalPeriodStart returns the first day of the month that is exported by NewBatch with the given year and fiscal
+// period. This is the inverse of the period calculation used in the batch header.
+func FiscalPeriodStart(year, period int) (time.Time, error) {
+	if period < 1 || period > 12 {
+		return time.Time{}, fmt.Errorf("fiscal period must be between 1 and 12, got %d", period)
+	}
+	if year < 1 {
+		return time.Time{}, fmt.Errorf("invalid fiscal year %d", year)
+	}
+
+	month := (period-1+domain.Env.FiscalStartMonth-1)%12 + 1
+	return time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC), nil
+}
@@ -2,6 +2,7 @@ package fin
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -33,3 +34,152 @@ func Test_getFiscalPeriod(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBatch(t *testing.T) {
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		providerType    string
+		wantContentType string
+		wantExtension   string
+	}{
+		{
+			name:            "Sage",
+			providerType:    ProviderTypeSage,
+			wantContentType: "text/csv",
+			wantExtension:   "csv",
+		},
+		{
+			name:            "QuickBooks",
+			providerType:    ProviderTypeQuickBooks,
+			wantContentType: "text/plain",
+			wantExtension:   "iif",
+		},
+		{
+			name:            "Journal",
+			providerType:    ProviderTypeJournal,
+			wantContentType: "text/csv",
+			wantExtension:   "csv",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, IsValidProviderType(tt.providerType))
+
+			got := NewBatch(tt.providerType, date)
+			assert.Equal(t, tt.wantContentType, got.ContentType())
+			assert.Equal(t, tt.wantExtension, got.FileExtension())
+		})
+	}
+
+	assert.False(t, IsValidProviderType("bogus"))
+	assert.Panics(t, func() { NewBatch("bogus", date) })
+}
+
+func TestFiscalPeriodStart(t *testing.T) {
+	domain.Env.FiscalStartMonth = 9
+
+	tests := []struct {
+		name    string
+		year    int
+		period  int
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:   "period 1",
+			year:   2021,
+			period: 1,
+			want:   time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:   "period 12",
+			year:   2021,
+			period: 12,
+			want:   time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "period 0",
+			year:    2021,
+			period:  0,
+			wantErr: true,
+		},
+		{
+			name:    "period 13",
+			year:    2021,
+			period:  13,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FiscalPeriodStart(tt.year, tt.period)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.period, getFiscalPeriod(int(got.Month())))
+		})
+	}
+}
+
+func TestNetAmount(t *testing.T) {
+	transactions := []Transaction{{Amount: 1000}, {Amount: -250}, {Amount: -750}}
+	assert.Equal(t, 0, NetAmount(transactions))
+
+	transactions = append(transactions, Transaction{Amount: 1})
+	assert.Equal(t, 1, NetAmount(transactions))
+}
+
+func Test_formatAmount(t *testing.T) {
+	assert.Equal(t, "0.00", formatAmount(0))
+	assert.Equal(t, "1234.56", formatAmount(123456))
+	assert.Equal(t, "-0.05", formatAmount(-5))
+	assert.Equal(t, "167772.17", formatAmount(16777217))
+	assert.Equal(t, "-92233720368547758.07", formatAmount(-9223372036854775807))
+}
+
+func Test_parseAmount(t *testing.T) {
+	for _, cents := range []int{0, 5, -5, 123456, 16777217, -9223372036854775807} {
+		got, err := parseAmount(formatAmount(cents))
+		assert.NoError(t, err)
+		assert.Equal(t, cents, got)
+	}
+
+	for _, s := range []string{"", "12", "1.2", "1.234", "--1.00", "1.-5", "x.00"} {
+		_, err := parseAmount(s)
+		assert.Error(t, err, "parseAmount(%q)", s)
+	}
+}
+
+func TestProvider_ParseAmounts(t *testing.T) {
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	amounts := []int{-1050, 16777217, 0, -16776167}
+
+	for _, providerType := range ProviderTypes {
+		t.Run(providerType, func(t *testing.T) {
+			batch := NewBatch(providerType, date)
+			for _, amount := range amounts {
+				batch.AppendToBatch(Transaction{
+					Account:     "abc123",
+					Amount:      amount,
+					Description: "description, with a comma",
+					Date:        date,
+				})
+			}
+
+			got, err := batch.ParseAmounts(batch.BatchToCSV())
+			assert.NoError(t, err)
+			assert.Equal(t, amounts, got)
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	assert.Equal(t, "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", Checksum(nil))
+	assert.Equal(t, Checksum([]byte("batch")), Checksum([]byte("batch")))
+	assert.NotEqual(t, Checksum([]byte("batch")), Checksum([]byte("batch2")))
+}
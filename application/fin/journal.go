@@ -0,0 +1,105 @@
+package fin
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+
+	"github.com/silinternational/cover-api/domain"
+)
+
+var journalHeader = []string{
+	"Year", "Period", "Date", "Account", "Debit", "Credit", "Description", "Reference", "Journal",
+}
+
+// Journal is a Provider that exports a batch as a generic double-entry journal in CSV format, with one row per
+// transaction and separate debit and credit columns.
+type Journal struct {
+	Period             int
+	Year               int
+	JournalDescription string
+	Transactions       []Transaction
+}
+
+func (j *Journal) AppendToBatch(t Transaction) {
+	j.Transactions = append(j.Transactions, t)
+}
+
+func (j *Journal) BatchToCSV() []byte {
+	records := [][]string{journalHeader}
+	for i := range j.Transactions {
+		records = append(records, j.transactionRow(i))
+	}
+
+	return domain.CSV(records)
+}
+
+func (j *Journal) ContentType() string {
+	return "text/csv"
+}
+
+func (j *Journal) FileExtension() string {
+	return "csv"
+}
+
+// ParseAmounts returns the amounts of the journal rows, reading a debit as a negative amount
+func (j *Journal) ParseAmounts(data []byte) ([]int, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal batch file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("journal batch file has no header")
+	}
+
+	amounts := make([]int, 0, len(records)-1)
+	for i, record := range records[1:] {
+		debit, credit := record[4], record[5]
+		if (debit == "") == (credit == "") {
+			return nil, fmt.Errorf("journal row %d must have either a debit or a credit", i+2)
+		}
+		if debit != "" {
+			amount, err := parseAmount(debit)
+			if err != nil {
+				return nil, fmt.Errorf("journal row %d: %w", i+2, err)
+			}
+			amounts = append(amounts, -amount)
+			continue
+		}
+		amount, err := parseAmount(credit)
+		if err != nil {
+			return nil, fmt.Errorf("journal row %d: %w", i+2, err)
+		}
+		amounts = append(amounts, amount)
+	}
+	return amounts, nil
+}
+
+func (j *Journal) transactionRow(rowNumber int) []string {
+	t := j.Transactions[rowNumber]
+
+	debit, credit := journalAmounts(t)
+
+	return []string{
+		fmt.Sprintf("%d", j.Year),
+		fmt.Sprintf("%02d", j.Period),
+		t.Date.Format("2006-01-02"),
+		t.Account,
+		debit,
+		credit,
+		t.Description,
+		t.Reference,
+		j.JournalDescription,
+	}
+}
+
+// journalAmounts returns the debit and credit columns for the Transaction. A negative Transaction amount is a debit,
+// which matches the sign convention used in the Sage export.
+func journalAmounts(t Transaction) (debit, credit string) {
+	if t.Amount < 0 {
+		debit = formatAmount(-t.Amount)
+	} else {
+		credit = formatAmount(t.Amount)
+	}
+	return debit, credit
+}
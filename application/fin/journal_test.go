@@ -0,0 +1,41 @@
+package fin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJournal_BatchToCSV(t *testing.T) {
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	j := &Journal{
+		Period:             3,
+		Year:               2021,
+		JournalDescription: "journal description",
+		Transactions: []Transaction{
+			{
+				Account:     "xyz123",
+				Amount:      -1050,
+				Description: "transaction, description",
+				Reference:   "abc123",
+				Date:        date,
+			},
+			{
+				Account:     "abc987",
+				Amount:      1050,
+				Description: "balance description",
+				Date:        date,
+			},
+		},
+	}
+
+	want := "Year,Period,Date,Account,Debit,Credit,Description,Reference,Journal\n" +
+		`2021,03,2021-03-01,xyz123,10.50,,"transaction, description",abc123,journal description` + "\n" +
+		"2021,03,2021-03-01,abc987,,10.50,balance description,,journal description\n"
+
+	got := j.BatchToCSV()
+
+	require.Equal(t, want, string(got))
+}
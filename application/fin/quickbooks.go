@@ -0,0 +1,109 @@
+package fin
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	iifHeader = "!TRNS\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\n" +
+		"!SPL\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tDOCNUM\tMEMO\n" +
+		"!ENDTRNS\n"
+
+	iifRowTemplate = "%s\tGENERAL JOURNAL\t%s\t%s\t%s\t%s\t%s\n"
+	iifEndRow      = "ENDTRNS\n"
+)
+
+// QuickBooks is a Provider that exports a batch as a single general journal entry in the QuickBooks IIF format.
+// The first transaction is the TRNS line and all others are SPL lines, so the batch as a whole must balance.
+type QuickBooks struct {
+	Date               time.Time
+	JournalDescription string
+	Transactions       []Transaction
+}
+
+func (q *QuickBooks) AppendToBatch(t Transaction) {
+	q.Transactions = append(q.Transactions, t)
+}
+
+func (q *QuickBooks) BatchToCSV() []byte {
+	var buf bytes.Buffer
+	buf.Write([]byte(iifHeader))
+	if len(q.Transactions) == 0 {
+		return buf.Bytes()
+	}
+
+	for i := range q.Transactions {
+		buf.Write(q.transactionRow(i))
+	}
+	buf.Write([]byte(iifEndRow))
+
+	return buf.Bytes()
+}
+
+func (q *QuickBooks) ContentType() string {
+	return "text/plain"
+}
+
+func (q *QuickBooks) FileExtension() string {
+	return "iif"
+}
+
+// ParseAmounts returns the amounts of the TRNS and SPL lines in the IIF data
+func (q *QuickBooks) ParseAmounts(data []byte) ([]int, error) {
+	lines := strings.Split(string(data), "\n")
+
+	amounts := make([]int, 0, len(lines))
+	for i, line := range lines {
+		fields := strings.Split(line, "\t")
+		if fields[0] != "TRNS" && fields[0] != "SPL" {
+			continue
+		}
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("IIF line %d has no transaction amount", i+1)
+		}
+		amount, err := parseAmount(fields[4])
+		if err != nil {
+			return nil, fmt.Errorf("IIF line %d: %w", i+1, err)
+		}
+		amounts = append(amounts, -amount)
+	}
+	return amounts, nil
+}
+
+func (q *QuickBooks) transactionRow(rowNumber int) []byte {
+	t := q.Transactions[rowNumber]
+
+	rowType := "SPL"
+	if rowNumber == 0 {
+		rowType = "TRNS"
+	}
+
+	memo := t.Description
+	if rowNumber == 0 {
+		memo = q.JournalDescription
+	}
+
+	str := fmt.Sprintf(
+		iifRowTemplate,
+		rowType,
+		q.Date.Format("01/02/2006"),
+		iifField(t.Account),
+		iifAmount(t),
+		iifField(t.Reference),
+		iifField(memo),
+	)
+	return []byte(str)
+}
+
+// iifAmount formats the Transaction amount for the IIF file, where a credit is negative
+func iifAmount(t Transaction) string {
+	return formatAmount(-t.Amount)
+}
+
+// iifField removes characters that would break the tab-delimited IIF row structure
+func iifField(s string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(s)
+}
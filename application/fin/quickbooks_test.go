@@ -0,0 +1,61 @@
+package fin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickBooks_BatchToCSV(t *testing.T) {
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	transactions := []Transaction{
+		{
+			Account:     "xyz123",
+			Amount:      -1050,
+			Description: "transaction description",
+			Reference:   "abc123",
+			Date:        date,
+		},
+		{
+			Account:     "abc987",
+			Amount:      1050,
+			Description: "balance\tdescription",
+			Date:        date,
+		},
+	}
+
+	tests := []struct {
+		name         string
+		transactions []Transaction
+		want         string
+	}{
+		{
+			name:         "no transactions",
+			transactions: nil,
+			want:         iifHeader,
+		},
+		{
+			name:         "two transactions",
+			transactions: transactions,
+			want: iifHeader +
+				"TRNS\tGENERAL JOURNAL\t03/01/2021\txyz123\t10.50\tabc123\tjournal description\n" +
+				"SPL\tGENERAL JOURNAL\t03/01/2021\tabc987\t-10.50\t\tbalance description\n" +
+				iifEndRow,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &QuickBooks{
+				Date:               date,
+				JournalDescription: "journal description",
+				Transactions:       tt.transactions,
+			}
+
+			got := q.BatchToCSV()
+
+			require.Equal(t, tt.want, string(got))
+		})
+	}
+}
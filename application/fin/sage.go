@@ -2,9 +2,8 @@ package fin
 
 import (
 	"bytes"
+	"encoding/csv"
 	"fmt"
-
-	"github.com/silinternational/cover-api/api"
 )
 
 const (
@@ -14,6 +13,10 @@ const (
 		`"SCURNDEC","TRANSDESC","TRANSREF","TRANSDATE","SRCELDGR","SRCETYPE",` + "\n"
 )
 
+// SageDescriptionLimit is the maximum length of a transaction description in a Sage import. Longer descriptions
+// are truncated.
+const SageDescriptionLimit = 60
+
 const (
 	transactionRowTemplate = `"2","000000","00001","%010d","",0,"%s","",%s,"2","%s","%s",%s,"GL","JE"` + "\n"
 	summaryRowTemplate     = `"1","000000","00001","","GL","JE","%d","%02d",0,"%s","00",0,0,0,2` + "\n"
@@ -42,6 +45,42 @@ func (s *Sage) BatchToCSV() []byte {
 	return buf.Bytes()
 }
 
+func (s *Sage) ContentType() string {
+	return "text/csv"
+}
+
+func (s *Sage) FileExtension() string {
+	return "csv"
+}
+
+// ParseAmounts returns the amounts of the transaction rows ("2" records) in the batch file data
+func (s *Sage) ParseAmounts(data []byte) ([]int, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Sage batch file: %w", err)
+	}
+
+	amounts := make([]int, 0, len(records))
+	for i, record := range records {
+		if record[0] != "2" {
+			continue
+		}
+		if len(record) < 9 {
+			return nil, fmt.Errorf("row %d of the Sage batch file has no transaction amount", i+1)
+		}
+		amount, err := parseAmount(record[8])
+		if err != nil {
+			return nil, fmt.Errorf("row %d of the Sage batch file: %w", i+1, err)
+		}
+		amounts = append(amounts, -amount)
+	}
+	return amounts, nil
+}
+
 func (s *Sage) summaryRow() []byte {
 	str := fmt.Sprintf(summaryRowTemplate, s.Year, s.Period, s.JournalDescription)
 	return []byte(str)
@@ -53,10 +92,23 @@ func (s *Sage) transactionRow(rowNumber int) []byte {
 		transactionRowTemplate,
 		20*(rowNumber+1),
 		t.Account,
-		api.Currency(-t.Amount).String(),
-		t.Description,
+		sageAmount(t),
+		truncate(t.Description, SageDescriptionLimit),
 		t.Reference,
 		t.Date.Format("20060102"),
 	)
 	return []byte(str)
 }
+
+// sageAmount formats the Transaction amount for the batch file, where a credit is negative
+func sageAmount(t Transaction) string {
+	return formatAmount(-t.Amount)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
@@ -2,6 +2,7 @@ package fin
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -43,3 +44,16 @@ func TestSage_BatchToCSV(t *testing.T) {
 
 	require.Equal(t, want, string(got))
 }
+
+func TestSage_BatchToCSV_truncate(t *testing.T) {
+	description := strings.Repeat("x", SageDescriptionLimit+10)
+
+	s := &Sage{
+		Transactions: []Transaction{{Account: "xyz123", Description: description, Date: time.Now()}},
+	}
+
+	got := string(s.BatchToCSV())
+
+	require.Contains(t, got, `"`+description[:SageDescriptionLimit]+`"`)
+	require.NotContains(t, got, description[:SageDescriptionLimit+1])
+}
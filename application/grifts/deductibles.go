@@ -0,0 +1,24 @@
+package grifts
+
+import (
+	"fmt"
+
+	"github.com/gobuffalo/pop/v5"
+	"github.com/markbates/grift/grift"
+
+	"github.com/silinternational/cover-api/models"
+)
+
+var _ = grift.Namespace("db", func() {
+	_ = grift.Desc("backfill_deductibles", "Record the deductible of claim items paid before it was recorded")
+	_ = grift.Add("backfill_deductibles", func(c *grift.Context) error {
+		return models.DB.Transaction(func(tx *pop.Connection) error {
+			n, err := models.BackfillClaimItemDeductibles(tx)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("updated the deductible of %d claim items\n", n)
+			return nil
+		})
+	})
+})
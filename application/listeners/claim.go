@@ -130,3 +130,67 @@ func claimDenied(e events.Event) {
 		return nil
 	})
 }
+
+func claimAppealed(e events.Event) {
+	var claim models.Claim
+	if err := findObject(e.Payload, &claim, e.Kind); err != nil {
+		return
+	}
+
+	if claim.Status != api.ClaimStatusReview1 {
+		panic(fmt.Sprintf(wrongStatusMsg, "claimAppealed", claim.Status))
+	}
+
+	models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.ClaimAppealedQueueMessage(tx, claim)
+		return nil
+	})
+}
+
+func claimWithdrawn(e events.Event) {
+	var claim models.Claim
+	if err := findObject(e.Payload, &claim, e.Kind); err != nil {
+		return
+	}
+
+	if claim.Status != api.ClaimStatusWithdrawn {
+		panic(fmt.Sprintf(wrongStatusMsg, "claimWithdrawn", claim.Status))
+	}
+
+	models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.ClaimWithdrawnQueueMessage(tx, claim)
+		return nil
+	})
+}
+
+func claimApprovalAdded(e events.Event) {
+	var claim models.Claim
+	if err := findObject(e.Payload, &claim, e.Kind); err != nil {
+		return
+	}
+
+	if claim.Status != api.ClaimStatusReview3 {
+		panic(fmt.Sprintf(wrongStatusMsg, "claimApprovalAdded", claim.Status))
+	}
+
+	models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.ClaimApprovalAddedQueueMessage(tx, claim)
+		return nil
+	})
+}
+
+func claimEscalated(e events.Event) {
+	var claim models.Claim
+	if err := findObject(e.Payload, &claim, e.Kind); err != nil {
+		return
+	}
+
+	if claim.Status != api.ClaimStatusReview3 {
+		panic(fmt.Sprintf(wrongStatusMsg, "claimEscalated", claim.Status))
+	}
+
+	models.DB.Transaction(func(tx *pop.Connection) error {
+		messages.ClaimEscalatedQueueMessage(tx, claim)
+		return nil
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/gobuffalo/events"
+	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/v5"
 
 	"github.com/silinternational/cover-api/api"
@@ -305,3 +306,147 @@ func (ts *TestSuite) Test_claimDenied() {
 		})
 	}
 }
+
+func (ts *TestSuite) Test_claimAppealed() {
+	t := ts.T()
+	db := ts.DB
+
+	f := getClaimFixtures(db)
+
+	appealedClaim := models.UpdateClaimStatus(db, f.Claims[0], api.ClaimStatusReview1, "")
+
+	testEmailer := notifications.DummyEmailService{}
+
+	tests := []struct {
+		name  string
+		event events.Event
+	}{
+		{
+			name: "claim appealed",
+			event: events.Event{
+				Kind:    domain.EventApiClaimAppealed,
+				Payload: newTestPayload(appealedClaim.ID, &testEmailer),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testEmailer.DeleteSentMessages()
+			claimAppealed(tt.event)
+
+			var nus models.NotificationUsers
+			ts.NoError(db.All(&nus), "error fetching NotificationUsers from db")
+			ts.Equal(3, len(nus), "incorrect number of NotificationUsers queued")
+		})
+	}
+}
+
+func (ts *TestSuite) Test_claimWithdrawn() {
+	t := ts.T()
+	db := ts.DB
+
+	f := getClaimFixtures(db)
+
+	withdrawnClaim := models.UpdateClaimStatus(db, f.Claims[0], api.ClaimStatusWithdrawn, "")
+
+	testEmailer := notifications.DummyEmailService{}
+
+	tests := []struct {
+		name  string
+		event events.Event
+	}{
+		{
+			name: "claim withdrawn",
+			event: events.Event{
+				Kind:    domain.EventApiClaimWithdrawn,
+				Payload: newTestPayload(withdrawnClaim.ID, &testEmailer),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testEmailer.DeleteSentMessages()
+			claimWithdrawn(tt.event)
+
+			var nus models.NotificationUsers
+			ts.NoError(db.All(&nus), "error fetching NotificationUsers from db")
+			ts.Equal(1, len(nus), "incorrect number of NotificationUsers queued")
+		})
+	}
+}
+
+func (ts *TestSuite) Test_claimApprovalAdded() {
+	t := ts.T()
+	db := ts.DB
+
+	f := getClaimFixtures(db)
+	admins := models.CreateAdminUsers(db)
+
+	claim := f.Claims[0]
+	claim.ReviewerID = nulls.NewUUID(admins[models.AppRoleSteward].ID)
+	claim = models.UpdateClaimStatus(db, claim, api.ClaimStatusReview3, "")
+
+	testEmailer := notifications.DummyEmailService{}
+
+	tests := []struct {
+		name  string
+		event events.Event
+	}{
+		{
+			name: "claim approval added",
+			event: events.Event{
+				Kind:    domain.EventApiClaimApprovalAdded,
+				Payload: newTestPayload(claim.ID, &testEmailer),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testEmailer.DeleteSentMessages()
+			claimApprovalAdded(tt.event)
+
+			var nus models.NotificationUsers
+			ts.NoError(db.All(&nus), "error fetching NotificationUsers from db")
+			ts.Equal(1, len(nus), "incorrect number of NotificationUsers queued")
+		})
+	}
+}
+
+func (ts *TestSuite) Test_claimEscalated() {
+	t := ts.T()
+	db := ts.DB
+
+	f := getClaimFixtures(db)
+	models.CreateAdminUsers(db)
+
+	escalatedClaim := models.UpdateClaimStatus(db, f.Claims[0], api.ClaimStatusReview3, "")
+
+	testEmailer := notifications.DummyEmailService{}
+
+	tests := []struct {
+		name  string
+		event events.Event
+	}{
+		{
+			name: "claim escalated",
+			event: events.Event{
+				Kind:    domain.EventApiClaimEscalated,
+				Payload: newTestPayload(escalatedClaim.ID, &testEmailer),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testEmailer.DeleteSentMessages()
+			claimEscalated(tt.event)
+
+			var nus models.NotificationUsers
+			ts.NoError(db.All(&nus), "error fetching NotificationUsers from db")
+			ts.Equal(1, len(nus), "incorrect number of NotificationUsers queued")
+		})
+	}
+}
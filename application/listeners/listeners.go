@@ -35,6 +35,10 @@ var eventTypes = map[string]func(event events.Event){
 	domain.EventApiClaimReview3:            claimReview3,
 	domain.EventApiClaimApproved:           claimApproved,
 	domain.EventApiClaimDenied:             claimDenied,
+	domain.EventApiClaimAppealed:           claimAppealed,
+	domain.EventApiClaimWithdrawn:          claimWithdrawn,
+	domain.EventApiClaimEscalated:          claimEscalated,
+	domain.EventApiClaimApprovalAdded:      claimApprovalAdded,
 	domain.EventApiNotificationCreated:     notificationCreated,
 	domain.EventApiPolicyUserInviteCreated: policyUserInviteCreated,
 }
@@ -211,6 +211,38 @@ func ClaimReview3QueueMessage(tx *pop.Connection, claim models.Claim) {
 	notn.CreateNotificationUsersForSignators(tx)
 }
 
+// ClaimApprovalAddedQueueMessage queues messages to the users who may give
+//  the final approvals that a claim still needs after another user approved it
+func ClaimApprovalAddedQueueMessage(tx *pop.Connection, claim models.Claim) {
+	claim.LoadPolicyMembers(tx, false)
+	memberName := claim.Policy.Members[0].Name()
+
+	data := newEmailMessageData()
+	data.addClaimData(tx, claim)
+	data["memberName"] = memberName
+
+	item := data["item"].(models.Item)
+
+	claim.LoadReviewer(tx, false)
+	data["firstReviewer"] = claim.Reviewer.Name()
+
+	notn := models.Notification{
+		ClaimID:       nulls.NewUUID(claim.ID),
+		Body:          data.renderHTML(MessageTemplateClaimReview3Signator),
+		Subject:       "Another approval needed for claim on " + item.Name,
+		InappText:     "A claim needs another final approval",
+		Event:         "Claim Approval Added Notification",
+		EventCategory: EventCategoryClaim,
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Claim Approval Added Notification: " + err.Error())
+	}
+
+	for _, a := range claim.FindPendingApprovers(tx) {
+		notn.CreateNotificationUserForUser(tx, a)
+	}
+}
+
 // ClaimApprovedQueueMessage queues messages to a claim's members to
 //  notify them that it has been approved
 func ClaimApprovedQueueMessage(tx *pop.Connection, claim models.Claim) {
@@ -262,3 +294,105 @@ func ClaimDeniedQueueMessage(tx *pop.Connection, claim models.Claim) {
 		notn.CreateNotificationUserForUser(tx, m)
 	}
 }
+
+// ClaimAppealedQueueMessage queues messages to the stewards to notify them that a denied claim
+//  has been appealed and to the claim's members to confirm that the appeal was received
+func ClaimAppealedQueueMessage(tx *pop.Connection, claim models.Claim) {
+	claim.LoadPolicyMembers(tx, false)
+	memberName := claim.Policy.Members[0].Name()
+
+	data := newEmailMessageData()
+	data.addClaimData(tx, claim)
+	data["memberName"] = memberName
+
+	item := data["item"].(models.Item)
+
+	stewardNotn := models.Notification{
+		ClaimID:       nulls.NewUUID(claim.ID),
+		Body:          data.renderHTML(MessageTemplateClaimAppealedSteward),
+		Subject:       "Appeal of denied claim on " + item.Name,
+		InappText:     "A denied claim has been appealed and is waiting for review",
+		Event:         "Claim Appealed Steward Notification",
+		EventCategory: EventCategoryClaim,
+	}
+	if err := stewardNotn.Create(tx); err != nil {
+		panic("error creating new Claim Appealed Steward Notification: " + err.Error())
+	}
+
+	stewardNotn.CreateNotificationUsersForStewards(tx)
+
+	memberNotn := models.Notification{
+		ClaimID:       nulls.NewUUID(claim.ID),
+		Body:          data.renderHTML(MessageTemplateClaimAppealedMember),
+		Subject:       "We Received Your Appeal",
+		InappText:     "your appeal has been received",
+		Event:         "Claim Appealed Member Notification",
+		EventCategory: EventCategoryClaim,
+	}
+	if err := memberNotn.Create(tx); err != nil {
+		panic("error creating new Claim Appealed Member Notification: " + err.Error())
+	}
+
+	for _, m := range claim.Policy.Members {
+		memberNotn.CreateNotificationUserForUser(tx, m)
+	}
+}
+
+// ClaimWithdrawnQueueMessage queues messages to the stewards to
+//  notify them that a claim has been withdrawn by the member
+func ClaimWithdrawnQueueMessage(tx *pop.Connection, claim models.Claim) {
+	claim.LoadPolicyMembers(tx, false)
+	memberName := claim.Policy.Members[0].Name()
+
+	data := newEmailMessageData()
+	data.addClaimData(tx, claim)
+	data["memberName"] = memberName
+
+	item := data["item"].(models.Item)
+
+	notn := models.Notification{
+		ClaimID:       nulls.NewUUID(claim.ID),
+		Body:          data.renderHTML(MessageTemplateClaimWithdrawnSteward),
+		Subject:       "Claim withdrawn on " + item.Name,
+		InappText:     "A claim has been withdrawn by the member",
+		Event:         "Claim Withdrawn Notification",
+		EventCategory: EventCategoryClaim,
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Claim Withdrawn Notification: " + err.Error())
+	}
+
+	notn.CreateNotificationUsersForStewards(tx)
+}
+
+// ClaimEscalatedQueueMessage queues messages to the signators whose approval limit
+//  allows them to approve a claim that was escalated because its payout exceeded
+//  another signator's limit
+func ClaimEscalatedQueueMessage(tx *pop.Connection, claim models.Claim) {
+	claim.LoadPolicyMembers(tx, false)
+	memberName := claim.Policy.Members[0].Name()
+
+	data := newEmailMessageData()
+	data.addClaimData(tx, claim)
+	data["memberName"] = memberName
+
+	item := data["item"].(models.Item)
+
+	notn := models.Notification{
+		ClaimID:       nulls.NewUUID(claim.ID),
+		Body:          data.renderHTML(MessageTemplateClaimEscalatedSignator),
+		Subject:       "Claim on " + item.Name + " needs a higher approval authority",
+		InappText:     "A claim over another signator's approval limit is waiting for your approval",
+		Event:         "Claim Escalated Notification",
+		EventCategory: EventCategoryClaim,
+	}
+	if err := notn.Create(tx); err != nil {
+		panic("error creating new Claim Escalated Notification: " + err.Error())
+	}
+
+	var approvers models.Users
+	approvers.FindApprovers(tx, claim.TotalPayout)
+	for _, a := range approvers {
+		notn.CreateNotificationUserForUser(tx, a)
+	}
+}
@@ -3,6 +3,7 @@ package messages
 import (
 	"testing"
 
+	"github.com/gobuffalo/nulls"
 	"github.com/gobuffalo/pop/v5"
 
 	"github.com/silinternational/cover-api/api"
@@ -289,3 +290,172 @@ func (ts *TestSuite) Test_ClaimDeniedQueueMessage() {
 		})
 	}
 }
+
+func (ts *TestSuite) Test_ClaimAppealedQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	f := getClaimFixtures(db)
+
+	member0 := f.Policies[0].Members[0]
+	member1 := f.Policies[0].Members[1]
+	item := f.Policies[0].Items[0]
+
+	steward := models.CreateAdminUsers(db)[models.AppRoleSteward]
+
+	appealedClaim := models.UpdateClaimStatus(db, f.Claims[0], api.ClaimStatusReview1, "")
+	appealedClaim.AppealReason = "I found the police report"
+
+	ClaimAppealedQueueMessage(db, appealedClaim)
+
+	tests := []testData{
+		{
+			name:                  "stewards",
+			wantToEmails:          []interface{}{steward.EmailOfChoice()},
+			wantSubjectContains:   "Appeal of denied claim on " + item.Name,
+			wantInappTextContains: "A denied claim has been appealed",
+			wantBodyContains: []string{
+				domain.Env.UIURL,
+				appealedClaim.ReferenceNumber,
+				appealedClaim.AppealReason,
+			},
+		},
+		{
+			name:                  "members",
+			wantToEmails:          []interface{}{member0.EmailOfChoice(), member1.EmailOfChoice()},
+			wantSubjectContains:   "We Received Your Appeal",
+			wantInappTextContains: "your appeal has been received",
+			wantBodyContains: []string{
+				domain.Env.UIURL,
+				item.Name,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validateNotificationUsers(ts, db, tt)
+		})
+	}
+}
+
+func (ts *TestSuite) Test_ClaimWithdrawnQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	f := getClaimFixtures(db)
+
+	steward := models.CreateAdminUsers(db)[models.AppRoleSteward]
+
+	withdrawnClaim := models.UpdateClaimStatus(db, f.Claims[0], api.ClaimStatusWithdrawn, "filed by mistake")
+
+	tests := []testData{
+		{
+			name:                  "claim withdrawn",
+			wantToEmails:          []interface{}{steward.EmailOfChoice()},
+			wantSubjectContains:   "Claim withdrawn on " + withdrawnClaim.ClaimItems[0].Item.Name,
+			wantInappTextContains: "A claim has been withdrawn by the member",
+			wantBodyContains: []string{
+				domain.Env.UIURL,
+				withdrawnClaim.ReferenceNumber,
+				withdrawnClaim.StatusReason,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ClaimWithdrawnQueueMessage(db, withdrawnClaim)
+			validateNotificationUsers(ts, db, tt)
+		})
+	}
+}
+
+func (ts *TestSuite) Test_ClaimApprovalAddedQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	f := getClaimFixtures(db)
+
+	admins := models.CreateAdminUsers(db)
+	others := models.CreateAdminUsers(db)
+	otherSteward := others[models.AppRoleSteward]
+	otherSignator := others[models.AppRoleSignator]
+
+	claim := f.Claims[0]
+	claim.ReviewerID = nulls.NewUUID(admins[models.AppRoleSteward].ID)
+	claim = models.UpdateClaimStatus(db, claim, api.ClaimStatusReview3, "")
+
+	approval := models.ClaimApproval{ClaimID: claim.ID, UserID: admins[models.AppRoleSignator].ID}
+	ts.NoError(approval.Create(db))
+
+	tests := []testData{
+		{
+			name: "approval added",
+			wantToEmails: []interface{}{
+				otherSteward.EmailOfChoice(),
+				otherSignator.EmailOfChoice(),
+			},
+			wantSubjectContains:   "Another approval needed for claim on " + claim.ClaimItems[0].Item.Name,
+			wantInappTextContains: "A claim needs another final approval",
+			wantBodyContains: []string{
+				domain.Env.UIURL,
+				claim.ReferenceNumber,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ClaimApprovalAddedQueueMessage(db, claim)
+			validateNotificationUsers(ts, db, tt)
+
+			for _, u := range admins {
+				n, err := db.Where("email_address = ?", u.EmailOfChoice()).Count(&models.NotificationUser{})
+				ts.NoError(err)
+				ts.Equal(0, n, "the reviewer and the approver should not be notified")
+			}
+		})
+	}
+}
+
+func (ts *TestSuite) Test_ClaimEscalatedQueueMessage() {
+	t := ts.T()
+	db := ts.DB
+
+	f := getClaimFixtures(db)
+
+	admins := models.CreateAdminUsers(db)
+	approver := admins[models.AppRoleSignator]
+	limitedSignator := models.CreateAdminUsers(db)[models.AppRoleSignator]
+	limit := api.Currency(100)
+	ts.NoError(limitedSignator.SetApprovalLimit(db, &limit))
+
+	escalatedClaim := f.Claims[0]
+	escalatedClaim.TotalPayout = 50000
+	escalatedClaim = models.UpdateClaimStatus(db, escalatedClaim, api.ClaimStatusReview3, "")
+
+	tests := []testData{
+		{
+			name:                  "claim escalated",
+			wantToEmails:          []interface{}{approver.EmailOfChoice()},
+			wantSubjectContains:   "Claim on " + escalatedClaim.ClaimItems[0].Item.Name + " needs a higher approval authority",
+			wantInappTextContains: "A claim over another signator's approval limit is waiting for your approval",
+			wantBodyContains: []string{
+				domain.Env.UIURL,
+				escalatedClaim.ReferenceNumber,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ClaimEscalatedQueueMessage(db, escalatedClaim)
+			validateNotificationUsers(ts, db, tt)
+
+			n, err := db.Where("email_address = ?", limitedSignator.EmailOfChoice()).Count(&models.NotificationUser{})
+			ts.NoError(err)
+			ts.Equal(0, n, "signator with a lower approval limit should not be notified")
+		})
+	}
+}
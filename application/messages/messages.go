@@ -28,6 +28,10 @@ const (
 	MessageTemplateClaimReview3Signator   = "claim_review3_signator"
 	MessageTemplateClaimApprovedMember    = "claim_approved_member"
 	MessageTemplateClaimDeniedMember      = "claim_denied_member"
+	MessageTemplateClaimAppealedSteward   = "claim_appealed_steward"
+	MessageTemplateClaimAppealedMember    = "claim_appealed_member"
+	MessageTemplateClaimWithdrawnSteward  = "claim_withdrawn_steward"
+	MessageTemplateClaimEscalatedSignator = "claim_escalated_signator"
 
 	MessageTemplateItemPendingSteward = "item_pending_steward"
 	MessageTemplateItemApprovedMember = "item_approved_member"
@@ -123,8 +127,8 @@ func (m MessageData) addItemData(tx *pop.Connection, item models.Item) {
 	} else {
 		m["coverageEndDate"] = ""
 	}
-	m["annualPremium"] = "$" + item.CalculateAnnualPremium().String()
-	m["proratedPremium"] = "$" + item.CalculateProratedPremium(item.CoverageStartDate).String()
+	m["annualPremium"] = "$" + item.CalculateAnnualPremium(tx).String()
+	m["proratedPremium"] = "$" + item.CalculateProratedPremium(tx, item.CoverageStartDate).String()
 }
 
 func (m MessageData) addStewardData(tx *pop.Connection) {
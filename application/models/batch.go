@@ -0,0 +1,344 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/fin"
+)
+
+type Batches []Batch
+
+// Batch is an immutable snapshot of a set of LedgerEntries and the file generated from them for export to the
+// accounting system
+type Batch struct {
+	ID             uuid.UUID     `db:"id"`
+	ProviderType   string        `db:"provider_type" validate:"required"`
+	Type           api.BatchType `db:"type" validate:"required"`
+	BatchDate      time.Time     `db:"batch_date" validate:"required"` // first day of the batch period
+	EndDate        time.Time     `db:"end_date" validate:"required"`   // last day of the batch period
+	Filename       string        `db:"filename" validate:"required"`
+	ContentType    string        `db:"content_type" validate:"required"`
+	Content        []byte        `db:"content"`
+	Checksum       string        `db:"checksum"` // SHA-256 of Content
+	EntryCount     int           `db:"entry_count"`
+	TotalPremiums  api.Currency  `db:"total_premiums"`
+	TotalClaims    api.Currency  `db:"total_claims"`
+	CreatedByID    uuid.UUID     `db:"created_by_id" validate:"required"`
+	ApprovedAt     nulls.Time    `db:"approved_at"`
+	ApprovedByID   nulls.UUID    `db:"approved_by_id"`
+	ReversedAt     nulls.Time    `db:"reversed_at"`
+	ReversedByID   nulls.UUID    `db:"reversed_by_id"`
+	ReversalReason string        `db:"reversal_reason"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+
+	LedgerEntries LedgerEntries `many_to_many:"batch_ledger_entries" validate:"-"`
+}
+
+// batchMetadataColumns are the columns of a Batch other than the file Content, which is only loaded when the file
+// is downloaded
+var batchMetadataColumns = []string{
+	"id", "provider_type", "type", "batch_date", "end_date", "filename", "content_type", "checksum", "entry_count",
+	"total_premiums", "total_claims", "created_by_id", "approved_at", "approved_by_id", "reversed_at",
+	"reversed_by_id", "reversal_reason", "created_at", "updated_at",
+}
+
+type BatchLedgerEntry struct {
+	ID            uuid.UUID `db:"id"`
+	BatchID       uuid.UUID `db:"batch_id"`
+	LedgerEntryID uuid.UUID `db:"ledger_entry_id"`
+	Reconciled    bool      `db:"reconciled"` // true if the entry was reconciled by the approval of this Batch
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (b *Batch) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(b), nil
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (b *BatchLedgerEntry) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(b), nil
+}
+
+func (b *Batch) Create(tx *pop.Connection) error {
+	return create(tx, b)
+}
+
+// Update saves the changes to the Batch. The Content is never changed, since it may not have been loaded.
+func (b *Batch) Update(tx *pop.Connection) error {
+	return update(tx, b, "content")
+}
+
+func (b *BatchLedgerEntry) Create(tx *pop.Connection) error {
+	return create(tx, b)
+}
+
+func (b *Batch) GetID() uuid.UUID {
+	return b.ID
+}
+
+// FindByID finds the Batch with the given ID, without its Content. Use LoadContent to load the file.
+func (b *Batch) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	err := tx.Select(batchMetadataColumns...).Find(b, id)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// IsActorAllowedTo ensure the actor is an admin, since batches are only used by the accounting staff
+func (b *Batch) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, r *http.Request) bool {
+	return actor.IsAdmin()
+}
+
+// NewBatch generates the export file for the given LedgerEntries and saves it, along with a reference to each of
+// the LedgerEntries, as a new Batch of the given type for the period from startDate to endDate. The file extension
+// is added to the given filename according to the provider.
+func NewBatch(ctx context.Context, providerType string, batchType api.BatchType, startDate, endDate time.Time,
+	filename string, entries LedgerEntries) (Batch, error) {
+	tx := Tx(ctx)
+
+	export := entries.MakeBatch(providerType, startDate)
+	content := export.BatchToCSV()
+
+	b := Batch{
+		ProviderType: providerType,
+		Type:         batchType,
+		BatchDate:    startDate,
+		EndDate:      endDate,
+		Filename:     filename + "." + export.FileExtension(),
+		ContentType:  export.ContentType(),
+		Content:      content,
+		Checksum:     fin.Checksum(content),
+		EntryCount:   len(entries),
+		CreatedByID:  CurrentUser(ctx).ID,
+	}
+	for _, e := range entries {
+		if e.Type.IsClaim() {
+			b.TotalClaims += e.Amount
+		} else {
+			b.TotalPremiums += e.Amount
+		}
+	}
+
+	// A repeated request, e.g. a reload or a retry, reuses the latest snapshot if nothing has changed
+	var latest Batch
+	if err := latest.FindLatestPending(tx, batchType, startDate, endDate); err == nil {
+		if latest.ProviderType == providerType && latest.Checksum == b.Checksum {
+			latest.LedgerEntries = entries
+			return latest, nil
+		}
+	} else if domain.IsOtherThanNoRows(err) {
+		return Batch{}, err
+	}
+
+	if err := b.Create(tx); err != nil {
+		return Batch{}, err
+	}
+
+	for _, e := range entries {
+		ble := BatchLedgerEntry{BatchID: b.ID, LedgerEntryID: e.ID}
+		if err := ble.Create(tx); err != nil {
+			return Batch{}, err
+		}
+	}
+
+	b.LedgerEntries = entries
+	return b, nil
+}
+
+// Approve marks the Batch as approved and reconciles the LedgerEntries included in the Batch snapshot. Entries
+// that have already been reconciled are skipped. A Batch cannot be approved if another Batch of the same type for
+// the same period was approved and not reversed. Returns the number of entries reconciled.
+func (b *Batch) Approve(ctx context.Context) (int, error) {
+	if b.ApprovedAt.Valid {
+		err := fmt.Errorf("batch %s was already approved", b.ID)
+		return 0, api.NewAppError(err, api.ErrorBatchAlreadyApproved, api.CategoryUser)
+	}
+
+	tx := Tx(ctx)
+
+	periodApproved, err := b.isPeriodApproved(tx)
+	if err != nil {
+		return 0, err
+	}
+	if periodApproved {
+		err := fmt.Errorf("a batch overlapping %s to %s was already approved",
+			b.BatchDate.Format(domain.DateFormat), b.EndDate.Format(domain.DateFormat))
+		return 0, api.NewAppError(err, api.ErrorBatchPeriodAlreadyApproved, api.CategoryUser)
+	}
+	b.LoadLedgerEntries(tx, true)
+
+	var pending LedgerEntries
+	for _, e := range b.LedgerEntries {
+		if !e.DateEntered.Valid {
+			pending = append(pending, e)
+		}
+	}
+
+	if err := pending.Reconcile(ctx); err != nil {
+		return 0, err
+	}
+	for _, e := range pending {
+		err := tx.RawQuery("UPDATE batch_ledger_entries SET reconciled = true WHERE batch_id = ? AND ledger_entry_id = ?",
+			b.ID, e.ID).Exec()
+		if err != nil {
+			return 0, appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+	}
+
+	b.ApprovedAt = nulls.NewTime(time.Now().UTC())
+	b.ApprovedByID = nulls.NewUUID(CurrentUser(ctx).ID)
+	if err := b.Update(tx); err != nil {
+		return 0, err
+	}
+
+	return len(pending), nil
+}
+
+// Reverse undoes the approval of the Batch. Each LedgerEntry that was reconciled by the approval of this Batch is
+// marked as not entered, so that it can be included in a new Batch, and any Claim paid by the Batch is returned to
+// Approved. Entries that were already reconciled by another Batch are left alone. If the Batch was already posted in
+// the accounting system, a compensating LedgerEntry is created for each entry to cancel it out. Returns the number of
+// entries reversed.
+func (b *Batch) Reverse(ctx context.Context, input api.BatchReverseInput) (int, error) {
+	if !b.ApprovedAt.Valid {
+		err := fmt.Errorf("batch %s has not been approved", b.ID)
+		return 0, api.NewAppError(err, api.ErrorBatchNotApproved, api.CategoryUser)
+	}
+	if b.ReversedAt.Valid {
+		err := fmt.Errorf("batch %s was already reversed", b.ID)
+		return 0, api.NewAppError(err, api.ErrorBatchAlreadyReversed, api.CategoryUser)
+	}
+
+	tx := Tx(ctx)
+
+	entries, err := b.reconciledEntries(tx)
+	if err != nil {
+		return 0, err
+	}
+
+	var n int
+	for i := range entries {
+		entry := entries[i]
+		if !entry.DateEntered.Valid {
+			continue
+		}
+
+		if input.Posted {
+			reversal := entry.NewReversal()
+			if err := reversal.Create(tx); err != nil {
+				return 0, err
+			}
+		}
+
+		if err := entry.Unreconcile(ctx); err != nil {
+			return 0, err
+		}
+		n++
+	}
+
+	b.ReversedAt = nulls.NewTime(time.Now().UTC())
+	b.ReversedByID = nulls.NewUUID(CurrentUser(ctx).ID)
+	b.ReversalReason = input.Reason
+	if err := b.Update(tx); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// reconciledEntries finds the LedgerEntries that were reconciled by the approval of the Batch
+func (b *Batch) reconciledEntries(tx *pop.Connection) (LedgerEntries, error) {
+	var entries LedgerEntries
+	err := tx.Where("id IN (SELECT ledger_entry_id FROM batch_ledger_entries WHERE batch_id = ? AND reconciled = true)",
+		b.ID).All(&entries)
+	return entries, appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// isPeriodApproved returns true if another Batch of the same type for a period that overlaps this Batch's period is
+// approved and has not been reversed. A renewal Batch covers the whole year, so it is only compared with other
+// renewal Batches and does not block the monthly Batches of the same year.
+func (b *Batch) isPeriodApproved(tx *pop.Connection) (bool, error) {
+	exists, err := tx.Where("type = ?", b.Type).
+		Where("batch_date <= ? AND end_date >= ?", b.EndDate, b.BatchDate).
+		Where("approved_at IS NOT NULL AND reversed_at IS NULL").
+		Where("id != ?", b.ID).
+		Exists(&Batch{})
+	return exists, appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// FindLatestPending finds the most recently created Batch of the given type for the given period that has not been
+// approved
+func (b *Batch) FindLatestPending(tx *pop.Connection, batchType api.BatchType, startDate, endDate time.Time) error {
+	err := tx.Where("type = ? AND batch_date = ? AND end_date = ?", batchType, startDate, endDate).
+		Where("approved_at IS NULL").
+		Order("created_at desc").
+		First(b)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// All finds all Batches, most recent first, without their Content
+func (b *Batches) All(tx *pop.Connection) error {
+	err := tx.Select(batchMetadataColumns...).Order("created_at desc").All(b)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// LoadContent loads the file Content of the Batch
+func (b *Batch) LoadContent(tx *pop.Connection) error {
+	var stored Batch
+	if err := tx.Select("id", "content").Find(&stored, b.ID); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	b.Content = stored.Content
+	return nil
+}
+
+func (b *Batch) LoadLedgerEntries(tx *pop.Connection, reload bool) {
+	if len(b.LedgerEntries) == 0 || reload {
+		if err := tx.Load(b, "LedgerEntries"); err != nil {
+			panic("database error loading Batch.LedgerEntries, " + err.Error())
+		}
+	}
+}
+
+func (b *Batch) ConvertToAPI() api.Batch {
+	return api.Batch{
+		ID:             b.ID,
+		ProviderType:   b.ProviderType,
+		Type:           b.Type,
+		BatchDate:      b.BatchDate,
+		EndDate:        b.EndDate,
+		Filename:       b.Filename,
+		Checksum:       b.Checksum,
+		EntryCount:     b.EntryCount,
+		TotalPremiums:  b.TotalPremiums,
+		TotalClaims:    b.TotalClaims,
+		CreatedByID:    b.CreatedByID,
+		CreatedAt:      b.CreatedAt,
+		ApprovedAt:     convertTimeToAPI(b.ApprovedAt),
+		ApprovedByID:   convertUUIDToAPI(b.ApprovedByID),
+		ReversedAt:     convertTimeToAPI(b.ReversedAt),
+		ReversedByID:   convertUUIDToAPI(b.ReversedByID),
+		ReversalReason: b.ReversalReason,
+	}
+}
+
+func (b *Batches) ConvertToAPI() api.Batches {
+	batches := make(api.Batches, len(*b))
+	for i, bb := range *b {
+		batches[i] = bb.ConvertToAPI()
+	}
+	return batches
+}
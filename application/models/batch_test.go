@@ -0,0 +1,265 @@
+package models
+
+import (
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/fin"
+)
+
+func (ms *ModelSuite) createBatchEntries() (User, LedgerEntries) {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	user := f.Users[0]
+	ctx := CreateTestContext(user)
+
+	for i := range f.Items {
+		ms.NoError(f.Items[i].Approve(ctx, false))
+	}
+
+	var entries LedgerEntries
+	ms.NoError(ms.DB.Where("type = ?", LedgerEntryTypeNewCoverage).All(&entries))
+	ms.Equal(2, len(entries), "incorrect number of LedgerEntries in test setup")
+
+	return user, entries
+}
+
+func (ms *ModelSuite) TestNewBatch() {
+	user, entries := ms.createBatchEntries()
+	ctx := CreateTestContext(user)
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	got, err := NewBatch(ctx, fin.ProviderTypeQuickBooks, api.BatchTypePeriod, date, domain.EndOfMonth(date),
+		"batch_2021-03", entries)
+	ms.NoError(err)
+
+	ms.Equal("batch_2021-03.iif", got.Filename, "incorrect Filename")
+	ms.Equal("text/plain", got.ContentType, "incorrect ContentType")
+	ms.Equal(entries.ToCsv(fin.ProviderTypeQuickBooks, date), got.Content, "incorrect Content")
+	ms.Equal(len(entries), got.EntryCount, "incorrect EntryCount")
+	ms.Equal(entries[0].Amount+entries[1].Amount, got.TotalPremiums, "incorrect TotalPremiums")
+	ms.Equal(api.Currency(0), got.TotalClaims, "incorrect TotalClaims")
+	ms.Equal(user.ID, got.CreatedByID, "incorrect CreatedByID")
+	ms.Equal(api.BatchTypePeriod, got.Type, "incorrect Type")
+
+	var batch Batch
+	ms.NoError(batch.FindByID(ms.DB, got.ID))
+	batch.LoadLedgerEntries(ms.DB, false)
+	ms.Equal(len(entries), len(batch.LedgerEntries), "incorrect number of LedgerEntries saved with Batch")
+	ms.Empty(batch.Content, "FindByID should not load the Content")
+	ms.NoError(batch.LoadContent(ms.DB))
+	ms.Equal(got.Content, batch.Content, "incorrect Content loaded")
+
+	again, err := NewBatch(ctx, fin.ProviderTypeQuickBooks, api.BatchTypePeriod, date, domain.EndOfMonth(date),
+		"batch_2021-03", entries)
+	ms.NoError(err)
+	ms.Equal(got.ID, again.ID, "an unchanged batch should reuse the latest snapshot")
+
+	changed, err := NewBatch(ctx, fin.ProviderTypeQuickBooks, api.BatchTypePeriod, date, domain.EndOfMonth(date),
+		"batch_2021-03", entries[:1])
+	ms.NoError(err)
+	ms.NotEqual(got.ID, changed.ID, "a changed batch should be saved as a new snapshot")
+
+	otherProvider, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypePeriod, date, domain.EndOfMonth(date),
+		"batch_2021-03", entries[:1])
+	ms.NoError(err)
+	ms.NotEqual(changed.ID, otherProvider.ID, "a batch for another provider should be saved as a new snapshot")
+
+	var batches Batches
+	ms.NoError(batches.All(ms.DB))
+	ms.Equal(3, len(batches), "incorrect number of snapshots saved")
+	for _, b := range batches {
+		ms.Empty(b.Content, "the list should not load the Content")
+		ms.NotEmpty(b.Checksum, "the list should load the Checksum")
+	}
+}
+
+func (ms *ModelSuite) TestBatch_Approve() {
+	user, entries := ms.createBatchEntries()
+	ctx := CreateTestContext(user)
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// Only the first entry is included in the snapshot
+	batch, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypePeriod, date, domain.EndOfMonth(date),
+		"batch", entries[:1])
+	ms.NoError(err)
+
+	n, err := batch.Approve(ctx)
+	ms.NoError(err)
+	ms.Equal(1, n, "incorrect number of entries approved")
+	ms.True(batch.ApprovedAt.Valid, "ApprovedAt was not set")
+	ms.Equal(user.ID, batch.ApprovedByID.UUID, "incorrect ApprovedByID")
+
+	var entry LedgerEntry
+	ms.NoError(ms.DB.Find(&entry, entries[0].ID))
+	ms.True(entry.DateEntered.Valid, "entry in batch was not reconciled")
+
+	ms.NoError(ms.DB.Find(&entry, entries[1].ID))
+	ms.False(entry.DateEntered.Valid, "entry not in batch should not be reconciled")
+
+	_, err = batch.Approve(ctx)
+	ms.EqualAppError(api.AppError{Key: api.ErrorBatchAlreadyApproved, Category: api.CategoryUser}, err)
+
+	// A second batch for the same period cannot be approved
+	batch2, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypePeriod, date, domain.EndOfMonth(date),
+		"batch", entries[1:])
+	ms.NoError(err)
+	_, err = batch2.Approve(ctx)
+	ms.EqualAppError(api.AppError{Key: api.ErrorBatchPeriodAlreadyApproved, Category: api.CategoryUser}, err)
+
+	// Nor can a batch for a custom range that overlaps the approved period
+	overlapStart := time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)
+	overlapEnd := time.Date(2021, 4, 15, 0, 0, 0, 0, time.UTC)
+	batch3, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypePeriod, overlapStart, overlapEnd,
+		"batch", entries[1:])
+	ms.NoError(err)
+	_, err = batch3.Approve(ctx)
+	ms.EqualAppError(api.AppError{Key: api.ErrorBatchPeriodAlreadyApproved, Category: api.CategoryUser}, err)
+
+	// A batch for the following month does not overlap
+	april := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	batch4, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypePeriod, april, domain.EndOfMonth(april),
+		"batch", entries[1:])
+	ms.NoError(err)
+	_, err = batch4.Approve(ctx)
+	ms.NoError(err)
+}
+
+func (ms *ModelSuite) TestBatch_ApproveRenewal() {
+	user, entries := ms.createBatchEntries()
+	ctx := CreateTestContext(user)
+	jan1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	dec31 := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	// A renewal batch does not block a monthly batch in the same year
+	renewal, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypeRenewal, jan1, dec31, "renewal_2021",
+		entries[:1])
+	ms.NoError(err)
+	_, err = renewal.Approve(ctx)
+	ms.NoError(err)
+
+	monthly, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypePeriod, march, domain.EndOfMonth(march),
+		"batch_2021-03", entries[1:])
+	ms.NoError(err)
+	_, err = monthly.Approve(ctx)
+	ms.NoError(err)
+
+	// Nor does a monthly batch block the renewal batch
+	monthly2, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypePeriod, jan1, domain.EndOfMonth(jan1),
+		"batch_2021-01", entries[:1])
+	ms.NoError(err)
+	_, err = monthly2.Approve(ctx)
+	ms.NoError(err)
+
+	renewal2, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypeRenewal, jan1, dec31, "renewal_2021",
+		entries[1:])
+	ms.NoError(err)
+	ms.NotEqual(renewal.ID, renewal2.ID, "an approved renewal batch should not be reused")
+
+	// But a second renewal batch for the same year cannot be approved
+	_, err = renewal2.Approve(ctx)
+	ms.EqualAppError(api.AppError{Key: api.ErrorBatchPeriodAlreadyApproved, Category: api.CategoryUser}, err)
+
+	var pending Batch
+	ms.NoError(pending.FindLatestPending(ms.DB, api.BatchTypeRenewal, jan1, dec31))
+	ms.Equal(renewal2.ID, pending.ID, "incorrect pending renewal batch")
+	ms.Error(pending.FindLatestPending(ms.DB, api.BatchTypePeriod, jan1, dec31),
+		"a renewal batch should not be found as a pending period batch")
+}
+
+func (ms *ModelSuite) TestBatch_Reverse() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	item := f.Claims[0].ClaimItems[0].Item
+
+	user := f.Users[0]
+	ctx := CreateTestContext(user)
+	ms.NoError(item.SetAccountablePerson(ms.DB, user.ID))
+	ms.NoError(item.Update(ctx))
+
+	var claim Claim
+	ms.NoError(ms.DB.Find(&claim, f.Claims[0].ID))
+	claim = UpdateClaimStatus(ms.DB, claim, api.ClaimStatusApproved, "")
+	ms.NoError(claim.CreateLedgerEntry(ms.DB))
+
+	var entries LedgerEntries
+	ms.NoError(ms.DB.Where("claim_id = ?", claim.ID).All(&entries))
+
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	batch, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypePeriod, date, domain.EndOfMonth(date),
+		"batch", entries)
+	ms.NoError(err)
+
+	_, err = batch.Reverse(ctx, api.BatchReverseInput{})
+	ms.EqualAppError(api.AppError{Key: api.ErrorBatchNotApproved, Category: api.CategoryUser}, err)
+
+	_, err = batch.Approve(ctx)
+	ms.NoError(err)
+	ms.NoError(ms.DB.Find(&claim, claim.ID))
+	ms.Equal(api.ClaimStatusPaid, claim.Status, "claim was not paid by the batch approval")
+
+	n, err := batch.Reverse(ctx, api.BatchReverseInput{Posted: true, Reason: "bad import"})
+	ms.NoError(err)
+	ms.Equal(len(entries), n, "incorrect number of entries reversed")
+	ms.True(batch.ReversedAt.Valid, "ReversedAt was not set")
+	ms.Equal("bad import", batch.ReversalReason, "incorrect ReversalReason")
+
+	ms.NoError(ms.DB.Find(&claim, claim.ID))
+	ms.Equal(api.ClaimStatusApproved, claim.Status, "claim status was not restored")
+
+	var history ClaimHistory
+	ms.NoError(ms.DB.Where("claim_id = ? AND field_name = ? AND new_value = ?",
+		claim.ID, FieldClaimStatus, api.ClaimStatusApproved).First(&history), "claim history was not created")
+
+	var original LedgerEntry
+	ms.NoError(ms.DB.Find(&original, entries[0].ID))
+	ms.False(original.DateEntered.Valid, "original entry DateEntered was not cleared")
+
+	var reversal LedgerEntry
+	ms.NoError(ms.DB.Where("reversed_entry_id = ?", original.ID).First(&reversal), "compensating entry not found")
+	ms.Equal(-original.Amount, reversal.Amount, "incorrect compensating entry Amount")
+	ms.Equal(original.ClaimID, reversal.ClaimID, "incorrect compensating entry ClaimID")
+	ms.False(reversal.DateEntered.Valid, "compensating entry should not be entered")
+
+	_, err = batch.Reverse(ctx, api.BatchReverseInput{})
+	ms.EqualAppError(api.AppError{Key: api.ErrorBatchAlreadyReversed, Category: api.CategoryUser}, err)
+}
+
+func (ms *ModelSuite) TestBatch_ReverseSharedEntries() {
+	user, entries := ms.createBatchEntries()
+	ctx := CreateTestContext(user)
+	march := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	jan1 := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	dec31 := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	// the first entry is reconciled by a monthly batch
+	monthly, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypePeriod, march, domain.EndOfMonth(march),
+		"batch_2021-03", entries[:1])
+	ms.NoError(err)
+	_, err = monthly.Approve(ctx)
+	ms.NoError(err)
+
+	// a second batch shares that entry, but only reconciles the other one
+	renewal, err := NewBatch(ctx, fin.ProviderTypeSage, api.BatchTypeRenewal, jan1, dec31, "renewal_2021", entries)
+	ms.NoError(err)
+	n, err := renewal.Approve(ctx)
+	ms.NoError(err)
+	ms.Equal(1, n, "incorrect number of entries approved by the second batch")
+
+	n, err = renewal.Reverse(ctx, api.BatchReverseInput{Posted: true, Reason: "bad import"})
+	ms.NoError(err)
+	ms.Equal(1, n, "only the entry reconciled by the second batch should be reversed")
+
+	var entry LedgerEntry
+	ms.NoError(ms.DB.Find(&entry, entries[0].ID))
+	ms.True(entry.DateEntered.Valid, "entry reconciled by the first batch should still be entered")
+	ms.NoError(ms.DB.Find(&entry, entries[1].ID))
+	ms.False(entry.DateEntered.Valid, "entry reconciled by the second batch was not reversed")
+
+	count, err := ms.DB.Where("reversed_entry_id = ?", entries[0].ID).Count(&LedgerEntry{})
+	ms.NoError(err)
+	ms.Equal(0, count, "no compensating entry should be created for the first batch's entry")
+	count, err = ms.DB.Where("reversed_entry_id = ?", entries[1].ID).Count(&LedgerEntry{})
+	ms.NoError(err)
+	ms.Equal(1, count, "compensating entry not created for the reversed entry")
+}
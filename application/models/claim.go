@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/gobuffalo/buffalo"
@@ -33,15 +34,16 @@ var ValidClaimIncidentTypes = map[api.ClaimIncidentType]struct{}{
 }
 
 var ValidClaimStatus = map[api.ClaimStatus]struct{}{
-	api.ClaimStatusDraft:    {},
-	api.ClaimStatusReview1:  {},
-	api.ClaimStatusReview2:  {},
-	api.ClaimStatusReview3:  {},
-	api.ClaimStatusRevision: {},
-	api.ClaimStatusReceipt:  {},
-	api.ClaimStatusApproved: {},
-	api.ClaimStatusPaid:     {},
-	api.ClaimStatusDenied:   {},
+	api.ClaimStatusDraft:     {},
+	api.ClaimStatusReview1:   {},
+	api.ClaimStatusReview2:   {},
+	api.ClaimStatusReview3:   {},
+	api.ClaimStatusRevision:  {},
+	api.ClaimStatusReceipt:   {},
+	api.ClaimStatusApproved:  {},
+	api.ClaimStatusPaid:      {},
+	api.ClaimStatusDenied:    {},
+	api.ClaimStatusWithdrawn: {},
 }
 
 var ValidClaimIncidentTypePayoutOptions = map[api.ClaimIncidentType]map[api.PayoutOption]struct{}{
@@ -95,6 +97,10 @@ type Claim struct {
 	PaymentDate         nulls.Time            `db:"payment_date"`
 	TotalPayout         api.Currency          `db:"total_payout"`
 	StatusReason        string                `db:"status_reason" validate:"required_if=Status Revision,required_if=Status Denied"`
+	AppealedAt          nulls.Time            `db:"appealed_at"`
+	AppealReason        string                `db:"appeal_reason"`
+	DeniedByID          nulls.UUID            `db:"denied_by_id"`
+	EscalatedAt         nulls.Time            `db:"escalated_at"`
 	City                string                `db:"city"`
 	State               string                `db:"state"`
 	Country             string                `db:"country"`
@@ -144,7 +150,17 @@ func (c *Claim) Update(ctx context.Context) error {
 		return appErr
 	}
 
-	if c.Status != api.ClaimStatusDraft {
+	if oldClaim.Status != c.Status {
+		transition, _ := claimWorkflow.transition(oldClaim.Status, c.Status)
+		if user := CurrentUser(ctx); !transition.isRoleAllowed(user.AppRole) {
+			err := fmt.Errorf("a user with role %s may not change a claim from %s to %s",
+				user.AppRole, oldClaim.Status, c.Status)
+			return api.NewAppError(err, api.ErrorClaimTransitionRole, api.CategoryUser)
+		}
+	}
+
+	// a draft may be withdrawn before any item is added
+	if c.Status != api.ClaimStatusDraft && c.Status != api.ClaimStatusWithdrawn {
 		c.LoadClaimItems(tx, false)
 		if len(c.ClaimItems) == 0 {
 			err := errors.New("claim must have a claimItem if no longer in draft")
@@ -161,6 +177,12 @@ func (c *Claim) Update(ctx context.Context) error {
 		return err
 	}
 
+	if isClaimStatusInQueue(oldClaim.Status) && !isClaimStatusInQueue(c.Status) {
+		if err := clearQueueAssignment(tx, "claim_id", c.ID); err != nil {
+			return err
+		}
+	}
+
 	updates := c.Compare(oldClaim)
 	for i := range updates {
 		history := c.NewHistory(ctx, api.HistoryActionUpdate, updates[i])
@@ -180,8 +202,10 @@ func (c *Claim) UpdateByUser(ctx context.Context) error {
 	// If the user edits something, it should take it off of the steward's list of things to review and
 	//  also force the user to resubmit it.
 	if c.Status == api.ClaimStatusReview1 {
-		c.Status = api.ClaimStatusDraft
-		c.StatusChange = ClaimStatusChangeReturnedToDraft + user.Name()
+		if transition, ok := claimWorkflow.transitionFor(c.Status, ClaimActionEdit); ok {
+			c.Status = transition.To
+			c.StatusChange = ClaimStatusChangeReturnedToDraft + user.Name()
+		}
 	}
 
 	if user.IsAdmin() {
@@ -207,7 +231,7 @@ func (c *Claim) canUpdate(user User) bool {
 
 	switch c.Status {
 	// cannot modify this when the Claim has one of these statuses
-	case api.ClaimStatusApproved, api.ClaimStatusDenied, api.ClaimStatusPaid:
+	case api.ClaimStatusApproved, api.ClaimStatusDenied, api.ClaimStatusPaid, api.ClaimStatusWithdrawn:
 		return false
 	}
 
@@ -235,7 +259,8 @@ func (c *Claim) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission
 	// Only admin can do these
 	adminSubs := []string{
 		api.ResourceRevision, api.ResourceApprove,
-		api.ResourcePreapprove, api.ResourceReceipt, api.ResourceDeny,
+		api.ResourcePreapprove, api.ResourceReceipt, api.ResourceDeny, api.ResourceDisbursements,
+		api.ResourceAdjustments, api.ResourceRecoveries, api.ResourceAssignment,
 	}
 	if domain.IsStringInSlice(string(sub), adminSubs) {
 		return false
@@ -262,45 +287,10 @@ func (c *Claim) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission
 	return false
 }
 
+// claimStatusTransitions returns the statuses to which a claim may change from each status, as defined by the
+// claim workflow
 func claimStatusTransitions() map[api.ClaimStatus][]api.ClaimStatus {
-	return map[api.ClaimStatus][]api.ClaimStatus{
-		api.ClaimStatusDraft: {
-			api.ClaimStatusReview1,
-		},
-		api.ClaimStatusReview1: {
-			api.ClaimStatusDraft,
-			api.ClaimStatusRevision,
-			api.ClaimStatusReceipt,
-			api.ClaimStatusReview3,
-			api.ClaimStatusDenied,
-		},
-		api.ClaimStatusRevision: {
-			api.ClaimStatusDraft,
-			api.ClaimStatusReview1,
-		},
-		api.ClaimStatusReceipt: {
-			api.ClaimStatusDraft,
-			api.ClaimStatusReview2,
-		},
-		api.ClaimStatusReview2: {
-			api.ClaimStatusDraft,
-			api.ClaimStatusRevision,
-			api.ClaimStatusReceipt,
-			api.ClaimStatusReview3,
-			api.ClaimStatusDenied,
-		},
-		api.ClaimStatusReview3: {
-			api.ClaimStatusDraft,
-			api.ClaimStatusRevision,
-			api.ClaimStatusReceipt,
-			api.ClaimStatusApproved,
-			api.ClaimStatusDenied,
-		},
-		api.ClaimStatusApproved: {
-			api.ClaimStatusPaid,
-		},
-		api.ClaimStatusDenied: {},
-	}
+	return claimWorkflow.targets()
 }
 
 func isClaimTransitionValid(status1, status2 api.ClaimStatus) (bool, error) {
@@ -356,30 +346,20 @@ func (c *Claim) AddItem(tx *pop.Connection, input api.ClaimItemCreateInput) (Cla
 	return claimItem, nil
 }
 
-// SubmitForApproval changes the status of the claim to either Review1 or Review2
-//   depending on its current status.
+// SubmitForApproval changes the status of the claim to the review status given by the claim workflow for its
+//   current status, normally Review1 from Draft or Revision and Review2 from Receipt.
 func (c *Claim) SubmitForApproval(ctx context.Context) error {
 	tx := Tx(ctx)
 	user := CurrentUser(ctx)
 
-	oldStatus := c.Status
-	var eventType string
+	transition, ok := claimWorkflow.transitionFor(c.Status, ClaimActionSubmit)
+	if !ok {
+		err := fmt.Errorf("invalid claim status for submit: %s", c.Status)
+		return api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
+	}
 
-	switch oldStatus {
-	case api.ClaimStatusDraft, api.ClaimStatusRevision:
-		c.Status = api.ClaimStatusReview1
-		c.StatusChange = ClaimStatusChangeReview1
-		eventType = domain.EventApiClaimReview1
-	case api.ClaimStatusReceipt:
-		if !c.HasReceiptFile(tx) {
-			err := errors.New("submitting this claim at this stage is not allowed until a receipt is attached")
-			return api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
-		}
-		c.Status = api.ClaimStatusReview2
-		c.StatusChange = ClaimStatusChangeReview2 + user.Name()
-		eventType = domain.EventApiClaimReview2
-	default:
-		err := fmt.Errorf("invalid claim status for submit: %s", oldStatus)
+	if c.Status == api.ClaimStatusReceipt && !c.HasReceiptFile(tx) {
+		err := errors.New("submitting this claim at this stage is not allowed until a receipt is attached")
 		return api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
 	}
 
@@ -395,6 +375,11 @@ func (c *Claim) SubmitForApproval(ctx context.Context) error {
 			return api.NewAppError(err, errorKey, api.CategoryUser)
 		}
 	}
+	if err := c.checkPayoutOption(transition); err != nil {
+		return err
+	}
+
+	eventType := c.setReviewStatus(transition.To, user)
 
 	if err := c.Update(ctx); err != nil {
 		return err
@@ -407,14 +392,26 @@ func (c *Claim) SubmitForApproval(ctx context.Context) error {
 	}
 	emitEvent(e)
 
+	if c.Status == api.ClaimStatusReview3 {
+		return c.clearApprovals(tx)
+	}
 	return nil
 }
 
 // RequestRevision changes the status of the claim to Revision
 func (c *Claim) RequestRevision(ctx context.Context, message string) error {
+	transition, ok := claimWorkflow.transitionFor(c.Status, ClaimActionRevision)
+	if !ok {
+		err := fmt.Errorf("invalid claim status for request revision: %s", c.Status)
+		return api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
+	}
+
 	user := CurrentUser(ctx)
+	if err := c.checkAppealReviewer(user); err != nil {
+		return err
+	}
 
-	c.Status = api.ClaimStatusRevision
+	c.Status = transition.To
 	c.StatusChange = ClaimStatusChangeRevisions + user.Name()
 	c.StatusReason = message
 	c.setReviewer(ctx)
@@ -433,25 +430,27 @@ func (c *Claim) RequestRevision(ctx context.Context, message string) error {
 	return nil
 }
 
-// RequestReceipt changes the status of the claim to Receipt
-//   provided that the current status is Review1.
+// RequestReceipt changes the status of the claim to Receipt, provided that the claim workflow allows it from the
+//   current status. From Review1, this preapproves the claim.
 func (c *Claim) RequestReceipt(ctx buffalo.Context, reason string) error {
-	oldStatus := c.Status
-	var eventType string
-
-	switch oldStatus {
-	case api.ClaimStatusReview1:
-		eventType = domain.EventApiClaimPreapproved
-	case api.ClaimStatusReview2, api.ClaimStatusReview3:
-		eventType = domain.EventApiClaimReceipt
-	default:
-		err := fmt.Errorf("invalid claim status for request receipt: %s", oldStatus)
+	transition, ok := claimWorkflow.transitionFor(c.Status, ClaimActionReceipt)
+	if !ok {
+		err := fmt.Errorf("invalid claim status for request receipt: %s", c.Status)
 		appErr := api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
 		return appErr
 	}
 
+	eventType := domain.EventApiClaimReceipt
+	if c.Status == api.ClaimStatusReview1 {
+		eventType = domain.EventApiClaimPreapproved
+	}
+
 	user := CurrentUser(ctx)
-	c.Status = api.ClaimStatusReceipt
+	if err := c.checkAppealReviewer(user); err != nil {
+		return err
+	}
+
+	c.Status = transition.To
 	c.StatusChange = ClaimStatusChangeReceipt + user.Name()
 	c.StatusReason = reason
 	c.setReviewer(ctx)
@@ -470,42 +469,58 @@ func (c *Claim) RequestReceipt(ctx buffalo.Context, reason string) error {
 	return nil
 }
 
-// Approve changes the status of the claim from either Review1, Review2 to Review3 or
-//  from Review3 to Approved. It also adds the ReviewerID and ReviewDate.
-func (c *Claim) Approve(ctx context.Context) error {
+// Approve changes the status of the claim to the status given by the claim workflow for its current status,
+//  normally from Review1 or Review2 to Review3 or from Review3 to Approved. It also adds the ReviewerID and
+//  ReviewDate.
+//  From Review3, the approval is recorded and the claim stays in Review3 until it has the number of approvals
+//  required by the claim workflow for its payout. If the payout is over the user's approval limit, the claim
+//  is escalated to the signators who can approve it instead.
+//  On final approval, the payout is disbursed in full, unless the input gives the amount of a first installment.
+func (c *Claim) Approve(ctx context.Context, input api.ClaimApproveInput) error {
 	var eventType string
 
 	user := CurrentUser(ctx)
+	if err := c.checkAppealReviewer(user); err != nil {
+		return err
+	}
 
-	switch c.Status {
-	case api.ClaimStatusReview1:
-		c.LoadClaimItems(Tx(ctx), false)
-		payOption := c.ClaimItems[0].PayoutOption
-		if payOption != api.PayoutOptionFMV && payOption != api.PayoutOptionFixedFraction {
-			err := fmt.Errorf("cannot approve payout option %s from status %s", payOption, c.Status)
-			appErr := api.NewAppError(err, api.ErrorClaimItemInvalidPayoutOption, api.CategoryUser)
-			return appErr
-		}
-		c.Status = api.ClaimStatusReview3
-		c.StatusChange = ClaimStatusChangeReview3 + user.Name()
-		eventType = domain.EventApiClaimReview3
-	case api.ClaimStatusReview2:
-		c.Status = api.ClaimStatusReview3
-		c.StatusChange = ClaimStatusChangeReview3 + user.Name()
-		eventType = domain.EventApiClaimReview3
-	case api.ClaimStatusReview3:
+	if input.Disbursement != nil && *input.Disbursement < 0 {
+		err := fmt.Errorf("disbursement of %s is negative", *input.Disbursement)
+		return api.NewAppError(err, api.ErrorClaimDisbursement, api.CategoryUser)
+	}
+
+	transition, ok := claimWorkflow.transitionFor(c.Status, ClaimActionApprove)
+	if !ok {
+		err := fmt.Errorf("invalid claim status for approve: %s", c.Status)
+		appErr := api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
+		return appErr
+	}
+
+	if transition.To == api.ClaimStatusApproved {
 		if user.ID == c.ReviewerID.UUID {
 			err := fmt.Errorf("different approver required for final approval")
 			appErr := api.NewAppError(err, api.ErrorClaimInvalidApprover, api.CategoryUser)
 			return appErr
 		}
-		c.Status = api.ClaimStatusApproved
+		if !user.CanApprove(c.TotalPayout) {
+			return c.escalate(ctx)
+		}
+		final, err := c.addApproval(ctx)
+		if err != nil {
+			return err
+		}
+		if !final {
+			return c.approvalAdded(ctx)
+		}
+		c.Status = transition.To
 		c.StatusChange = ClaimStatusChangeApproved + user.Name()
 		eventType = domain.EventApiClaimApproved
-	default:
-		err := fmt.Errorf("invalid claim status for approve: %s", c.Status)
-		appErr := api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
-		return appErr
+	} else {
+		c.LoadClaimItems(Tx(ctx), false)
+		if err := c.checkPayoutOption(transition); err != nil {
+			return err
+		}
+		eventType = c.setReviewStatus(transition.To, user)
 	}
 
 	c.setReviewer(ctx)
@@ -521,28 +536,39 @@ func (c *Claim) Approve(ctx context.Context) error {
 	}
 	emitEvent(e)
 
-	if c.Status == api.ClaimStatusApproved {
+	if c.Status == api.ClaimStatusReview3 {
+		return c.clearApprovals(Tx(ctx))
+	}
+	if c.Status != api.ClaimStatusApproved {
+		return nil
+	}
+	if input.Disbursement == nil {
 		return c.CreateLedgerEntry(Tx(ctx))
 	}
+	if *input.Disbursement > 0 {
+		return c.Disburse(Tx(ctx), *input.Disbursement)
+	}
 	return nil
 }
 
 // Deny changes the status of the claim to Denied and adds the ReviewerID and ReviewDate.
 func (c *Claim) Deny(ctx context.Context, message string) error {
-	oldStatus := c.Status
-
-	if oldStatus != api.ClaimStatusReview1 && oldStatus != api.ClaimStatusReview2 &&
-		oldStatus != api.ClaimStatusReview3 {
-		err := fmt.Errorf("invalid claim status for deny: %s", oldStatus)
+	transition, ok := claimWorkflow.transitionFor(c.Status, ClaimActionDeny)
+	if !ok {
+		err := fmt.Errorf("invalid claim status for deny: %s", c.Status)
 		appErr := api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
 		return appErr
 	}
 
 	user := CurrentUser(ctx)
+	if err := c.checkAppealReviewer(user); err != nil {
+		return err
+	}
 
-	c.Status = api.ClaimStatusDenied
+	c.Status = transition.To
 	c.StatusChange = ClaimStatusChangeDenied + user.Name()
 	c.StatusReason = message
+	c.DeniedByID = nulls.NewUUID(user.ID)
 	c.setReviewer(ctx)
 
 	if err := c.Update(ctx); err != nil {
@@ -559,6 +585,168 @@ func (c *Claim) Deny(ctx context.Context, message string) error {
 	return nil
 }
 
+// Withdraw cancels a claim at the request of a member of its policy, provided that the claim workflow allows it from
+// the current status and for the member's role. The stewards are notified unless the claim was still in Draft.
+func (c *Claim) Withdraw(ctx context.Context, reason string) error {
+	oldStatus := c.Status
+	user := CurrentUser(ctx)
+
+	c.LoadPolicy(Tx(ctx), false)
+	if !c.Policy.isMember(Tx(ctx), user.ID) {
+		err := fmt.Errorf("user %s is not a member of the claim's policy", user.ID)
+		return api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden)
+	}
+
+	transition, ok := claimWorkflow.transitionFor(oldStatus, ClaimActionWithdraw)
+	if !ok {
+		err := fmt.Errorf("invalid claim status for withdraw: %s", oldStatus)
+		return api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
+	}
+	if !transition.isRoleAllowed(user.AppRole) {
+		err := fmt.Errorf("a user with role %s may not withdraw a claim", user.AppRole)
+		return api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden)
+	}
+
+	c.Status = transition.To
+	c.StatusChange = ClaimStatusChangeWithdrawn + user.Name()
+	c.StatusReason = reason
+
+	if err := c.Update(ctx); err != nil {
+		return err
+	}
+
+	if oldStatus == api.ClaimStatusDraft {
+		return nil
+	}
+
+	e := events.Event{
+		Kind:    domain.EventApiClaimWithdrawn,
+		Message: fmt.Sprintf("Claim Withdrawn: %s  ID: %s", c.IncidentDescription, c.ID.String()),
+		Payload: events.Payload{domain.EventPayloadID: c.ID},
+	}
+	emitEvent(e)
+
+	return nil
+}
+
+// Appeal returns a denied claim to Review1 so that it can be reviewed again, provided that it was denied within the
+// appeal period and has not been appealed before. The review must be handled by someone other than the admin who
+// denied it.
+func (c *Claim) Appeal(ctx context.Context, input api.ClaimAppealInput) error {
+	user := CurrentUser(ctx)
+
+	c.LoadPolicy(Tx(ctx), false)
+	if !c.Policy.isMember(Tx(ctx), user.ID) {
+		err := fmt.Errorf("user %s is not a member of the claim's policy", user.ID)
+		return api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden)
+	}
+
+	transition, ok := claimWorkflow.transitionFor(c.Status, ClaimActionAppeal)
+	if !ok {
+		err := fmt.Errorf("invalid claim status for appeal: %s", c.Status)
+		return api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
+	}
+	if !transition.isRoleAllowed(user.AppRole) {
+		err := fmt.Errorf("a user with role %s may not appeal a claim", user.AppRole)
+		return api.NewAppError(err, api.ErrorNotAuthorized, api.CategoryForbidden)
+	}
+
+	if c.AppealedAt.Valid {
+		err := fmt.Errorf("claim %s was already appealed", c.ID)
+		return api.NewAppError(err, api.ErrorClaimAlreadyAppealed, api.CategoryUser)
+	}
+
+	deadline := c.ReviewDate.Time.AddDate(0, 0, domain.Env.ClaimAppealDays)
+	if time.Now().UTC().After(deadline) {
+		err := fmt.Errorf("the appeal period for claim %s ended on %s", c.ID, deadline.Format(domain.DateFormat))
+		return api.NewAppError(err, api.ErrorClaimAppealExpired, api.CategoryUser)
+	}
+
+	c.Status = transition.To
+	c.StatusChange = ClaimStatusChangeAppealed + user.Name()
+	c.AppealedAt = nulls.NewTime(time.Now().UTC())
+	c.AppealReason = input.AppealReason
+
+	if err := c.Update(ctx); err != nil {
+		return err
+	}
+
+	e := events.Event{
+		Kind:    domain.EventApiClaimAppealed,
+		Message: fmt.Sprintf("Claim Appealed: %s  ID: %s", c.IncidentDescription, c.ID.String()),
+		Payload: events.Payload{domain.EventPayloadID: c.ID},
+	}
+	emitEvent(e)
+
+	return nil
+}
+
+// escalate flags a claim in Review3 as needing approval by a signator with a higher approval limit and notifies
+// those signators. No approval is recorded. Returns an error if the claim was already escalated.
+func (c *Claim) escalate(ctx context.Context) error {
+	user := CurrentUser(ctx)
+	if c.EscalatedAt.Valid {
+		err := fmt.Errorf("payout of %s exceeds the approval limit of user %s", c.TotalPayout, user.ID)
+		return api.NewAppError(err, api.ErrorClaimApprovalLimit, api.CategoryUser)
+	}
+
+	c.EscalatedAt = nulls.NewTime(time.Now().UTC())
+	if err := c.Update(ctx); err != nil {
+		return err
+	}
+
+	e := events.Event{
+		Kind:    domain.EventApiClaimEscalated,
+		Message: fmt.Sprintf("Claim Escalated: %s  ID: %s", c.IncidentDescription, c.ID.String()),
+		Payload: events.Payload{domain.EventPayloadID: c.ID},
+	}
+	emitEvent(e)
+
+	return nil
+}
+
+// checkAppealReviewer returns an error if the claim was appealed and the user is the admin who denied it
+func (c *Claim) checkAppealReviewer(user User) error {
+	if c.AppealedAt.Valid && c.DeniedByID.Valid && c.DeniedByID.UUID == user.ID {
+		err := fmt.Errorf("an appealed claim must be reviewed by someone other than the admin who denied it")
+		return api.NewAppError(err, api.ErrorClaimAppealReviewer, api.CategoryUser)
+	}
+	return nil
+}
+
+// setReviewStatus changes the Claim to one of the review statuses and returns the event for the change
+func (c *Claim) setReviewStatus(status api.ClaimStatus, user User) string {
+	c.Status = status
+
+	switch status {
+	case api.ClaimStatusReview1:
+		c.StatusChange = ClaimStatusChangeReview1
+		return domain.EventApiClaimReview1
+	case api.ClaimStatusReview2:
+		c.StatusChange = ClaimStatusChangeReview2 + user.Name()
+		return domain.EventApiClaimReview2
+	default:
+		c.StatusChange = ClaimStatusChangeReview3 + user.Name()
+		c.EscalatedAt = nulls.Time{}
+		return domain.EventApiClaimReview3
+	}
+}
+
+// checkPayoutOption returns an error if the transition is limited to payout options other than the Claim's.
+// The ClaimItems must already be loaded.
+func (c *Claim) checkPayoutOption(transition ClaimWorkflowTransition) error {
+	if len(c.ClaimItems) == 0 {
+		return nil
+	}
+	payOption := c.ClaimItems[0].PayoutOption
+	if !transition.isPayoutOptionAllowed(payOption) {
+		err := fmt.Errorf("cannot change a claim with payout option %s from status %s to %s",
+			payOption, transition.From, transition.To)
+		return api.NewAppError(err, api.ErrorClaimItemInvalidPayoutOption, api.CategoryUser)
+	}
+	return nil
+}
+
 func (c *Claim) LoadClaimItems(tx *pop.Connection, reload bool) {
 	if len(c.ClaimItems) == 0 || reload {
 		if err := tx.Load(c, "ClaimItems", "ClaimItems.Item"); err != nil {
@@ -610,6 +798,8 @@ func (c *Claim) ConvertToAPI(tx *pop.Connection) api.Claim {
 	c.LoadClaimItems(tx, true)
 	c.LoadClaimFiles(tx, true)
 
+	totalRecovered := c.TotalRecovered(tx)
+
 	return api.Claim{
 		ID:                  c.ID,
 		PolicyID:            c.PolicyID,
@@ -623,7 +813,15 @@ func (c *Claim) ConvertToAPI(tx *pop.Connection) api.Claim {
 		ReviewerID:          convertUUIDToAPI(c.ReviewerID),
 		PaymentDate:         convertTimeToAPI(c.PaymentDate),
 		TotalPayout:         c.TotalPayout,
+		TotalDisbursed:      c.TotalDisbursed(tx),
+		TotalRecovered:      totalRecovered,
+		NetLoss:             c.TotalPayout - totalRecovered,
+		Approvals:           c.approvalCount(tx),
+		ApprovalsRequired:   claimWorkflow.approvalRule(c.TotalPayout).Approvals,
 		StatusReason:        c.StatusReason,
+		AppealedAt:          convertTimeToAPI(c.AppealedAt),
+		AppealReason:        c.AppealReason,
+		EscalatedAt:         convertTimeToAPI(c.EscalatedAt),
 		Items:               c.ClaimItems.ConvertToAPI(tx),
 		Files:               c.ClaimFiles.ConvertToAPI(tx),
 	}
@@ -637,7 +835,26 @@ func (c *Claims) ConvertToAPI(tx *pop.Connection) api.Claims {
 	return claims
 }
 
-func (c *Claims) ByStatus(tx *pop.Connection, statuses []api.ClaimStatus) error {
+var claimListOptions = listOptions{
+	table: "claims",
+	sortColumns: map[string]string{
+		"created_at":       "created_at",
+		"updated_at":       "updated_at",
+		"incident_date":    "incident_date",
+		"reference_number": "reference_number",
+		"status":           "status",
+		"total_payout":     "total_payout",
+	},
+	defaultSort: "created_at",
+	filterColumns: map[string]string{
+		"status":        "status",
+		"incident_type": "incident_type",
+	},
+}
+
+// ByStatus finds the claims, or a page of the claims, with any of the given statuses. If no statuses are given, the
+// claims awaiting review are found.
+func (c *Claims) ByStatus(tx *pop.Connection, statuses []api.ClaimStatus, query api.Query) (api.Meta, error) {
 	if len(statuses) == 0 {
 		statuses = []api.ClaimStatus{
 			api.ClaimStatusReview1,
@@ -646,8 +863,36 @@ func (c *Claims) ByStatus(tx *pop.Connection, statuses []api.ClaimStatus) error
 		}
 	}
 
-	err := tx.Where("status in (?)", statuses).All(c)
-	return appErrorFromDB(err, api.ErrorQueryFailure)
+	return findList(tx.Where("status in (?)", statuses), query, claimListOptions, c)
+}
+
+// ByUser finds the claims, or a page of the claims, on the policies of which the user is a member
+func (c *Claims) ByUser(tx *pop.Connection, user User, query api.Query) (api.Meta, error) {
+	q := tx.Where("claims.policy_id IN (SELECT policy_id FROM policy_users WHERE user_id = ?)", user.ID)
+	return findList(q, query, claimListOptions, c)
+}
+
+// ByPolicy finds the claims, or a page of the claims, on a policy
+func (c *Claims) ByPolicy(tx *pop.Connection, policyID uuid.UUID, query api.Query) (api.Meta, error) {
+	return findList(tx.Where("policy_id = ?", policyID), query, claimListOptions, c)
+}
+
+// EscalatedFor finds the escalated claims in Review3, or a page of them, that the user's approval limit allows them
+// to approve, oldest escalation first by default
+func (c *Claims) EscalatedFor(tx *pop.Connection, user User, query api.Query) (api.Meta, error) {
+	q := tx.Where("status = ? AND escalated_at IS NOT NULL", api.ClaimStatusReview3)
+	if user.ApprovalLimit.Valid {
+		q = q.Where("total_payout <= ?", user.ApprovalLimit.Int)
+	}
+
+	opts := claimListOptions
+	opts.sortColumns = map[string]string{"escalated_at": "escalated_at"}
+	for field, column := range claimListOptions.sortColumns {
+		opts.sortColumns[field] = column
+	}
+	opts.defaultSort = "escalated_at"
+
+	return findList(q, query, opts, c)
 }
 
 func ConvertClaimCreateInput(input api.ClaimCreateInput) Claim {
@@ -780,6 +1025,14 @@ func (c *Claim) Compare(old Claim) []FieldUpdate {
 		})
 	}
 
+	if c.AppealReason != old.AppealReason {
+		updates = append(updates, FieldUpdate{
+			OldValue:  old.AppealReason,
+			NewValue:  c.AppealReason,
+			FieldName: FieldClaimAppealReason,
+		})
+	}
+
 	if c.City != old.City {
 		updates = append(updates, FieldUpdate{
 			OldValue:  old.City,
@@ -849,31 +1102,53 @@ func ClaimsWithRecentStatusChanges(tx *pop.Connection) (api.RecentClaims, error)
 	return claims, nil
 }
 
+// CreateLedgerEntry disburses the remaining balance of the payout of an approved Claim
 func (c *Claim) CreateLedgerEntry(tx *pop.Connection) error {
+	return c.Disburse(tx, 0)
+}
+
+// Disburse creates a claim LedgerEntry for each ClaimItem's share of the given amount, so that a Claim can be paid
+// in installments. The amount is shared in proportion to the undisbursed payout of each ClaimItem. If the amount is
+// zero, the remaining balance of the payout is disbursed.
+func (c *Claim) Disburse(tx *pop.Connection, amount api.Currency) error {
 	if c.Status != api.ClaimStatusApproved {
-		return errors.New("cannot pay out a claim that is not approved")
+		err := fmt.Errorf("cannot pay out a claim with status %s", c.Status)
+		return api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
 	}
 
 	c.LoadClaimItems(tx, false)
-	c.LoadPolicy(tx, false)
-	c.Policy.LoadEntityCode(tx, false)
 
-	for i := range c.ClaimItems {
-		c.ClaimItems[i].LoadItem(tx, false)
-		item := c.ClaimItems[i].Item
-		item.LoadRiskCategory(tx, false)
+	disbursed := c.disbursedByItem(tx, false)
+	remaining := make([]api.Currency, len(c.ClaimItems))
+	var totalRemaining api.Currency
+	for i, claimItem := range c.ClaimItems {
+		remaining[i] = claimItem.PayoutAmount - disbursed[claimItem.ItemID]
+		if remaining[i] < 0 {
+			remaining[i] = 0
+		}
+		totalRemaining += remaining[i]
+	}
 
-		name := item.GetAccountablePersonName(tx)
+	if amount == 0 {
+		amount = totalRemaining
+		if amount == 0 && len(disbursed) > 0 {
+			err := fmt.Errorf("the payout of claim %s has been fully disbursed", c.ID)
+			return api.NewAppError(err, api.ErrorClaimDisbursement, api.CategoryUser)
+		}
+	}
+	if amount < 0 || amount > totalRemaining {
+		err := fmt.Errorf("disbursement of %s exceeds the remaining payout of %s", amount, totalRemaining)
+		return api.NewAppError(err, api.ErrorClaimDisbursement, api.CategoryUser)
+	}
 
-		le := NewLedgerEntry(c.Policy, &item, c)
-		le.Type = LedgerEntryTypeClaim
-		le.Amount = -c.TotalPayout
-		le.FirstName = name.First
-		le.LastName = name.Last
-		le.RiskCategoryName = item.RiskCategory.Name
-		le.RiskCategoryCC = item.RiskCategory.CostCenter
-		le.IncomeAccount = domain.Env.ClaimIncomeAccount
+	shares := shareDisbursement(amount, remaining)
+	for i := range c.ClaimItems {
+		// a claim with no payout still gets an entry for each item, so that it can be marked as paid
+		if shares[i] == 0 && amount > 0 {
+			continue
+		}
 
+		le := c.newClaimLedgerEntry(tx, &c.ClaimItems[i], LedgerEntryTypeClaim, -shares[i])
 		if err := le.Create(tx); err != nil {
 			return err
 		}
@@ -881,6 +1156,118 @@ func (c *Claim) CreateLedgerEntry(tx *pop.Connection) error {
 	return nil
 }
 
+// newClaimLedgerEntry prepares a LedgerEntry of the given type and amount for a ClaimItem of the Claim
+func (c *Claim) newClaimLedgerEntry(tx *pop.Connection, claimItem *ClaimItem, entryType LedgerEntryType,
+	amount api.Currency) LedgerEntry {
+	c.LoadPolicy(tx, false)
+	c.Policy.LoadEntityCode(tx, false)
+
+	claimItem.LoadItem(tx, false)
+	item := claimItem.Item
+	item.LoadRiskCategory(tx, false)
+
+	name := item.GetAccountablePersonName(tx)
+
+	le := NewLedgerEntry(c.Policy, &item, c)
+	le.Type = entryType
+	le.Amount = amount
+	le.FirstName = name.First
+	le.LastName = name.Last
+	le.RiskCategoryName = item.RiskCategory.Name
+	le.RiskCategoryCC = item.RiskCategory.CostCenter
+	le.IncomeAccount = domain.Env.ClaimIncomeAccount
+	return le
+}
+
+// shareDisbursement divides the amount in proportion to the remaining balances using the largest remainder method:
+// each share is rounded down, then the cents left over go to the shares with the largest fractions, so that the
+// shares add up to the amount. No share exceeds its remaining balance.
+func shareDisbursement(amount api.Currency, remaining []api.Currency) []api.Currency {
+	shares := make([]api.Currency, len(remaining))
+	fractions := make([]api.Currency, len(remaining))
+
+	var total api.Currency
+	for _, r := range remaining {
+		if r > 0 {
+			total += r
+		}
+	}
+	if total == 0 || amount <= 0 {
+		return shares
+	}
+
+	leftover := amount
+	for i, r := range remaining {
+		if r <= 0 {
+			continue
+		}
+		shares[i], fractions[i] = amount*r/total, amount*r%total
+		if shares[i] > r {
+			shares[i], fractions[i] = r, 0
+		}
+		leftover -= shares[i]
+	}
+
+	order := make([]int, len(remaining))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return fractions[order[a]] > fractions[order[b]] })
+	for _, i := range order {
+		if leftover == 0 {
+			break
+		}
+		if shares[i] < remaining[i] {
+			shares[i]++
+			leftover--
+		}
+	}
+	return shares
+}
+
+// disbursedByItem returns the total of the claim LedgerEntries for each Item, excluding compensating entries. If
+// enteredOnly is true, only entries that have been entered into the accounting system are included.
+func (c *Claim) disbursedByItem(tx *pop.Connection, enteredOnly bool) map[uuid.UUID]api.Currency {
+	var entries LedgerEntries
+	q := tx.Where("claim_id = ? AND type IN (?, ?) AND reversed_entry_id IS NULL",
+		c.ID, LedgerEntryTypeClaim, LedgerEntryTypeClaimAdjustment)
+	if enteredOnly {
+		q = q.Where("date_entered IS NOT NULL")
+	}
+	if err := q.All(&entries); err != nil {
+		panic("database error finding claim ledger entries, " + err.Error())
+	}
+
+	disbursed := map[uuid.UUID]api.Currency{}
+	for _, e := range entries {
+		disbursed[e.ItemID.UUID] -= e.Amount
+	}
+	return disbursed
+}
+
+// TotalDisbursed returns the total of all disbursements of the payout, including those not yet paid
+func (c *Claim) TotalDisbursed(tx *pop.Connection) api.Currency {
+	var total api.Currency
+	for _, amount := range c.disbursedByItem(tx, false) {
+		total += amount
+	}
+	return total
+}
+
+// isFullyPaid returns true if the disbursements entered into the accounting system add up to the payout of every
+// ClaimItem
+func (c *Claim) isFullyPaid(tx *pop.Connection) bool {
+	c.LoadClaimItems(tx, false)
+
+	paid := c.disbursedByItem(tx, true)
+	for _, claimItem := range c.ClaimItems {
+		if paid[claimItem.ItemID] < claimItem.PayoutAmount {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *Claim) UpdateStatus(ctx context.Context, newStatus api.ClaimStatus) error {
 	if newStatus == c.Status {
 		return nil
@@ -892,6 +1279,12 @@ func (c *Claim) UpdateStatus(ctx context.Context, newStatus api.ClaimStatus) err
 		return appErrorFromDB(err, api.ErrorUpdateFailure)
 	}
 
+	if isClaimStatusInQueue(oldStatus) && !isClaimStatusInQueue(newStatus) {
+		if err := clearQueueAssignment(tx, "claim_id", c.ID); err != nil {
+			return err
+		}
+	}
+
 	history := c.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
 		FieldName: FieldClaimStatus,
 		OldValue:  string(oldStatus),
@@ -930,9 +1323,23 @@ func (c *Claim) SubmittedAt(tx *pop.Connection) time.Time {
 	return histories[0].CreatedAt
 }
 
-func (c *Claim) calculatePayout(ctx context.Context) error {
+// isPayoutFinal returns true if the Claim has been decided, after which its payout is not recalculated
+func (c *Claim) isPayoutFinal() bool {
 	switch c.Status {
 	case api.ClaimStatusPaid, api.ClaimStatusDenied, api.ClaimStatusApproved:
+		return true
+	}
+	return false
+}
+
+// canAdjust returns true if the Claim has been approved, after which its payout can only be changed by a
+// ClaimAdjustment
+func (c *Claim) canAdjust() bool {
+	return c.Status == api.ClaimStatusApproved || c.Status == api.ClaimStatusPaid
+}
+
+func (c *Claim) calculatePayout(ctx context.Context) error {
+	if c.isPayoutFinal() {
 		return nil
 	}
 
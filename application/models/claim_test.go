@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"net/url"
 	"testing"
 	"time"
 
@@ -400,10 +401,13 @@ func (ms *ModelSuite) TestClaim_Approve() {
 	ms.NoError(ms.DB.Destroy(&tempClaim.ClaimItems[0]),
 		"error trying to destroy ClaimItem fixture for test")
 
+	negative := api.Currency(-100)
+
 	tests := []struct {
 		name            string
 		claim           Claim
 		actor           User
+		input           api.ClaimApproveInput
 		wantErrContains string
 		wantErrKey      api.ErrorKey
 		wantErrCat      api.ErrorCategory
@@ -453,6 +457,15 @@ func (ms *ModelSuite) TestClaim_Approve() {
 			wantErrCat:      api.CategoryUser,
 			wantErrContains: "different approver required for final approval",
 		},
+		{
+			name:            "negative disbursement",
+			actor:           signator,
+			claim:           review3Claim,
+			input:           api.ClaimApproveInput{Disbursement: &negative},
+			wantErrKey:      api.ErrorClaimDisbursement,
+			wantErrCat:      api.CategoryUser,
+			wantErrContains: "is negative",
+		},
 		{
 			name:       "from review3 to approved, new user",
 			actor:      signator,
@@ -464,7 +477,7 @@ func (ms *ModelSuite) TestClaim_Approve() {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			ctx := CreateTestContext(tt.actor)
-			got := tt.claim.Approve(ctx)
+			got := tt.claim.Approve(ctx, tt.input)
 
 			if tt.wantErrContains != "" {
 				ms.Error(got, " did not return expected error")
@@ -975,6 +988,10 @@ func (ms *ModelSuite) TestClaim_CreateLedgerEntry() {
 	claim.TotalPayout = 12345
 	ms.NoError(ms.DB.Update(&claim), "unable to update claim test fixture")
 
+	claim.LoadClaimItems(ms.DB, false)
+	claim.ClaimItems[0].PayoutAmount = 12345
+	ms.NoError(ms.DB.Update(&claim.ClaimItems[0]), "unable to update claim item test fixture")
+
 	ms.NoError(claim.CreateLedgerEntry(ms.DB), "claim is approved now, it shouldn't be a problem")
 
 	var le LedgerEntry
@@ -989,6 +1006,129 @@ func (ms *ModelSuite) TestClaim_CreateLedgerEntry() {
 	ms.Equal(user.LastName, le.LastName, "LastName is incorrect")
 }
 
+func (ms *ModelSuite) TestClaim_Disburse() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2, ClaimsPerPolicy: 1, ClaimItemsPerClaim: 2})
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+
+	claim := f.Claims[0]
+	claim.LoadClaimItems(ms.DB, false)
+	claim.ClaimItems[0].PayoutAmount = 30000
+	ms.NoError(ms.DB.Update(&claim.ClaimItems[0]))
+	claim.ClaimItems[1].PayoutAmount = 10000
+	ms.NoError(ms.DB.Update(&claim.ClaimItems[1]))
+
+	err := claim.Disburse(ms.DB, 1000)
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimStatus, Category: api.CategoryUser}, err)
+
+	claim = UpdateClaimStatus(ms.DB, claim, api.ClaimStatusApproved, "")
+	claim.LoadClaimItems(ms.DB, true)
+
+	itemTotal := func(claimItem ClaimItem) api.Currency {
+		var entries LedgerEntries
+		ms.NoError(ms.DB.Where("claim_id = ? AND item_id = ?", claim.ID, claimItem.ItemID).All(&entries))
+		var total api.Currency
+		for _, e := range entries {
+			total -= e.Amount
+		}
+		return total
+	}
+
+	// an advance is shared in proportion to the item payouts
+	ms.NoError(claim.Disburse(ms.DB, 10000))
+	ms.Equal(api.Currency(7500), itemTotal(claim.ClaimItems[0]), "incorrect share of advance for first item")
+	ms.Equal(api.Currency(2500), itemTotal(claim.ClaimItems[1]), "incorrect share of advance for second item")
+	ms.Equal(api.Currency(10000), claim.TotalDisbursed(ms.DB), "incorrect TotalDisbursed after advance")
+
+	var advance LedgerEntries
+	ms.NoError(ms.DB.Where("claim_id = ?", claim.ID).All(&advance))
+
+	err = claim.Disburse(ms.DB, 30001)
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimDisbursement, Category: api.CategoryUser}, err)
+
+	// the final settlement pays the remaining balance
+	ms.NoError(claim.Disburse(ms.DB, 0))
+	ms.Equal(api.Currency(30000), itemTotal(claim.ClaimItems[0]), "incorrect total for first item")
+	ms.Equal(api.Currency(10000), itemTotal(claim.ClaimItems[1]), "incorrect total for second item")
+	ms.Equal(api.Currency(40000), claim.TotalDisbursed(ms.DB), "incorrect TotalDisbursed after settlement")
+
+	err = claim.Disburse(ms.DB, 0)
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimDisbursement, Category: api.CategoryUser}, err)
+
+	// the claim is paid only when all disbursements are entered
+	ms.NoError(advance.Reconcile(ctx))
+	ms.NoError(ms.DB.Find(&claim, claim.ID))
+	ms.Equal(api.ClaimStatusApproved, claim.Status, "claim should not be paid after the advance")
+
+	var all LedgerEntries
+	ms.NoError(ms.DB.Where("claim_id = ? AND date_entered IS NULL", claim.ID).All(&all))
+	ms.NoError(all.Reconcile(ctx))
+	ms.NoError(ms.DB.Find(&claim, claim.ID))
+	ms.Equal(api.ClaimStatusPaid, claim.Status, "claim should be paid after the settlement")
+}
+
+func (ms *ModelSuite) Test_shareDisbursement() {
+	tests := []struct {
+		name      string
+		amount    api.Currency
+		remaining []api.Currency
+		want      []api.Currency
+	}{
+		{
+			name:      "proportional",
+			amount:    1000,
+			remaining: []api.Currency{3000, 1000},
+			want:      []api.Currency{750, 250},
+		},
+		{
+			name:      "rounding",
+			amount:    100,
+			remaining: []api.Currency{1000, 1000, 1000},
+			want:      []api.Currency{34, 33, 33},
+		},
+		{
+			name:      "leftover cents go to the largest fractions",
+			amount:    100,
+			remaining: []api.Currency{100, 200, 400},
+			want:      []api.Currency{14, 29, 57},
+		},
+		{
+			name:      "no share is negative",
+			amount:    2,
+			remaining: []api.Currency{1, 1, 1, 1},
+			want:      []api.Currency{1, 1, 0, 0},
+		},
+		{
+			name:      "shares do not exceed the remaining balances",
+			amount:    4,
+			remaining: []api.Currency{1, 1, 1, 1},
+			want:      []api.Currency{1, 1, 1, 1},
+		},
+		{
+			name:      "amount exceeds the total remaining",
+			amount:    500,
+			remaining: []api.Currency{100, 300},
+			want:      []api.Currency{100, 300},
+		},
+		{
+			name:      "item fully disbursed",
+			amount:    500,
+			remaining: []api.Currency{500, 0},
+			want:      []api.Currency{500, 0},
+		},
+		{
+			name:      "nothing remaining",
+			amount:    0,
+			remaining: []api.Currency{0, 0},
+			want:      []api.Currency{0, 0},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			ms.Equal(tt.want, shareDisbursement(tt.amount, tt.remaining))
+		})
+	}
+}
+
 func (ms *ModelSuite) TestClaims_ByStatus() {
 	f := CreateItemFixtures(ms.DB, FixturesConfig{
 		NumberOfPolicies: 9,
@@ -1028,7 +1168,7 @@ func (ms *ModelSuite) TestClaims_ByStatus() {
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
 			var claims Claims
-			err := claims.ByStatus(ms.DB, tt.statuses)
+			_, err := claims.ByStatus(ms.DB, tt.statuses, api.NewQuery(url.Values{}))
 			ms.NoError(err)
 
 			gotIDs := make([]uuid.UUID, len(claims))
@@ -1090,3 +1230,208 @@ func (ms *ModelSuite) TestClaim_Create() {
 		})
 	}
 }
+
+func (ms *ModelSuite) TestClaim_Appeal() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 4, ClaimItemsPerClaim: 1})
+	member := f.Policies[0].Members[0]
+	ctx := CreateTestContext(member)
+
+	admins := CreateAdminUsers(ms.DB)
+	steward := admins[AppRoleSteward]
+
+	denied := func(claim Claim, deniedAt time.Time) Claim {
+		claim = UpdateClaimStatus(ms.DB, claim, api.ClaimStatusDenied, "no police report")
+		claim.ReviewDate = nulls.NewTime(deniedAt)
+		claim.ReviewerID = nulls.NewUUID(steward.ID)
+		claim.DeniedByID = nulls.NewUUID(steward.ID)
+		ms.NoError(ms.DB.Update(&claim))
+		return claim
+	}
+
+	now := time.Now().UTC()
+	review1Claim := UpdateClaimStatus(ms.DB, f.Claims[0], api.ClaimStatusReview1, "")
+	expiredClaim := denied(f.Claims[1], now.AddDate(0, 0, -domain.Env.ClaimAppealDays-1))
+	appealedClaim := denied(f.Claims[2], now.AddDate(0, 0, -1))
+	appealedClaim.AppealedAt = nulls.NewTime(now)
+	appealedClaim.AppealReason = "new evidence"
+	ms.NoError(ms.DB.Update(&appealedClaim))
+	deniedClaim := denied(f.Claims[3], now.AddDate(0, 0, -1))
+
+	input := api.ClaimAppealInput{AppealReason: "I found the police report"}
+
+	tests := []struct {
+		name     string
+		claim    Claim
+		input    api.ClaimAppealInput
+		appError *api.AppError
+	}{
+		{
+			name:     "not denied",
+			claim:    review1Claim,
+			input:    input,
+			appError: &api.AppError{Key: api.ErrorClaimStatus, Category: api.CategoryUser},
+		},
+		{
+			name:     "appeal period ended",
+			claim:    expiredClaim,
+			input:    input,
+			appError: &api.AppError{Key: api.ErrorClaimAppealExpired, Category: api.CategoryUser},
+		},
+		{
+			name:     "already appealed",
+			claim:    appealedClaim,
+			input:    input,
+			appError: &api.AppError{Key: api.ErrorClaimAlreadyAppealed, Category: api.CategoryUser},
+		},
+		{
+			name:     "no reason",
+			claim:    deniedClaim,
+			input:    api.ClaimAppealInput{AppealReason: " "},
+			appError: &api.AppError{Key: api.ErrorValidation, Category: api.CategoryUser},
+		},
+		{
+			name:  "good",
+			claim: deniedClaim,
+			input: input,
+		},
+	}
+	// only a member of the claim's policy may appeal
+	claim := deniedClaim
+	err := claim.Appeal(CreateTestContext(steward), input)
+	ms.EqualAppError(api.AppError{Key: api.ErrorNotAuthorized, Category: api.CategoryForbidden}, err)
+
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			claim := tt.claim
+			err := claim.Appeal(ctx, tt.input)
+			if tt.appError != nil {
+				ms.EqualAppError(*tt.appError, err)
+				return
+			}
+			ms.NoError(err)
+
+			ms.Equal(api.ClaimStatusReview1, claim.Status, "incorrect Status")
+			ms.True(claim.AppealedAt.Valid, "AppealedAt was not set")
+			ms.Equal(tt.input.AppealReason, claim.AppealReason, "incorrect AppealReason")
+			ms.Contains(claim.StatusChange, ClaimStatusChangeAppealed, "incorrect StatusChange")
+		})
+	}
+
+	// the admin who denied the claim may not review the appeal
+	ms.NoError(claim.FindByID(ms.DB, deniedClaim.ID))
+	err = claim.Deny(CreateTestContext(steward), "still no police report")
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimAppealReviewer, Category: api.CategoryUser}, err)
+
+	ms.NoError(claim.Deny(CreateTestContext(admins[AppRoleSignator]), "still no police report"))
+	ms.Equal(api.ClaimStatusDenied, claim.Status, "claim was not denied by a different admin")
+}
+
+func (ms *ModelSuite) TestClaim_Withdraw() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 4, ClaimItemsPerClaim: 1})
+	member := f.Policies[0].Members[0]
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+
+	draftClaim := f.Claims[0]
+	receiptClaim := UpdateClaimStatus(ms.DB, f.Claims[1], api.ClaimStatusReceipt, "")
+	review3Claim := UpdateClaimStatus(ms.DB, f.Claims[2], api.ClaimStatusReview3, "")
+
+	emptyDraftClaim := f.Claims[3]
+	emptyDraftClaim.LoadClaimItems(ms.DB, false)
+	ms.NoError(ms.DB.Destroy(&emptyDraftClaim.ClaimItems[0]),
+		"error trying to destroy ClaimItem fixture for test")
+	emptyDraftClaim.ClaimItems = nil
+
+	tests := []struct {
+		name     string
+		actor    User
+		claim    Claim
+		appError *api.AppError
+	}{
+		{
+			name:     "steward",
+			actor:    steward,
+			claim:    receiptClaim,
+			appError: &api.AppError{Key: api.ErrorNotAuthorized, Category: api.CategoryForbidden},
+		},
+		{
+			name:     "too far along in review",
+			actor:    member,
+			claim:    review3Claim,
+			appError: &api.AppError{Key: api.ErrorClaimStatus, Category: api.CategoryUser},
+		},
+		{
+			name:  "from draft",
+			actor: member,
+			claim: draftClaim,
+		},
+		{
+			name:  "from receipt",
+			actor: member,
+			claim: receiptClaim,
+		},
+		{
+			name:  "draft with no items",
+			actor: member,
+			claim: emptyDraftClaim,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			claim := tt.claim
+			err := claim.Withdraw(CreateTestContext(tt.actor), "filed by mistake")
+			if tt.appError != nil {
+				ms.EqualAppError(*tt.appError, err)
+				return
+			}
+			ms.NoError(err)
+
+			var got Claim
+			ms.NoError(got.FindByID(ms.DB, claim.ID))
+			ms.Equal(api.ClaimStatusWithdrawn, got.Status, "incorrect Status")
+			ms.Equal(ClaimStatusChangeWithdrawn+member.Name(), got.StatusChange, "incorrect StatusChange")
+			ms.Equal("filed by mistake", got.StatusReason, "incorrect StatusReason")
+
+			var history ClaimHistory
+			ms.NoError(ms.DB.Where("claim_id = ? AND field_name = ? AND new_value = ?",
+				claim.ID, FieldClaimStatus, api.ClaimStatusWithdrawn).First(&history), "claim history was not created")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestClaim_ApproveOverLimit() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	admins := CreateAdminUsers(ms.DB)
+	limited := admins[AppRoleSignator]
+	limited2 := CreateAdminUsers(ms.DB)[AppRoleSignator]
+	unlimited := CreateAdminUsers(ms.DB)[AppRoleSignator]
+
+	limit := api.Currency(100)
+	ms.NoError(limited.SetApprovalLimit(ms.DB, &limit))
+	ms.NoError(limited2.SetApprovalLimit(ms.DB, &limit))
+
+	claim := f.Claims[0]
+	claim.ReviewerID = nulls.NewUUID(admins[AppRoleSteward].ID)
+	claim = UpdateClaimStatus(ms.DB, claim, api.ClaimStatusReview3, "")
+	claim = UpdateClaimItems(ms.DB, claim, UpdateClaimItemsParams{PayoutOption: api.PayoutOptionFMV, FMV: 2000})
+	ms.NoError(claim.calculatePayout(CreateTestContext(limited)))
+	ms.Greater(int(claim.TotalPayout), int(limit), "claim payout must be over the limit for this test")
+
+	ms.NoError(claim.Approve(CreateTestContext(limited), api.ClaimApproveInput{}))
+	ms.Equal(api.ClaimStatusReview3, claim.Status, "claim over the limit should not be approved")
+	ms.True(claim.EscalatedAt.Valid, "claim over the limit was not escalated")
+	ms.Equal(0, claim.approvalCount(ms.DB), "approval over the limit should not be recorded")
+
+	err := claim.Approve(CreateTestContext(limited2), api.ClaimApproveInput{})
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimApprovalLimit, Category: api.CategoryUser}, err)
+
+	var limitedQueue, unlimitedQueue Claims
+	_, err = limitedQueue.EscalatedFor(ms.DB, limited, api.NewQuery(url.Values{}))
+	ms.NoError(err)
+	ms.Equal(0, len(limitedQueue), "claim should not be in the queue of a signator who cannot approve it")
+	_, err = unlimitedQueue.EscalatedFor(ms.DB, unlimited, api.NewQuery(url.Values{}))
+	ms.NoError(err)
+	ms.Equal(1, len(unlimitedQueue), "claim should be in the queue of a signator who can approve it")
+
+	ms.NoError(claim.Approve(CreateTestContext(unlimited), api.ClaimApproveInput{}))
+	ms.Equal(api.ClaimStatusApproved, claim.Status, "claim was not approved by a signator within the limit")
+}
@@ -0,0 +1,209 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+type ClaimAdjustments []ClaimAdjustment
+
+// ClaimAdjustment is a change to the payout of a ClaimItem after its Claim was approved. The change takes effect
+// only when a second admin approves it.
+type ClaimAdjustment struct {
+	ID              uuid.UUID    `db:"id"`
+	ClaimID         uuid.UUID    `db:"claim_id" validate:"required"`
+	ClaimItemID     uuid.UUID    `db:"claim_item_id" validate:"required"`
+	OldPayoutAmount api.Currency `db:"old_payout_amount" validate:"min=0"`
+	NewPayoutAmount api.Currency `db:"new_payout_amount" validate:"min=0"`
+	Reason          string       `db:"reason" validate:"required"`
+	RequestedByID   uuid.UUID    `db:"requested_by_id" validate:"required"`
+	ApprovedByID    nulls.UUID   `db:"approved_by_id"`
+	ApprovedAt      nulls.Time   `db:"approved_at"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (a *ClaimAdjustment) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(a), nil
+}
+
+func (a *ClaimAdjustment) Create(tx *pop.Connection) error {
+	return create(tx, a)
+}
+
+func (a *ClaimAdjustment) Update(tx *pop.Connection) error {
+	return update(tx, a)
+}
+
+func (a *ClaimAdjustment) GetID() uuid.UUID {
+	return a.ID
+}
+
+func (a *ClaimAdjustment) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return find(tx, a, id)
+}
+
+// IsActorAllowedTo ensure the actor is an admin, since only admins may adjust a payout
+func (a *ClaimAdjustment) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, r *http.Request) bool {
+	return actor.IsAdmin()
+}
+
+// NewClaimAdjustment requests a change to the payout of the ClaimItem. The Claim must have been approved and the
+// ClaimItem may not already have an adjustment waiting for approval.
+func NewClaimAdjustment(ctx context.Context, claimItem ClaimItem, input api.ClaimAdjustmentInput) (ClaimAdjustment, error) {
+	tx := Tx(ctx)
+
+	claimItem.LoadClaim(tx, false)
+	if !claimItem.Claim.canAdjust() {
+		err := fmt.Errorf("cannot adjust the payout of a claim with status %s", claimItem.Claim.Status)
+		return ClaimAdjustment{}, api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
+	}
+
+	if input.PayoutAmount < 0 || input.PayoutAmount == claimItem.PayoutAmount {
+		err := fmt.Errorf("invalid payout adjustment from %s to %s", claimItem.PayoutAmount, input.PayoutAmount)
+		return ClaimAdjustment{}, api.NewAppError(err, api.ErrorClaimAdjustmentAmount, api.CategoryUser)
+	}
+
+	pending, err := tx.Where("claim_item_id = ? AND approved_at IS NULL", claimItem.ID).Exists(&ClaimAdjustment{})
+	if err != nil {
+		return ClaimAdjustment{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if pending {
+		err := fmt.Errorf("claim item %s already has an adjustment waiting for approval", claimItem.ID)
+		return ClaimAdjustment{}, api.NewAppError(err, api.ErrorClaimAdjustmentPending, api.CategoryUser)
+	}
+
+	a := ClaimAdjustment{
+		ClaimID:         claimItem.ClaimID,
+		ClaimItemID:     claimItem.ID,
+		OldPayoutAmount: claimItem.PayoutAmount,
+		NewPayoutAmount: input.PayoutAmount,
+		Reason:          input.Reason,
+		RequestedByID:   CurrentUser(ctx).ID,
+	}
+	if err := a.Create(tx); err != nil {
+		return ClaimAdjustment{}, err
+	}
+	return a, nil
+}
+
+// Approve applies the adjustment to the payout of the ClaimItem and the Claim. The approver must not be the admin
+// who requested the adjustment. If the new payout differs from what has been disbursed on a Paid Claim, or is less
+// than what has been disbursed on an Approved Claim, a ClaimAdjustment LedgerEntry is created for the difference.
+// A Paid Claim that is no longer fully paid is returned to Approved.
+func (a *ClaimAdjustment) Approve(ctx context.Context) error {
+	if a.ApprovedAt.Valid {
+		err := fmt.Errorf("claim adjustment %s was already approved", a.ID)
+		return api.NewAppError(err, api.ErrorClaimAdjustmentAlreadyApproved, api.CategoryUser)
+	}
+
+	user := CurrentUser(ctx)
+	if user.ID == a.RequestedByID {
+		err := fmt.Errorf("claim adjustment %s cannot be approved by the user who requested it", a.ID)
+		return api.NewAppError(err, api.ErrorClaimAdjustmentInvalidApprover, api.CategoryUser)
+	}
+
+	tx := Tx(ctx)
+
+	var claim Claim
+	if err := claim.FindByID(tx, a.ClaimID); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if !claim.canAdjust() {
+		err := fmt.Errorf("cannot adjust the payout of a claim with status %s", claim.Status)
+		return api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
+	}
+
+	var claimItem ClaimItem
+	if err := claimItem.FindByID(tx, a.ClaimItemID); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if claimItem.PayoutAmount != a.OldPayoutAmount {
+		err := fmt.Errorf("payout of claim item %s changed since the adjustment was requested", claimItem.ID)
+		return api.NewAppError(err, api.ErrorClaimAdjustmentAmount, api.CategoryUser)
+	}
+
+	claimItem.PayoutAmount = a.NewPayoutAmount
+	if _, err := claimItem.getUpdates(ctx); err != nil {
+		return err
+	}
+	if err := update(tx, &claimItem); err != nil {
+		return err
+	}
+
+	difference := claim.disbursedByItem(tx, false)[claimItem.ItemID] - a.NewPayoutAmount
+	if difference > 0 || (difference < 0 && claim.Status == api.ClaimStatusPaid) {
+		le := claim.newClaimLedgerEntry(tx, &claimItem, LedgerEntryTypeClaimAdjustment, difference)
+		if err := le.Create(tx); err != nil {
+			return err
+		}
+	}
+
+	claim.LoadClaimItems(tx, true)
+	claim.TotalPayout += a.NewPayoutAmount - a.OldPayoutAmount
+	if claim.Status == api.ClaimStatusPaid && !claim.isFullyPaid(tx) {
+		claim.Status = api.ClaimStatusApproved
+		claim.StatusChange = ClaimStatusChangeAdjusted + user.Name()
+	}
+	if err := claim.Update(ctx); err != nil {
+		return err
+	}
+
+	a.ApprovedAt = nulls.NewTime(time.Now().UTC())
+	a.ApprovedByID = nulls.NewUUID(user.ID)
+	return a.Update(tx)
+}
+
+// FindByClaim finds all ClaimAdjustments for the given Claim, oldest first
+func (a *ClaimAdjustments) FindByClaim(tx *pop.Connection, claimID uuid.UUID) error {
+	err := tx.Where("claim_id = ?", claimID).Order("created_at asc").All(a)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// totalAdjustment returns the total change made to the PayoutAmount by approved ClaimAdjustments
+func (c *ClaimItem) totalAdjustment(tx *pop.Connection) api.Currency {
+	var adjustments ClaimAdjustments
+	if err := tx.Where("claim_item_id = ? AND approved_at IS NOT NULL", c.ID).All(&adjustments); err != nil {
+		panic("database error finding claim adjustments, " + err.Error())
+	}
+
+	var total api.Currency
+	for _, a := range adjustments {
+		total += a.NewPayoutAmount - a.OldPayoutAmount
+	}
+	return total
+}
+
+func (a *ClaimAdjustment) ConvertToAPI() api.ClaimAdjustment {
+	return api.ClaimAdjustment{
+		ID:              a.ID,
+		ClaimID:         a.ClaimID,
+		ClaimItemID:     a.ClaimItemID,
+		OldPayoutAmount: a.OldPayoutAmount,
+		NewPayoutAmount: a.NewPayoutAmount,
+		Reason:          a.Reason,
+		RequestedByID:   a.RequestedByID,
+		ApprovedByID:    convertUUIDToAPI(a.ApprovedByID),
+		ApprovedAt:      convertTimeToAPI(a.ApprovedAt),
+		CreatedAt:       a.CreatedAt,
+	}
+}
+
+func (a *ClaimAdjustments) ConvertToAPI() api.ClaimAdjustments {
+	adjustments := make(api.ClaimAdjustments, len(*a))
+	for i, aa := range *a {
+		adjustments[i] = aa.ConvertToAPI()
+	}
+	return adjustments
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestNewClaimAdjustment() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 2, ClaimItemsPerClaim: 1})
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+	ctx := CreateTestContext(steward)
+
+	approved := UpdateClaimStatus(ms.DB, f.Claims[0], api.ClaimStatusApproved, "")
+	approved.LoadClaimItems(ms.DB, false)
+	approvedItem := approved.ClaimItems[0]
+
+	review := UpdateClaimStatus(ms.DB, f.Claims[1], api.ClaimStatusReview3, "")
+	review.LoadClaimItems(ms.DB, false)
+	reviewItem := review.ClaimItems[0]
+
+	tests := []struct {
+		name      string
+		claimItem ClaimItem
+		input     api.ClaimAdjustmentInput
+		appError  *api.AppError
+	}{
+		{
+			name:      "claim not approved",
+			claimItem: reviewItem,
+			input:     api.ClaimAdjustmentInput{PayoutAmount: 1000, Reason: "receipt"},
+			appError:  &api.AppError{Key: api.ErrorClaimStatus, Category: api.CategoryUser},
+		},
+		{
+			name:      "same amount",
+			claimItem: approvedItem,
+			input:     api.ClaimAdjustmentInput{PayoutAmount: approvedItem.PayoutAmount, Reason: "receipt"},
+			appError:  &api.AppError{Key: api.ErrorClaimAdjustmentAmount, Category: api.CategoryUser},
+		},
+		{
+			name:      "negative amount",
+			claimItem: approvedItem,
+			input:     api.ClaimAdjustmentInput{PayoutAmount: -1, Reason: "receipt"},
+			appError:  &api.AppError{Key: api.ErrorClaimAdjustmentAmount, Category: api.CategoryUser},
+		},
+		{
+			name:      "missing reason",
+			claimItem: approvedItem,
+			input:     api.ClaimAdjustmentInput{PayoutAmount: 1000},
+			appError:  &api.AppError{Key: api.ErrorValidation, Category: api.CategoryUser},
+		},
+		{
+			name:      "good",
+			claimItem: approvedItem,
+			input:     api.ClaimAdjustmentInput{PayoutAmount: 1000, Reason: "receipt"},
+		},
+		{
+			name:      "already pending",
+			claimItem: approvedItem,
+			input:     api.ClaimAdjustmentInput{PayoutAmount: 2000, Reason: "another receipt"},
+			appError:  &api.AppError{Key: api.ErrorClaimAdjustmentPending, Category: api.CategoryUser},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := NewClaimAdjustment(ctx, tt.claimItem, tt.input)
+			if tt.appError != nil {
+				ms.EqualAppError(*tt.appError, err)
+				return
+			}
+			ms.NoError(err)
+
+			ms.Equal(tt.claimItem.ClaimID, got.ClaimID, "incorrect ClaimID")
+			ms.Equal(tt.claimItem.PayoutAmount, got.OldPayoutAmount, "incorrect OldPayoutAmount")
+			ms.Equal(tt.input.PayoutAmount, got.NewPayoutAmount, "incorrect NewPayoutAmount")
+			ms.Equal(tt.input.Reason, got.Reason, "incorrect Reason")
+			ms.Equal(steward.ID, got.RequestedByID, "incorrect RequestedByID")
+			ms.False(got.ApprovedAt.Valid, "adjustment should not be approved")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestClaimAdjustment_Approve() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	admins := CreateAdminUsers(ms.DB)
+	stewardCtx := CreateTestContext(admins[AppRoleSteward])
+	signatorCtx := CreateTestContext(admins[AppRoleSignator])
+
+	claim := UpdateClaimStatus(ms.DB, f.Claims[0], api.ClaimStatusApproved, "")
+	ms.NoError(claim.Disburse(ms.DB, 0))
+
+	var entries LedgerEntries
+	ms.NoError(ms.DB.Where("claim_id = ?", claim.ID).All(&entries))
+	ms.NoError(entries.Reconcile(stewardCtx))
+	ms.NoError(claim.FindByID(ms.DB, claim.ID))
+	ms.Equal(api.ClaimStatusPaid, claim.Status, "claim was not paid in test setup")
+
+	claim.LoadClaimItems(ms.DB, true)
+	claimItem := claim.ClaimItems[0]
+	oldPayout := claimItem.PayoutAmount
+	newPayout := oldPayout + 1500
+
+	adjustment, err := NewClaimAdjustment(stewardCtx, claimItem, api.ClaimAdjustmentInput{
+		PayoutAmount: newPayout,
+		Reason:       "final invoice was higher than the estimate",
+	})
+	ms.NoError(err)
+
+	err = adjustment.Approve(stewardCtx)
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimAdjustmentInvalidApprover, Category: api.CategoryUser}, err)
+
+	ms.NoError(adjustment.Approve(signatorCtx))
+	ms.True(adjustment.ApprovedAt.Valid, "ApprovedAt was not set")
+	ms.Equal(admins[AppRoleSignator].ID, adjustment.ApprovedByID.UUID, "incorrect ApprovedByID")
+
+	var updatedItem ClaimItem
+	ms.NoError(updatedItem.FindByID(ms.DB, claimItem.ID))
+	ms.Equal(newPayout, updatedItem.PayoutAmount, "ClaimItem PayoutAmount was not adjusted")
+	ms.Equal(api.Currency(1500), updatedItem.explainPayout(ms.DB).adjustment, "incorrect adjustment in payout breakdown")
+
+	var history ClaimHistory
+	ms.NoError(ms.DB.Where("claim_item_id = ? AND field_name = ?", claimItem.ID, FieldClaimItemPayoutAmount).
+		First(&history), "claim item history was not created")
+	ms.Equal(newPayout.String(), history.NewValue, "incorrect history NewValue")
+
+	var updatedClaim Claim
+	ms.NoError(updatedClaim.FindByID(ms.DB, claim.ID))
+	ms.Equal(claim.TotalPayout+1500, updatedClaim.TotalPayout, "Claim TotalPayout was not adjusted")
+	ms.Equal(api.ClaimStatusApproved, updatedClaim.Status, "claim with an unpaid adjustment should not be Paid")
+
+	var entry LedgerEntry
+	ms.NoError(ms.DB.Where("claim_id = ? AND type = ?", claim.ID, LedgerEntryTypeClaimAdjustment).First(&entry),
+		"adjustment ledger entry not found")
+	ms.Equal(api.Currency(-1500), entry.Amount, "incorrect adjustment ledger entry Amount")
+	ms.Equal(claimItem.ItemID, entry.ItemID.UUID, "incorrect adjustment ledger entry ItemID")
+
+	adjustmentEntries := LedgerEntries{entry}
+	ms.NoError(adjustmentEntries.Reconcile(stewardCtx))
+	ms.NoError(updatedClaim.FindByID(ms.DB, claim.ID))
+	ms.Equal(api.ClaimStatusPaid, updatedClaim.Status, "claim should be Paid after the adjustment is entered")
+
+	err = adjustment.Approve(signatorCtx)
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimAdjustmentAlreadyApproved, Category: api.CategoryUser}, err)
+}
@@ -0,0 +1,138 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gobuffalo/events"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+type ClaimApprovals []ClaimApproval
+
+// ClaimApproval is a final approval given to a Claim in Review3. A Claim is approved once it has the number of
+// ClaimApprovals required by the claim workflow.
+type ClaimApproval struct {
+	ID      uuid.UUID `db:"id"`
+	ClaimID uuid.UUID `db:"claim_id" validate:"required"`
+	UserID  uuid.UUID `db:"user_id" validate:"required"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (a *ClaimApproval) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(a), nil
+}
+
+func (a *ClaimApproval) Create(tx *pop.Connection) error {
+	return create(tx, a)
+}
+
+// addApproval records a final approval of the Claim by the current user. The user must have one of the roles
+// allowed by the approval rule for the Claim's payout and may not approve the Claim more than once. Returns true if
+// the Claim now has all the approvals it needs.
+func (c *Claim) addApproval(ctx context.Context) (bool, error) {
+	tx := Tx(ctx)
+	user := CurrentUser(ctx)
+	rule := claimWorkflow.approvalRule(c.TotalPayout)
+
+	if !isRoleInList(user.AppRole, rule.Roles) {
+		err := fmt.Errorf("a user with role %s may not give final approval to a claim with a payout of %s",
+			user.AppRole, c.TotalPayout)
+		return false, api.NewAppError(err, api.ErrorClaimInvalidApprover, api.CategoryUser)
+	}
+
+	exists, err := tx.Where("claim_id = ? AND user_id = ?", c.ID, user.ID).Exists(&ClaimApproval{})
+	if err != nil {
+		return false, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if exists {
+		err := fmt.Errorf("user %s already approved claim %s", user.ID, c.ID)
+		return false, api.NewAppError(err, api.ErrorClaimDuplicateApproval, api.CategoryUser)
+	}
+
+	approval := ClaimApproval{ClaimID: c.ID, UserID: user.ID}
+	if err := approval.Create(tx); err != nil {
+		return false, err
+	}
+
+	return c.approvalCount(tx) >= rule.Approvals, nil
+}
+
+// approvalAdded records a final approval that did not complete the Claim's approvals in the Claim's history and
+// notifies the users who may give the remaining approvals
+func (c *Claim) approvalAdded(ctx context.Context) error {
+	tx := Tx(ctx)
+
+	n := c.approvalCount(tx)
+	history := c.NewHistory(ctx, api.HistoryActionUpdate, FieldUpdate{
+		FieldName: FieldClaimApprovals,
+		OldValue:  strconv.Itoa(n - 1),
+		NewValue:  strconv.Itoa(n),
+	})
+	if err := history.Create(tx); err != nil {
+		return appErrorFromDB(err, api.ErrorCreateFailure)
+	}
+
+	e := events.Event{
+		Kind:    domain.EventApiClaimApprovalAdded,
+		Message: fmt.Sprintf("Claim Approval Added: %s  ID: %s", c.IncidentDescription, c.ID.String()),
+		Payload: events.Payload{domain.EventPayloadID: c.ID},
+	}
+	emitEvent(e)
+
+	return nil
+}
+
+// FindPendingApprovers finds the users who may give one of the final approvals the Claim still needs: those with a
+// role allowed by the approval rule for its payout and an approval limit that covers it, who have not already
+// approved it. The reviewer who submitted it for final approval is excluded.
+func (c *Claim) FindPendingApprovers(tx *pop.Connection) Users {
+	rule := claimWorkflow.approvalRule(c.TotalPayout)
+	roles := make([]interface{}, len(rule.Roles))
+	for i, r := range rule.Roles {
+		roles[i] = r
+	}
+
+	var users Users
+	err := tx.Where("app_role IN (?)", roles...).
+		Where("(approval_limit IS NULL OR approval_limit >= ?)", c.TotalPayout).
+		Where("id NOT IN (SELECT user_id FROM claim_approvals WHERE claim_id = ?)", c.ID).
+		Where("id <> ?", c.ReviewerID.UUID).
+		All(&users)
+	if err != nil {
+		panic("error finding pending approvers for claim " + err.Error())
+	}
+	return users
+}
+
+// approvalCount returns the number of final approvals the Claim has received since it entered Review3
+func (c *Claim) approvalCount(tx *pop.Connection) int {
+	n, err := tx.Where("claim_id = ?", c.ID).Count(&ClaimApproval{})
+	if err != nil {
+		panic("database error counting claim approvals, " + err.Error())
+	}
+	return n
+}
+
+// clearApprovals removes the final approvals given to the Claim, so that it needs a full set of approvals each
+// time it enters Review3
+func (c *Claim) clearApprovals(tx *pop.Connection) error {
+	var approvals ClaimApprovals
+	if err := tx.Where("claim_id = ?", c.ID).All(&approvals); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if len(approvals) == 0 {
+		return nil
+	}
+	return appErrorFromDB(tx.Destroy(&approvals), api.ErrorQueryFailure)
+}
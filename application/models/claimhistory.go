@@ -65,3 +65,44 @@ ORDER BY created_at DESC
 	}
 	return nil
 }
+
+var claimHistoryListOptions = listOptions{
+	table:         "claim_histories",
+	sortColumns:   map[string]string{"created_at": "created_at", "field_name": "field_name"},
+	defaultSort:   "created_at",
+	defaultDesc:   true,
+	filterColumns: map[string]string{"field_name": "field_name", "action": "action"},
+}
+
+// FindByClaim finds the ClaimHistories, or a page of the ClaimHistories, of a Claim, newest first by default
+func (ch *ClaimHistories) FindByClaim(tx *pop.Connection, claimID uuid.UUID, query api.Query) (api.Meta, error) {
+	return findList(tx.Where("claim_id = ?", claimID), query, claimHistoryListOptions, ch)
+}
+
+func (ch *ClaimHistories) ConvertToAPI(tx *pop.Connection) api.ClaimHistories {
+	userIDs := make([]uuid.UUID, len(*ch))
+	for i, c := range *ch {
+		userIDs[i] = c.UserID
+	}
+	users := historyUsers(tx, userIDs)
+
+	histories := make(api.ClaimHistories, len(*ch))
+	for i, c := range *ch {
+		histories[i] = c.ConvertToAPI(users[c.UserID])
+	}
+	return histories
+}
+
+func (ch *ClaimHistory) ConvertToAPI(user api.HistoryUser) api.ClaimHistory {
+	return api.ClaimHistory{
+		ID:          ch.ID,
+		ClaimID:     ch.ClaimID,
+		ClaimItemID: convertUUIDToAPI(ch.ClaimItemID),
+		Action:      ch.Action,
+		FieldName:   ch.FieldName,
+		OldValue:    ch.OldValue,
+		NewValue:    ch.NewValue,
+		User:        user,
+		CreatedAt:   ch.CreatedAt,
+	}
+}
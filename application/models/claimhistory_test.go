@@ -1,5 +1,14 @@
 package models
 
+import (
+	"net/url"
+	"testing"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+)
+
 func (ms *ModelSuite) TestClaimHistories_RecentClaimStatusChanges() {
 	fixtures := CreateClaimHistoryFixtures_RecentClaimStatusChanges(ms.DB)
 	chFixes := fixtures.ClaimHistories
@@ -19,3 +28,65 @@ func (ms *ModelSuite) TestClaimHistories_RecentClaimStatusChanges() {
 
 	ms.Equal(want, got, "incorrect results")
 }
+
+func (ms *ModelSuite) TestClaimHistories_FindByClaim() {
+	fixtures := CreateClaimHistoryFixtures_RecentClaimStatusChanges(ms.DB)
+	chFixes := fixtures.ClaimHistories
+	claimID := fixtures.Claims[1].ID
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCount int
+		wantFirst string
+	}{
+		{
+			name:      "default",
+			wantCount: 4,
+			wantFirst: chFixes[7].ID.String(),
+		},
+		{
+			name:      "filter",
+			query:     "filter=field_name:ReferenceNumber",
+			wantCount: 1,
+			wantFirst: chFixes[5].ID.String(),
+		},
+		{
+			name:      "limit",
+			query:     "limit=3",
+			wantCount: 3,
+			wantFirst: chFixes[7].ID.String(),
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+
+			var got ClaimHistories
+			_, err := got.FindByClaim(ms.DB, claimID, api.NewQuery(buffalo.ParamValues(values)))
+			ms.NoError(err)
+			ms.Equal(tt.wantCount, len(got), "incorrect number of histories")
+			if tt.wantFirst != "" {
+				ms.Equal(tt.wantFirst, got[0].ID.String(), "histories are not in the correct order")
+			}
+			for _, h := range got {
+				ms.Equal(claimID, h.ClaimID, "history of the wrong claim")
+			}
+		})
+	}
+}
+
+func (ms *ModelSuite) TestClaimHistories_ConvertToAPI() {
+	fixtures := CreateClaimHistoryFixtures_RecentClaimStatusChanges(ms.DB)
+	user := fixtures.Policies[0].Members[0]
+	histories := fixtures.ClaimHistories[0:2]
+
+	got := histories.ConvertToAPI(ms.DB)
+
+	ms.Equal(2, len(got), "incorrect number of histories")
+	ms.Equal(histories[1].ID, got[1].ID, "incorrect ID")
+	ms.Equal(histories[1].FieldName, got[1].FieldName, "incorrect FieldName")
+	ms.Equal(user.ID, got[1].User.ID, "incorrect user ID")
+	ms.Equal(user.FirstName, got[1].User.FirstName, "incorrect user first name")
+	ms.Equal(user.LastName, got[1].User.LastName, "incorrect user last name")
+}
@@ -27,24 +27,28 @@ var ValidPayoutOptions = map[api.PayoutOption]struct{}{
 type ClaimItems []ClaimItem
 
 type ClaimItem struct {
-	ID              uuid.UUID        `db:"id"`
-	ClaimID         uuid.UUID        `db:"claim_id"`
-	ItemID          uuid.UUID        `db:"item_id"`
-	IsRepairable    nulls.Bool       `db:"is_repairable"`
-	RepairEstimate  api.Currency     `db:"repair_estimate" validate:"min=0"`
-	RepairActual    api.Currency     `db:"repair_actual" validate:"min=0"`
-	ReplaceEstimate api.Currency     `db:"replace_estimate" validate:"min=0"`
-	ReplaceActual   api.Currency     `db:"replace_actual" validate:"min=0"`
-	PayoutOption    api.PayoutOption `db:"payout_option" validate:"payoutOption"`
-	PayoutAmount    api.Currency     `db:"payout_amount" validate:"min=0"`
-	CoverageAmount  api.Currency     `db:"coverage_amount" validate:"min=0"`
-	FMV             api.Currency     `db:"fmv" validate:"min=0"`
-	City            string           `db:"city"`
-	State           string           `db:"state"`
-	Country         string           `db:"country"`
-	LegacyID        nulls.Int        `db:"legacy_id"`
-	CreatedAt       time.Time        `db:"created_at"`
-	UpdatedAt       time.Time        `db:"updated_at"`
+	ID                    uuid.UUID        `db:"id"`
+	ClaimID               uuid.UUID        `db:"claim_id"`
+	ItemID                uuid.UUID        `db:"item_id"`
+	IsRepairable          nulls.Bool       `db:"is_repairable"`
+	RepairEstimate        api.Currency     `db:"repair_estimate" validate:"min=0"`
+	RepairActual          api.Currency     `db:"repair_actual" validate:"min=0"`
+	ReplaceEstimate       api.Currency     `db:"replace_estimate" validate:"min=0"`
+	ReplaceActual         api.Currency     `db:"replace_actual" validate:"min=0"`
+	PayoutOption          api.PayoutOption `db:"payout_option" validate:"payoutOption"`
+	PayoutAmount          api.Currency     `db:"payout_amount" validate:"min=0"`
+	Deductible            api.Currency     `db:"deductible" validate:"min=0"`
+	DeductibleRuleID      nulls.UUID       `db:"deductible_rule_id"`
+	DeductibleDescription string           `db:"deductible_description"` // snapshot of the rule when it was applied
+	DeductiblePercentage  nulls.Float64    `db:"deductible_percentage"`
+	CoverageAmount        api.Currency     `db:"coverage_amount" validate:"min=0"`
+	FMV                   api.Currency     `db:"fmv" validate:"min=0"`
+	City                  string           `db:"city"`
+	State                 string           `db:"state"`
+	Country               string           `db:"country"`
+	LegacyID              nulls.Int        `db:"legacy_id"`
+	CreatedAt             time.Time        `db:"created_at"`
+	UpdatedAt             time.Time        `db:"updated_at"`
 
 	Claim Claim `belongs_to:"claims" validate:"-"`
 	Item  Item  `belongs_to:"items" validate:"-"`
@@ -117,7 +121,11 @@ func (c *ClaimItem) revertToDraftIfEdited(ctx context.Context, updates []FieldUp
 		case FieldClaimItemReplaceActual, FieldClaimItemRepairActual:
 			continue
 		default:
-			return c.Claim.UpdateStatus(ctx, api.ClaimStatusDraft)
+			transition, ok := claimWorkflow.transitionFor(c.Claim.Status, ClaimActionEdit)
+			if !ok {
+				return nil
+			}
+			return c.Claim.UpdateStatus(ctx, transition.To)
 		}
 	}
 	return nil
@@ -137,6 +145,11 @@ func (c *ClaimItem) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permis
 		return true
 	}
 
+	// Only admin can adjust the payout
+	if sub == api.ResourceAdjustments {
+		return false
+	}
+
 	c.LoadItem(tx, false)
 
 	var policy Policy
@@ -177,23 +190,27 @@ func (c *ClaimItem) ConvertToAPI(tx *pop.Connection) api.ClaimItem {
 	c.LoadClaim(tx, false)
 
 	apiClaimItem := api.ClaimItem{
-		ID:              c.ID,
-		ItemID:          c.ItemID,
-		Item:            c.Item.ConvertToAPI(tx),
-		ClaimID:         c.ClaimID,
-		Status:          c.Claim.Status,
-		RepairEstimate:  c.RepairEstimate,
-		RepairActual:    c.RepairActual,
-		ReplaceEstimate: c.ReplaceEstimate,
-		ReplaceActual:   c.ReplaceActual,
-		PayoutOption:    c.PayoutOption,
-		PayoutAmount:    c.PayoutAmount,
-		CoverageAmount:  c.CoverageAmount,
-		FMV:             c.FMV,
-		ReviewDate:      c.Claim.ReviewDate.Time,
-		ReviewerID:      c.Claim.ReviewerID.UUID,
-		CreatedAt:       c.CreatedAt,
-		UpdatedAt:       c.UpdatedAt,
+		ID:                    c.ID,
+		ItemID:                c.ItemID,
+		Item:                  c.Item.ConvertToAPI(tx),
+		ClaimID:               c.ClaimID,
+		Status:                c.Claim.Status,
+		RepairEstimate:        c.RepairEstimate,
+		RepairActual:          c.RepairActual,
+		ReplaceEstimate:       c.ReplaceEstimate,
+		ReplaceActual:         c.ReplaceActual,
+		PayoutOption:          c.PayoutOption,
+		PayoutAmount:          c.PayoutAmount,
+		Deductible:            c.Deductible,
+		DeductibleRuleID:      convertUUIDToAPI(c.DeductibleRuleID),
+		DeductibleDescription: c.DeductibleDescription,
+		PayoutBreakdown:       c.explainPayout(tx).ConvertToAPI(),
+		CoverageAmount:        c.CoverageAmount,
+		FMV:                   c.FMV,
+		ReviewDate:            c.Claim.ReviewDate.Time,
+		ReviewerID:            c.Claim.ReviewerID.UUID,
+		CreatedAt:             c.CreatedAt,
+		UpdatedAt:             c.UpdatedAt,
 	}
 	if c.IsRepairable.Valid {
 		isRepairable := c.IsRepairable.Bool
@@ -407,37 +424,197 @@ func (c *ClaimItem) NewHistory(ctx context.Context, action string, fieldUpdate F
 	}
 }
 
-func (c *ClaimItem) updatePayoutAmount(ctx context.Context) error {
-	c.LoadItem(Tx(ctx), false)
+// payoutCalculation records each step of the payout calculation of a ClaimItem
+type payoutCalculation struct {
+	claimedValue          api.Currency
+	claimedValueSource    api.PayoutValue
+	repairThresholdValue  float64
+	coverageAmount        api.Currency
+	basis                 float64
+	limitedBy             api.PayoutValue
+	deductibleRuleID      nulls.UUID
+	deductibleDescription string
+	deductiblePercentage  nulls.Float64
+	deductible            api.Currency
+	adjustment            api.Currency
+	correction            api.Currency
+	payout                api.Currency
+}
+
+func (p payoutCalculation) ConvertToAPI() api.PayoutBreakdown {
+	b := api.PayoutBreakdown{
+		ClaimedValue:          p.claimedValue,
+		ClaimedValueSource:    p.claimedValueSource,
+		RepairThresholdValue:  api.Currency(math.Round(p.repairThresholdValue)),
+		CoverageAmount:        p.coverageAmount,
+		Basis:                 api.Currency(math.Round(p.basis)),
+		LimitedBy:             p.limitedBy,
+		DeductibleRuleID:      convertUUIDToAPI(p.deductibleRuleID),
+		DeductibleDescription: p.deductibleDescription,
+		Deductible:            p.deductible,
+		Adjustment:            p.adjustment,
+		Correction:            p.correction,
+		PayoutAmount:          p.payout,
+	}
+	if p.deductiblePercentage.Valid {
+		percentage := p.deductiblePercentage.Float64
+		b.DeductiblePercentage = &percentage
+	}
+	return b
+}
 
-	coverageAmount := c.Item.CoverageAmount
+// payoutBasis determines the value claimed for the PayoutOption and limits it by the RepairThreshold and the given
+// coverage amount. The deductible is not calculated.
+func (c *ClaimItem) payoutBasis(coverageAmount api.Currency) payoutCalculation {
+	b := payoutCalculation{coverageAmount: coverageAmount}
 
-	deductible := domain.Env.Deductible
-	maxValue := 0.0
 	switch c.PayoutOption {
 	case api.PayoutOptionRepair:
-		maxValue = float64(c.RepairEstimate)
+		b.claimedValue, b.claimedValueSource = c.RepairEstimate, api.PayoutValueRepairEstimate
 		if c.RepairActual > 0 {
-			maxValue = float64(c.RepairActual)
+			b.claimedValue, b.claimedValueSource = c.RepairActual, api.PayoutValueRepairActual
 		}
-		maxValue = math.Min(maxValue, float64(c.FMV)*domain.Env.RepairThreshold)
+		b.repairThresholdValue = float64(c.FMV) * domain.Env.RepairThreshold
 	case api.PayoutOptionReplacement:
-		maxValue = float64(c.ReplaceEstimate)
+		b.claimedValue, b.claimedValueSource = c.ReplaceEstimate, api.PayoutValueReplaceEstimate
 		if c.ReplaceActual > 0 {
-			maxValue = float64(c.ReplaceActual)
+			b.claimedValue, b.claimedValueSource = c.ReplaceActual, api.PayoutValueReplaceActual
 		}
 	case api.PayoutOptionFMV:
-		maxValue = float64(c.FMV)
+		b.claimedValue, b.claimedValueSource = c.FMV, api.PayoutValueFMV
 	case api.PayoutOptionFixedFraction:
-		deductible = domain.Env.EvacuationDeductible
-		maxValue = float64(coverageAmount)
+		b.claimedValue, b.claimedValueSource = b.coverageAmount, api.PayoutValueCoverageAmount
+	}
+
+	b.basis, b.limitedBy = float64(b.claimedValue), b.claimedValueSource
+	if c.PayoutOption == api.PayoutOptionRepair && b.repairThresholdValue < b.basis {
+		b.basis, b.limitedBy = b.repairThresholdValue, api.PayoutValueRepairThreshold
 	}
+	if float64(b.coverageAmount) < b.basis {
+		b.basis, b.limitedBy = float64(b.coverageAmount), api.PayoutValueCoverageAmount
+	}
+	return b
+}
+
+// calculatePayout calculates the payout using the most specific DeductibleRule, or the default deductible if no
+// rule matches. The FixedFraction payout option keeps the evacuation deductible unless the rule is specifically for
+// evacuations.
+func (c *ClaimItem) calculatePayout(tx *pop.Connection) payoutCalculation {
+	c.LoadClaim(tx, false)
+	c.Claim.LoadPolicy(tx, false)
+
+	c.LoadItem(tx, false)
+
+	b := c.payoutBasis(api.Currency(c.Item.CoverageAmount))
+	basis := api.Currency(math.Round(b.basis))
+
+	rule, ok := findDeductibleRule(tx, c.Claim.IncidentType, c.Claim.Policy.Type, c.Item.RiskCategoryID)
+	if c.PayoutOption == api.PayoutOptionFixedFraction && rule.IncidentType != api.ClaimIncidentTypeEvacuation {
+		ok = false
+	}
+
+	if ok {
+		b.deductible = rule.Amount(basis)
+		b.deductibleRuleID = nulls.NewUUID(rule.ID)
+		b.deductibleDescription = rule.Description()
+		if rule.Type == api.DeductibleTypePercentage {
+			b.deductiblePercentage = nulls.NewFloat64(rule.Percentage)
+		}
+	} else {
+		b.applyDefaultDeductible(c.PayoutOption)
+	}
+
+	b.payout = basis - b.deductible
+	return b
+}
+
+// applyDefaultDeductible sets the deductible used when no DeductibleRule applies: the evacuation deductible for the
+// FixedFraction payout option, otherwise the category deductible
+func (p *payoutCalculation) applyDefaultDeductible(option api.PayoutOption) {
+	fraction := domain.Env.Deductible
+	if option == api.PayoutOptionFixedFraction {
+		fraction = domain.Env.EvacuationDeductible
+	}
+	p.deductible = api.Currency(math.Round(p.basis)) - api.Currency(math.Round(p.basis*(1.0-fraction)))
+	p.deductibleDescription = percentString(fraction)
+	p.deductiblePercentage = nulls.NewFloat64(fraction)
+}
+
+// explainPayout returns the calculation of the recorded PayoutAmount. Only the values recorded when the payout was
+// last calculated, and any approved adjustments, are used, so the explanation still matches the PayoutAmount after
+// the item's coverage or the deductible rules change. A payout calculated before the deductible was recorded is
+// explained with the default deductible. If the payout calculated from the breakdown differs from the PayoutAmount,
+// the difference is shown as a correction.
+func (c *ClaimItem) explainPayout(tx *pop.Connection) payoutCalculation {
+	b := c.payoutBasis(c.CoverageAmount)
+	if c.DeductibleRuleID.Valid || c.DeductibleDescription != "" {
+		b.deductibleRuleID = c.DeductibleRuleID
+		b.deductibleDescription = c.DeductibleDescription
+		b.deductiblePercentage = c.DeductiblePercentage
+		b.deductible = c.Deductible
+	} else {
+		b.applyDefaultDeductible(c.PayoutOption)
+	}
+	b.adjustment = c.totalAdjustment(tx)
+	b.payout = api.Currency(math.Round(b.basis)) - b.deductible + b.adjustment
+	if b.payout != c.PayoutAmount {
+		b.correction = c.PayoutAmount - b.payout
+		b.payout = c.PayoutAmount
+	}
+	return b
+}
+
+// BackfillClaimItemDeductibles records the deductible of claim items that were paid before the deductible was
+// recorded. Those payouts used the default deductible, so the deductible is the payout basis less the payout before
+// adjustments. It also records the percentage of items paid with a percentage DeductibleRule before the percentage
+// was recorded. It returns the number of claim items updated.
+func BackfillClaimItemDeductibles(tx *pop.Connection) (int, error) {
+	var claimItems ClaimItems
+	if err := tx.Where("payout_amount > 0 AND deductible_rule_id IS NULL AND deductible_description = ''").
+		All(&claimItems); err != nil {
+		return 0, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	for i := range claimItems {
+		c := &claimItems[i]
+		b := c.payoutBasis(c.CoverageAmount)
+		b.applyDefaultDeductible(c.PayoutOption)
+
+		c.Deductible = api.Currency(math.Round(b.basis)) - (c.PayoutAmount - c.totalAdjustment(tx))
+		c.DeductibleDescription = b.deductibleDescription
+		c.DeductiblePercentage = b.deductiblePercentage
+		if err := update(tx, c); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := tx.RawQuery(`UPDATE claim_items SET deductible_percentage = deductible_rules.percentage
+		FROM deductible_rules WHERE claim_items.deductible_rule_id = deductible_rules.id
+		AND deductible_rules.type = ? AND claim_items.deductible_percentage IS NULL`,
+		api.DeductibleTypePercentage).ExecWithCount()
+	if err != nil {
+		return 0, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	return len(claimItems) + n, nil
+}
+
+// updatePayoutAmount calculates the payout, less the deductible, and records the coverage amount and the deductible
+// rule that were applied
+func (c *ClaimItem) updatePayoutAmount(ctx context.Context) error {
+	b := c.calculatePayout(Tx(ctx))
 
-	payout := api.Currency(math.Round(math.Min(maxValue, float64(coverageAmount)) * (1.0 - deductible)))
-	if c.PayoutAmount == payout {
+	if c.PayoutAmount == b.payout && c.Deductible == b.deductible && c.DeductibleRuleID == b.deductibleRuleID &&
+		c.DeductibleDescription == b.deductibleDescription && c.DeductiblePercentage == b.deductiblePercentage &&
+		c.CoverageAmount == b.coverageAmount {
 		return nil
 	}
 
-	c.PayoutAmount = payout
+	c.PayoutAmount = b.payout
+	c.CoverageAmount = b.coverageAmount
+	c.Deductible = b.deductible
+	c.DeductibleRuleID = b.deductibleRuleID
+	c.DeductibleDescription = b.deductibleDescription
+	c.DeductiblePercentage = b.deductiblePercentage
 	return c.Update(ctx)
 }
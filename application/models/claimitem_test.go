@@ -393,6 +393,282 @@ func (ms *ModelSuite) TestClaimItem_updatePayoutAmount() {
 	}
 }
 
+func (ms *ModelSuite) TestClaimItem_updatePayoutAmount_DeductibleRule() {
+	fixtures := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	UpdateClaimItems(ms.DB, fixtures.Claims[0], UpdateClaimItemsParams{
+		PayoutOption:    api.PayoutOptionReplacement,
+		ReplaceEstimate: 20000,
+	})
+	fixtures.Items[0].CoverageAmount = 100000
+	ms.NoError(ms.DB.Update(&fixtures.Items[0]))
+
+	testCtx := CreateTestContext(fixtures.Users[0])
+
+	var claimItem ClaimItem
+	ms.NoError(claimItem.FindByID(ms.DB, fixtures.Claims[0].ClaimItems[0].ID))
+
+	// without a rule, the default deductible is used
+	ms.NoError(claimItem.updatePayoutAmount(testCtx))
+	ms.Equal(api.Currency(19000), claimItem.PayoutAmount, "incorrect PayoutAmount with default deductible")
+	ms.Equal(api.Currency(1000), claimItem.Deductible, "incorrect Deductible with default deductible")
+	ms.False(claimItem.DeductibleRuleID.Valid, "DeductibleRuleID should not be set")
+	ms.Equal("5%", claimItem.DeductibleDescription, "incorrect DeductibleDescription")
+
+	rule := DeductibleRule{
+		IncidentType: fixtures.Claims[0].IncidentType,
+		Type:         api.DeductibleTypeFlat,
+		FlatAmount:   5000,
+	}
+	ms.NoError(rule.Create(ms.DB))
+
+	ms.NoError(claimItem.updatePayoutAmount(testCtx))
+	ms.Equal(api.Currency(15000), claimItem.PayoutAmount, "incorrect PayoutAmount with deductible rule")
+	ms.Equal(api.Currency(5000), claimItem.Deductible, "incorrect Deductible with deductible rule")
+	ms.Equal(rule.ID, claimItem.DeductibleRuleID.UUID, "incorrect DeductibleRuleID")
+	ms.Equal("$50.00", claimItem.DeductibleDescription, "incorrect DeductibleDescription")
+
+	// changing the rule does not change the recorded deductible
+	rule.FlatAmount = 7500
+	ms.NoError(rule.Update(ms.DB))
+
+	ms.NoError(claimItem.FindByID(ms.DB, claimItem.ID))
+	ms.Equal(api.Currency(5000), claimItem.Deductible, "recorded Deductible changed with the rule")
+	ms.Equal("$50.00", claimItem.DeductibleDescription, "recorded DeductibleDescription changed with the rule")
+}
+
+func (ms *ModelSuite) TestClaimItem_updatePayoutAmount_EvacuationDeductible() {
+	fixtures := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	UpdateClaimItems(ms.DB, fixtures.Claims[0], UpdateClaimItemsParams{PayoutOption: api.PayoutOptionFixedFraction})
+	fixtures.Items[0].CoverageAmount = 90000
+	ms.NoError(ms.DB.Update(&fixtures.Items[0]))
+	fixtures.Claims[0].IncidentType = api.ClaimIncidentTypeEvacuation
+	ms.NoError(ms.DB.Update(&fixtures.Claims[0]))
+
+	testCtx := CreateTestContext(fixtures.Users[0])
+
+	var claimItem ClaimItem
+	ms.NoError(claimItem.FindByID(ms.DB, fixtures.Claims[0].ClaimItems[0].ID))
+
+	// a rule for any incident type does not replace the evacuation deductible
+	anyIncident := DeductibleRule{Type: api.DeductibleTypeFlat, FlatAmount: 5000}
+	ms.NoError(anyIncident.Create(ms.DB))
+
+	ms.NoError(claimItem.updatePayoutAmount(testCtx))
+	ms.Equal(api.Currency(60000), claimItem.PayoutAmount, "incorrect PayoutAmount with a rule for any incident")
+	ms.False(claimItem.DeductibleRuleID.Valid, "DeductibleRuleID should not be set")
+	ms.Equal(percentString(domain.Env.EvacuationDeductible), claimItem.DeductibleDescription,
+		"incorrect DeductibleDescription")
+
+	// a rule for evacuations replaces the evacuation deductible
+	evacuation := DeductibleRule{
+		IncidentType: api.ClaimIncidentTypeEvacuation,
+		Type:         api.DeductibleTypeFlat,
+		FlatAmount:   5000,
+	}
+	ms.NoError(evacuation.Create(ms.DB))
+
+	ms.NoError(claimItem.updatePayoutAmount(testCtx))
+	ms.Equal(api.Currency(85000), claimItem.PayoutAmount, "incorrect PayoutAmount with an evacuation rule")
+	ms.Equal(evacuation.ID, claimItem.DeductibleRuleID.UUID, "incorrect DeductibleRuleID")
+}
+
+func (ms *ModelSuite) TestClaimItem_explainPayout() {
+	params := []UpdateClaimItemsParams{
+		{PayoutOption: api.PayoutOptionRepair, FMV: 1000, RepairEstimate: 100},
+		{PayoutOption: api.PayoutOptionRepair, FMV: 1000, RepairEstimate: 100, RepairActual: 800},
+		{PayoutOption: api.PayoutOptionReplacement, ReplaceEstimate: 2000},
+		{PayoutOption: api.PayoutOptionFMV, FMV: 300},
+	}
+
+	fixtures := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: len(params), ClaimItemsPerClaim: 1})
+
+	for i, p := range params {
+		UpdateClaimItems(ms.DB, fixtures.Claims[i], p)
+		fixtures.Items[i].CoverageAmount = 900
+		ms.NoError(ms.DB.Update(&fixtures.Items[i]))
+	}
+
+	percentage := domain.Env.Deductible
+
+	tests := []struct {
+		name      string
+		claimItem ClaimItem
+		want      api.PayoutBreakdown
+	}{
+		{
+			name:      "repair estimate",
+			claimItem: fixtures.Claims[0].ClaimItems[0],
+			want: api.PayoutBreakdown{
+				ClaimedValue:          100,
+				ClaimedValueSource:    api.PayoutValueRepairEstimate,
+				RepairThresholdValue:  700,
+				CoverageAmount:        900,
+				Basis:                 100,
+				LimitedBy:             api.PayoutValueRepairEstimate,
+				DeductibleDescription: "5%",
+				DeductiblePercentage:  &percentage,
+				Deductible:            5,
+				PayoutAmount:          95,
+			},
+		},
+		{
+			name:      "repair actual limited by repair threshold",
+			claimItem: fixtures.Claims[1].ClaimItems[0],
+			want: api.PayoutBreakdown{
+				ClaimedValue:          800,
+				ClaimedValueSource:    api.PayoutValueRepairActual,
+				RepairThresholdValue:  700,
+				CoverageAmount:        900,
+				Basis:                 700,
+				LimitedBy:             api.PayoutValueRepairThreshold,
+				DeductibleDescription: "5%",
+				DeductiblePercentage:  &percentage,
+				Deductible:            35,
+				PayoutAmount:          665,
+			},
+		},
+		{
+			name:      "replacement limited by coverage amount",
+			claimItem: fixtures.Claims[2].ClaimItems[0],
+			want: api.PayoutBreakdown{
+				ClaimedValue:          2000,
+				ClaimedValueSource:    api.PayoutValueReplaceEstimate,
+				CoverageAmount:        900,
+				Basis:                 900,
+				LimitedBy:             api.PayoutValueCoverageAmount,
+				DeductibleDescription: "5%",
+				DeductiblePercentage:  &percentage,
+				Deductible:            45,
+				PayoutAmount:          855,
+			},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			var claimItem ClaimItem
+			ms.NoError(claimItem.FindByID(ms.DB, tt.claimItem.ID))
+			ms.NoError(claimItem.updatePayoutAmount(CreateTestContext(fixtures.Users[0])))
+
+			got := claimItem.explainPayout(ms.DB).ConvertToAPI()
+			ms.Equal(tt.want, got)
+
+			// the explanation must match the calculated payout
+			ms.Equal(claimItem.PayoutAmount, got.PayoutAmount, "breakdown does not match PayoutAmount")
+			ms.Equal(claimItem.Deductible, got.Deductible, "breakdown does not match Deductible")
+		})
+	}
+
+	// once the claim is approved, the recorded deductible is explained even if the rules change
+	var claimItem ClaimItem
+	ms.NoError(claimItem.FindByID(ms.DB, fixtures.Claims[3].ClaimItems[0].ID))
+	ms.NoError(claimItem.updatePayoutAmount(CreateTestContext(fixtures.Users[0])))
+	UpdateClaimStatus(ms.DB, fixtures.Claims[3], api.ClaimStatusApproved, "")
+
+	rule := DeductibleRule{
+		IncidentType: fixtures.Claims[3].IncidentType,
+		Type:         api.DeductibleTypeFlat,
+		FlatAmount:   10000,
+	}
+	ms.NoError(rule.Create(ms.DB))
+
+	var approved ClaimItem
+	ms.NoError(approved.FindByID(ms.DB, claimItem.ID))
+	got := approved.explainPayout(ms.DB).ConvertToAPI()
+	ms.Equal(api.Currency(285), got.PayoutAmount, "incorrect PayoutAmount for approved claim")
+	ms.Equal(api.Currency(15), got.Deductible, "incorrect Deductible for approved claim")
+	ms.Equal("5%", got.DeductibleDescription, "incorrect DeductibleDescription for approved claim")
+	ms.Nil(got.DeductibleRuleID, "DeductibleRuleID should not be set for approved claim")
+
+	// a change to the item's coverage after approval does not change the explanation
+	fixtures.Items[3].CoverageAmount = 200
+	ms.NoError(ms.DB.Update(&fixtures.Items[3]))
+
+	ms.NoError(approved.FindByID(ms.DB, claimItem.ID))
+	got = approved.explainPayout(ms.DB).ConvertToAPI()
+	ms.Equal(api.Currency(900), got.CoverageAmount, "incorrect CoverageAmount for approved claim")
+	ms.Equal(api.Currency(300), got.Basis, "incorrect Basis for approved claim")
+	ms.Equal(api.PayoutValueFMV, got.LimitedBy, "incorrect LimitedBy for approved claim")
+	ms.Equal(api.Currency(15), got.Deductible, "incorrect Deductible for approved claim")
+	ms.Equal(api.Currency(285), got.PayoutAmount, "incorrect PayoutAmount for approved claim")
+}
+
+func (ms *ModelSuite) TestClaimItem_explainPayout_NoRecordedDeductible() {
+	fixtures := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	UpdateClaimItems(ms.DB, fixtures.Claims[0], UpdateClaimItemsParams{PayoutOption: api.PayoutOptionFMV, FMV: 300})
+
+	// a claim item paid before the deductible was recorded
+	var claimItem ClaimItem
+	ms.NoError(claimItem.FindByID(ms.DB, fixtures.Claims[0].ClaimItems[0].ID))
+	claimItem.CoverageAmount = 900
+	claimItem.PayoutAmount = 285
+	claimItem.Deductible = 0
+	claimItem.DeductibleRuleID = nulls.UUID{}
+	claimItem.DeductibleDescription = ""
+	claimItem.DeductiblePercentage = nulls.Float64{}
+	ms.NoError(ms.DB.Update(&claimItem))
+
+	percentage := domain.Env.Deductible
+	got := claimItem.explainPayout(ms.DB).ConvertToAPI()
+	ms.Equal(api.Currency(300), got.Basis, "incorrect Basis")
+	ms.Equal(api.Currency(15), got.Deductible, "incorrect Deductible")
+	ms.Equal("5%", got.DeductibleDescription, "incorrect DeductibleDescription")
+	ms.Equal(&percentage, got.DeductiblePercentage, "incorrect DeductiblePercentage")
+	ms.Equal(api.Currency(285), got.PayoutAmount, "incorrect PayoutAmount")
+	ms.Equal(api.Currency(0), got.Correction, "incorrect Correction")
+	ms.Equal(got.Basis-got.Deductible, got.PayoutAmount, "breakdown does not add up to the PayoutAmount")
+
+	// a recorded payout that does not match the calculation is explained with a correction
+	claimItem.PayoutAmount = 280
+	ms.NoError(ms.DB.Update(&claimItem))
+
+	got = claimItem.explainPayout(ms.DB).ConvertToAPI()
+	ms.Equal(api.Currency(15), got.Deductible, "incorrect Deductible")
+	ms.Equal(api.Currency(-5), got.Correction, "incorrect Correction")
+	ms.Equal(api.Currency(280), got.PayoutAmount, "incorrect PayoutAmount")
+	ms.Equal(got.Basis-got.Deductible+got.Adjustment+got.Correction, got.PayoutAmount,
+		"breakdown does not add up to the PayoutAmount")
+}
+
+func (ms *ModelSuite) TestBackfillClaimItemDeductibles() {
+	fixtures := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 2, ClaimItemsPerClaim: 1})
+	UpdateClaimItems(ms.DB, fixtures.Claims[0], UpdateClaimItemsParams{PayoutOption: api.PayoutOptionFMV, FMV: 300})
+
+	// a claim item paid before the deductible was recorded
+	var claimItem ClaimItem
+	ms.NoError(claimItem.FindByID(ms.DB, fixtures.Claims[0].ClaimItems[0].ID))
+	claimItem.CoverageAmount = 900
+	claimItem.PayoutAmount = 285
+	claimItem.Deductible = 0
+	claimItem.DeductibleRuleID = nulls.UUID{}
+	claimItem.DeductibleDescription = ""
+	claimItem.DeductiblePercentage = nulls.Float64{}
+	ms.NoError(ms.DB.Update(&claimItem))
+
+	// a claim item that has not been paid
+	var unpaid ClaimItem
+	ms.NoError(unpaid.FindByID(ms.DB, fixtures.Claims[1].ClaimItems[0].ID))
+	unpaid.PayoutAmount = 0
+	unpaid.DeductibleDescription = ""
+	ms.NoError(ms.DB.Update(&unpaid))
+
+	n, err := BackfillClaimItemDeductibles(ms.DB)
+	ms.NoError(err)
+	ms.Equal(1, n, "incorrect number of claim items updated")
+
+	ms.NoError(claimItem.FindByID(ms.DB, claimItem.ID))
+	ms.Equal(api.Currency(15), claimItem.Deductible, "incorrect Deductible")
+	ms.Equal("5%", claimItem.DeductibleDescription, "incorrect DeductibleDescription")
+	ms.Equal(nulls.NewFloat64(domain.Env.Deductible), claimItem.DeductiblePercentage,
+		"incorrect DeductiblePercentage")
+
+	ms.NoError(unpaid.FindByID(ms.DB, unpaid.ID))
+	ms.Equal("", unpaid.DeductibleDescription, "an unpaid claim item should not be updated")
+
+	n, err = BackfillClaimItemDeductibles(ms.DB)
+	ms.NoError(err)
+	ms.Equal(0, n, "the backfill should only update a claim item once")
+}
+
 func (ms *ModelSuite) TestClaimItem_ConvertToAPI() {
 	fixtures := CreateItemFixtures(ms.DB, FixturesConfig{
 		ClaimsPerPolicy:    1,
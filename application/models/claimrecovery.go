@@ -0,0 +1,134 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+type ClaimRecoveries []ClaimRecovery
+
+// ClaimRecovery is money recovered after a Claim was paid out, such as a reimbursement from a third party or the
+// value of a stolen item that was found
+type ClaimRecovery struct {
+	ID           uuid.UUID    `db:"id"`
+	ClaimID      uuid.UUID    `db:"claim_id" validate:"required"`
+	Source       string       `db:"source" validate:"required"`
+	Amount       api.Currency `db:"amount" validate:"min=1"`
+	RecoveryDate time.Time    `db:"recovery_date" validate:"required"`
+	Notes        string       `db:"notes"`
+	CreatedByID  uuid.UUID    `db:"created_by_id" validate:"required"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (r *ClaimRecovery) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(r), nil
+}
+
+func (r *ClaimRecovery) Create(tx *pop.Connection) error {
+	return create(tx, r)
+}
+
+// AddRecovery records money recovered for an approved or paid Claim. A ClaimRecovery LedgerEntry is created for each
+// ClaimItem, sharing the amount in proportion to the item payouts. The total recovered cannot exceed the payout.
+func (c *Claim) AddRecovery(ctx context.Context, input api.ClaimRecoveryInput) (ClaimRecovery, error) {
+	if c.Status != api.ClaimStatusApproved && c.Status != api.ClaimStatusPaid {
+		err := fmt.Errorf("cannot record a recovery for a claim with status %s", c.Status)
+		return ClaimRecovery{}, api.NewAppError(err, api.ErrorClaimStatus, api.CategoryUser)
+	}
+
+	tx := Tx(ctx)
+
+	if remaining := c.TotalPayout - c.TotalRecovered(tx); input.Amount > remaining {
+		err := fmt.Errorf("recovery of %s exceeds the unrecovered payout of %s", input.Amount, remaining)
+		return ClaimRecovery{}, api.NewAppError(err, api.ErrorClaimRecoveryAmount, api.CategoryUser)
+	}
+
+	r := ClaimRecovery{
+		ClaimID:      c.ID,
+		Source:       input.Source,
+		Amount:       input.Amount,
+		RecoveryDate: input.RecoveryDate,
+		Notes:        input.Notes,
+		CreatedByID:  CurrentUser(ctx).ID,
+	}
+	if err := r.Create(tx); err != nil {
+		return ClaimRecovery{}, err
+	}
+
+	c.LoadClaimItems(tx, false)
+	payouts := make([]api.Currency, len(c.ClaimItems))
+	var totalPayout api.Currency
+	for i, claimItem := range c.ClaimItems {
+		payouts[i] = claimItem.PayoutAmount
+		totalPayout += claimItem.PayoutAmount
+	}
+	shares := shareDisbursement(r.Amount, payouts)
+
+	// a claim with no payout has nothing to share the recovery by, so it goes to the first item
+	if totalPayout == 0 && len(shares) > 0 {
+		shares[0] = r.Amount
+	}
+
+	for i := range c.ClaimItems {
+		if shares[i] == 0 {
+			continue
+		}
+		le := c.newClaimLedgerEntry(tx, &c.ClaimItems[i], LedgerEntryTypeClaimRecovery, shares[i])
+		if err := le.Create(tx); err != nil {
+			return ClaimRecovery{}, err
+		}
+	}
+
+	return r, nil
+}
+
+// TotalRecovered returns the total of all ClaimRecoveries of the Claim
+func (c *Claim) TotalRecovered(tx *pop.Connection) api.Currency {
+	var recoveries ClaimRecoveries
+	if err := recoveries.FindByClaim(tx, c.ID); err != nil {
+		panic("database error finding claim recoveries, " + err.Error())
+	}
+
+	var total api.Currency
+	for _, r := range recoveries {
+		total += r.Amount
+	}
+	return total
+}
+
+// FindByClaim finds all ClaimRecoveries for the given Claim, oldest first
+func (r *ClaimRecoveries) FindByClaim(tx *pop.Connection, claimID uuid.UUID) error {
+	err := tx.Where("claim_id = ?", claimID).Order("recovery_date asc, created_at asc").All(r)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+func (r *ClaimRecovery) ConvertToAPI() api.ClaimRecovery {
+	return api.ClaimRecovery{
+		ID:           r.ID,
+		ClaimID:      r.ClaimID,
+		Source:       r.Source,
+		Amount:       r.Amount,
+		RecoveryDate: r.RecoveryDate,
+		Notes:        r.Notes,
+		CreatedByID:  r.CreatedByID,
+		CreatedAt:    r.CreatedAt,
+	}
+}
+
+func (r *ClaimRecoveries) ConvertToAPI() api.ClaimRecoveries {
+	recoveries := make(api.ClaimRecoveries, len(*r))
+	for i, rr := range *r {
+		recoveries[i] = rr.ConvertToAPI()
+	}
+	return recoveries
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"time"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestClaim_AddRecovery() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2, ClaimsPerPolicy: 1, ClaimItemsPerClaim: 2})
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+	ctx := CreateTestContext(steward)
+
+	claim := f.Claims[0]
+	claim.LoadClaimItems(ms.DB, false)
+	claim.ClaimItems[0].PayoutAmount = 30000
+	ms.NoError(ms.DB.Update(&claim.ClaimItems[0]))
+	claim.ClaimItems[1].PayoutAmount = 10000
+	ms.NoError(ms.DB.Update(&claim.ClaimItems[1]))
+	claim.TotalPayout = 40000
+
+	input := api.ClaimRecoveryInput{
+		Source:       "Acme Insurance",
+		Amount:       8000,
+		RecoveryDate: time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC),
+		Notes:        "third party reimbursement",
+	}
+
+	_, err := claim.AddRecovery(ctx, input)
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimStatus, Category: api.CategoryUser}, err)
+
+	claim = UpdateClaimStatus(ms.DB, claim, api.ClaimStatusPaid, "")
+	claim.LoadClaimItems(ms.DB, true)
+
+	_, err = claim.AddRecovery(ctx, api.ClaimRecoveryInput{Source: "Acme", RecoveryDate: input.RecoveryDate})
+	ms.EqualAppError(api.AppError{Key: api.ErrorValidation, Category: api.CategoryUser}, err)
+
+	got, err := claim.AddRecovery(ctx, input)
+	ms.NoError(err)
+	ms.Equal(claim.ID, got.ClaimID, "incorrect ClaimID")
+	ms.Equal(input.Source, got.Source, "incorrect Source")
+	ms.Equal(input.Amount, got.Amount, "incorrect Amount")
+	ms.Equal(input.Notes, got.Notes, "incorrect Notes")
+	ms.Equal(steward.ID, got.CreatedByID, "incorrect CreatedByID")
+
+	var entries LedgerEntries
+	ms.NoError(ms.DB.Where("claim_id = ? AND type = ?", claim.ID, LedgerEntryTypeClaimRecovery).
+		Order("amount desc").All(&entries))
+	ms.Equal(2, len(entries), "incorrect number of recovery ledger entries")
+	ms.Equal(api.Currency(6000), entries[0].Amount, "incorrect share of recovery for first item")
+	ms.Equal(api.Currency(2000), entries[1].Amount, "incorrect share of recovery for second item")
+
+	ms.Equal(api.Currency(8000), claim.TotalRecovered(ms.DB), "incorrect TotalRecovered")
+
+	// the total recovered cannot exceed the payout
+	input.Amount = 32001
+	_, err = claim.AddRecovery(ctx, input)
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimRecoveryAmount, Category: api.CategoryUser}, err)
+
+	apiClaim := claim.ConvertToAPI(ms.DB)
+	ms.Equal(api.Currency(8000), apiClaim.TotalRecovered, "incorrect api TotalRecovered")
+	ms.Equal(claim.TotalPayout-8000, apiClaim.NetLoss, "incorrect api NetLoss")
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+// ClaimSearch holds the criteria for a search of the claims on all policies. Empty fields are not used.
+type ClaimSearch struct {
+	// Statuses to include. If empty, only filed claims are included, so drafts and withdrawn claims are excluded.
+	Statuses        []api.ClaimStatus
+	IncidentType    api.ClaimIncidentType
+	IncidentFrom    nulls.Time // first incident date to include
+	IncidentTo      nulls.Time // last incident date to include
+	MinPayout       nulls.Int
+	MaxPayout       nulls.Int
+	EntityCode      string
+	ReferenceNumber string
+
+	// Text is found in the incident description, the names of the claim's items, or the names of the members of the
+	// claim's policy
+	Text string
+}
+
+// Search finds a page of the claims matching the search criteria
+func (c *Claims) Search(tx *pop.Connection, search ClaimSearch, query api.Query) (api.Meta, error) {
+	return findPage(search.query(tx), query, claimListOptions, c)
+}
+
+// SearchAll finds all the claims matching the search criteria, oldest first, for export. If there are too many to
+// export, an error is returned.
+func (c *Claims) SearchAll(tx *pop.Connection, search ClaimSearch) error {
+	return findExport(search.query(tx).Order("claims.created_at ASC, claims.id ASC"), c)
+}
+
+func (s ClaimSearch) query(tx *pop.Connection) *pop.Query {
+	q := tx.Q()
+
+	if len(s.Statuses) > 0 {
+		q = q.Where("claims.status IN (?)", s.Statuses)
+	} else {
+		q = q.Where("claims.status IN (?)", filedClaimStatuses())
+	}
+	if s.IncidentType != "" {
+		q = q.Where("claims.incident_type = ?", s.IncidentType)
+	}
+	if s.IncidentFrom.Valid {
+		q = q.Where("claims.incident_date >= ?", s.IncidentFrom.Time)
+	}
+	if s.IncidentTo.Valid {
+		q = q.Where("claims.incident_date < ?", s.IncidentTo.Time.AddDate(0, 0, 1))
+	}
+	if s.MinPayout.Valid {
+		q = q.Where("claims.total_payout >= ?", s.MinPayout.Int)
+	}
+	if s.MaxPayout.Valid {
+		q = q.Where("claims.total_payout <= ?", s.MaxPayout.Int)
+	}
+	if s.EntityCode != "" {
+		q = q.Where(`claims.policy_id IN (SELECT p.id FROM policies p
+			JOIN entity_codes e ON e.id = p.entity_code_id WHERE e.code = ?)`, s.EntityCode)
+	}
+	if s.ReferenceNumber != "" {
+		q = q.Where("claims.reference_number ILIKE ?", "%"+s.ReferenceNumber+"%")
+	}
+	if s.Text != "" {
+		text := "%" + s.Text + "%"
+		q = q.Where("(claims.incident_description ILIKE ?"+
+			" OR claims.id IN (SELECT ci.claim_id FROM claim_items ci JOIN items i ON i.id = ci.item_id"+
+			" WHERE i.name ILIKE ?)"+
+			" OR claims.policy_id IN (SELECT pu.policy_id FROM policy_users pu JOIN users u ON u.id = pu.user_id"+
+			" WHERE u.first_name || ' ' || u.last_name ILIKE ?))", text, text, text)
+	}
+
+	return q
+}
+
+// filedClaimStatuses returns the claim statuses for which ClaimStatus.IsFiled is true
+func filedClaimStatuses() []api.ClaimStatus {
+	statuses := make([]api.ClaimStatus, 0, len(ValidClaimStatus))
+	for status := range ValidClaimStatus {
+		if status.IsFiled() {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+var claimsCsvHeader = []string{
+	"Reference Number", "Status", "Incident Type", "Incident Date", "Incident Description", "Items", "Policy",
+	"Entity Code", "Total Payout", "Date Created",
+}
+
+// ClaimsToCsv returns a list of claims in CSV format
+func ClaimsToCsv(tx *pop.Connection, claims Claims) ([]byte, error) {
+	if err := claims.loadForCsv(tx); err != nil {
+		return nil, err
+	}
+
+	records := [][]string{claimsCsvHeader}
+	for _, c := range claims {
+		itemNames := make([]string, len(c.ClaimItems))
+		for j := range c.ClaimItems {
+			itemNames[j] = c.ClaimItems[j].Item.Name
+		}
+
+		records = append(records, []string{
+			c.ReferenceNumber,
+			string(c.Status),
+			string(c.IncidentType),
+			c.IncidentDate.Format(domain.DateFormat),
+			c.IncidentDescription,
+			strings.Join(itemNames, "; "),
+			c.Policy.Name,
+			c.Policy.EntityCode.Code,
+			c.TotalPayout.String(),
+			c.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return domain.CSV(records), nil
+}
+
+// loadForCsv loads the Policy with its EntityCode and the ClaimItems with their Items of each of the Claims, with one
+// query for each kind of record rather than for each Claim
+func (c Claims) loadForCsv(tx *pop.Connection) error {
+	if len(c) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(c))
+	policyIDs := make([]uuid.UUID, len(c))
+	for n := range c {
+		ids[n] = c[n].ID
+		policyIDs[n] = c[n].PolicyID
+	}
+
+	policies, err := policiesByID(tx, policyIDs)
+	if err != nil {
+		return err
+	}
+
+	var claimItems ClaimItems
+	if err := tx.Where("claim_id IN (?)", ids).Order("created_at ASC, id ASC").All(&claimItems); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	itemsByID := map[uuid.UUID]Item{}
+	if len(claimItems) > 0 {
+		itemIDs := make([]uuid.UUID, len(claimItems))
+		for n := range claimItems {
+			itemIDs[n] = claimItems[n].ItemID
+		}
+		var items Items
+		if err := tx.Where("id IN (?)", itemIDs).All(&items); err != nil {
+			return appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		for _, i := range items {
+			itemsByID[i.ID] = i
+		}
+	}
+
+	claimItemsByClaim := map[uuid.UUID]ClaimItems{}
+	for _, ci := range claimItems {
+		ci.Item = itemsByID[ci.ItemID]
+		claimItemsByClaim[ci.ClaimID] = append(claimItemsByClaim[ci.ClaimID], ci)
+	}
+
+	for n := range c {
+		c[n].Policy = policies[c[n].PolicyID]
+		c[n].ClaimItems = claimItemsByClaim[c[n].ID]
+	}
+	return nil
+}
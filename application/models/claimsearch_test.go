@@ -0,0 +1,135 @@
+package models
+
+import (
+	"encoding/csv"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestClaims_Search() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 3, ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	teamPolicy := ConvertPolicyType(ms.DB, f.Policies[1])
+	teamPolicy.LoadEntityCode(ms.DB, false)
+
+	householdClaim := UpdateClaimStatus(ms.DB, f.Claims[0], api.ClaimStatusReview2, "")
+	ms.NoError(ms.DB.RawQuery(`UPDATE claims SET incident_description = ?, total_payout = ?, incident_date = ?
+		WHERE id = ?`, "Burst pipe in the kitchen", 20000, time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC),
+		householdClaim.ID).Exec())
+
+	teamClaim := UpdateClaimStatus(ms.DB, f.Claims[1], api.ClaimStatusReview1, "")
+	ms.NoError(ms.DB.RawQuery("UPDATE claims SET total_payout = ? WHERE id = ?", 50000, teamClaim.ID).Exec())
+
+	withdrawnClaim := UpdateClaimStatus(ms.DB, f.Claims[2], api.ClaimStatusWithdrawn, "")
+
+	member := f.Policies[0].Members[0]
+
+	tests := []struct {
+		name    string
+		search  ClaimSearch
+		wantIDs []uuid.UUID
+	}{
+		{
+			name:    "all",
+			search:  ClaimSearch{},
+			wantIDs: []uuid.UUID{householdClaim.ID, teamClaim.ID},
+		},
+		{
+			name:    "status",
+			search:  ClaimSearch{Statuses: []api.ClaimStatus{api.ClaimStatusReview1, api.ClaimStatusReview3}},
+			wantIDs: []uuid.UUID{teamClaim.ID},
+		},
+		{
+			name:    "withdrawn",
+			search:  ClaimSearch{Statuses: []api.ClaimStatus{api.ClaimStatusWithdrawn}},
+			wantIDs: []uuid.UUID{withdrawnClaim.ID},
+		},
+		{
+			name: "incident date range",
+			search: ClaimSearch{
+				IncidentFrom: nulls.NewTime(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)),
+				IncidentTo:   nulls.NewTime(time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC)),
+			},
+			wantIDs: []uuid.UUID{householdClaim.ID},
+		},
+		{
+			name:    "payout range",
+			search:  ClaimSearch{MinPayout: nulls.NewInt(30000), MaxPayout: nulls.NewInt(50000)},
+			wantIDs: []uuid.UUID{teamClaim.ID},
+		},
+		{
+			name:    "entity code",
+			search:  ClaimSearch{EntityCode: teamPolicy.EntityCode.Code},
+			wantIDs: []uuid.UUID{teamClaim.ID},
+		},
+		{
+			name:    "reference number",
+			search:  ClaimSearch{ReferenceNumber: strings.ToLower(householdClaim.ReferenceNumber[1:])},
+			wantIDs: []uuid.UUID{householdClaim.ID},
+		},
+		{
+			name:    "text in description",
+			search:  ClaimSearch{Text: "burst PIPE"},
+			wantIDs: []uuid.UUID{householdClaim.ID},
+		},
+		{
+			name:    "text in item name",
+			search:  ClaimSearch{Text: f.Items[1].Name},
+			wantIDs: []uuid.UUID{teamClaim.ID},
+		},
+		{
+			name:    "text in member name",
+			search:  ClaimSearch{Text: member.FirstName + " " + member.LastName},
+			wantIDs: []uuid.UUID{householdClaim.ID},
+		},
+		{
+			name:    "no match",
+			search:  ClaimSearch{Text: "no such claim"},
+			wantIDs: []uuid.UUID{},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			var claims Claims
+			meta, err := claims.Search(ms.DB, tt.search, api.NewQuery(url.Values{}))
+			ms.NoError(err)
+			ms.Equal(len(tt.wantIDs), meta.TotalRecords, "incorrect total records")
+
+			ids := make([]uuid.UUID, len(claims))
+			for i := range claims {
+				ids[i] = claims[i].ID
+			}
+			ms.ElementsMatch(tt.wantIDs, ids, "incorrect claims found")
+
+			var all Claims
+			ms.NoError(all.SearchAll(ms.DB, tt.search))
+			ms.Equal(len(tt.wantIDs), len(all), "incorrect number of claims from SearchAll")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestClaimsToCsv() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 1, ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	claim := f.Claims[0]
+
+	got, err := ClaimsToCsv(ms.DB, Claims{claim})
+	ms.NoError(err)
+	records, err := csv.NewReader(strings.NewReader(string(got))).ReadAll()
+	ms.NoError(err)
+	ms.Equal(2, len(records), "incorrect number of rows")
+	ms.Equal(claimsCsvHeader, records[0], "incorrect header")
+
+	row := records[1]
+	ms.Equal(claim.ReferenceNumber, row[0], "incorrect reference number")
+	ms.Equal(string(api.ClaimStatusDraft), row[1], "incorrect status")
+	ms.Equal("2020-05-01", row[3], "incorrect incident date")
+	ms.Equal(claim.IncidentDescription, row[4], "incorrect incident description")
+	ms.Equal(f.Items[0].Name, row[5], "incorrect item names")
+	ms.Equal(f.Policies[0].Name, row[6], "incorrect policy name")
+}
@@ -0,0 +1,335 @@
+package models
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+// claimWorkflow is the claim review workflow in effect. It is loaded at startup.
+var claimWorkflow = defaultClaimWorkflow()
+
+// ClaimWorkflow defines the claim review process: the status to which each action takes a claim, who may take it,
+// and how many final approvals a claim needs depending on its payout. The default is in claim-workflow.json. It can
+// be replaced by a JSON file named in the CLAIM_WORKFLOW_FILE environment variable. The statuses themselves are fixed
+// by the API.
+type ClaimWorkflow struct {
+	Transitions []ClaimWorkflowTransition `json:"transitions"`
+	Approvals   []ClaimApprovalRule       `json:"approvals"`
+}
+
+// ClaimWorkflowTransition allows a Claim to change from one status to another by one of the claim actions. The
+// action decides which status a Claim goes to, e.g. whether an approval from Review1 goes to Review2 or Review3.
+type ClaimWorkflowTransition struct {
+	From   api.ClaimStatus `json:"from"`
+	Action ClaimAction     `json:"action"`
+	To     api.ClaimStatus `json:"to"`
+
+	// Roles that may make the change. If empty, the change is only made by the system, e.g. when a payment is
+	// reconciled, and is not restricted by role.
+	Roles []UserAppRole `json:"roles"`
+
+	// PayoutOptions, if not empty, limits the change to claims with one of these payout options
+	PayoutOptions []api.PayoutOption `json:"payout_options"`
+}
+
+// ClaimApprovalRule sets the number of distinct users that must give final approval to a claim with a total payout
+// of at least MinPayout. The rule with the highest MinPayout not above the payout applies.
+type ClaimApprovalRule struct {
+	// minimum total payout in cents
+	MinPayout api.Currency `json:"min_payout"`
+
+	// number of final approvals required
+	Approvals int `json:"approvals"`
+
+	// roles that may give the final approvals
+	Roles []UserAppRole `json:"roles"`
+}
+
+// ClaimAction is something done to a Claim that changes its status
+type ClaimAction string
+
+const (
+	ClaimActionSubmit   = ClaimAction("submit")
+	ClaimActionEdit     = ClaimAction("edit")
+	ClaimActionRevision = ClaimAction("revision")
+	ClaimActionReceipt  = ClaimAction("receipt")
+	ClaimActionApprove  = ClaimAction("approve")
+	ClaimActionDeny     = ClaimAction("deny")
+	ClaimActionWithdraw = ClaimAction("withdraw")
+	ClaimActionAppeal   = ClaimAction("appeal")
+	ClaimActionPay      = ClaimAction("pay")
+	ClaimActionReverse  = ClaimAction("reverse")
+)
+
+// claimActionTargets are the statuses to which each action may change a Claim
+var claimActionTargets = map[ClaimAction][]api.ClaimStatus{
+	ClaimActionSubmit:   {api.ClaimStatusReview1, api.ClaimStatusReview2, api.ClaimStatusReview3},
+	ClaimActionEdit:     {api.ClaimStatusDraft},
+	ClaimActionRevision: {api.ClaimStatusRevision},
+	ClaimActionReceipt:  {api.ClaimStatusReceipt},
+	ClaimActionApprove:  {api.ClaimStatusReview2, api.ClaimStatusReview3, api.ClaimStatusApproved},
+	ClaimActionDeny:     {api.ClaimStatusDenied},
+	ClaimActionWithdraw: {api.ClaimStatusWithdrawn},
+	ClaimActionAppeal:   {api.ClaimStatusReview1},
+	ClaimActionPay:      {api.ClaimStatusPaid},
+	ClaimActionReverse:  {api.ClaimStatusApproved},
+}
+
+// claimWorkflowRequiredActions are the actions the application depends on to move a Claim on from each status. A
+// workflow must have them for every status a Claim can reach from Draft.
+var claimWorkflowRequiredActions = map[api.ClaimStatus][]ClaimAction{
+	api.ClaimStatusDraft:    {ClaimActionSubmit},
+	api.ClaimStatusRevision: {ClaimActionSubmit},
+	api.ClaimStatusReceipt:  {ClaimActionSubmit},
+	api.ClaimStatusReview1:  {ClaimActionApprove},
+	api.ClaimStatusReview2:  {ClaimActionApprove},
+	api.ClaimStatusReview3:  {ClaimActionApprove},
+	api.ClaimStatusApproved: {ClaimActionPay},
+	api.ClaimStatusPaid:     {ClaimActionReverse},
+	api.ClaimStatusDenied:   {ClaimActionAppeal},
+}
+
+// defaultClaimWorkflowJSON is the workflow used unless CLAIM_WORKFLOW_FILE names another. A second signator must
+// approve a payout above 5000. It is embedded so that the default does not depend on a file deployed with the binary.
+//
+//go:embed claim-workflow.json
+var defaultClaimWorkflowJSON []byte
+
+func defaultClaimWorkflow() ClaimWorkflow {
+	var w ClaimWorkflow
+	if err := json.Unmarshal(defaultClaimWorkflowJSON, &w); err != nil {
+		panic("error parsing the default claim workflow, " + err.Error())
+	}
+	return w
+}
+
+// LoadClaimWorkflow reads the claim workflow from a JSON file and validates it. If filename is empty, the default
+// workflow is validated and used.
+func LoadClaimWorkflow(filename string) error {
+	w := defaultClaimWorkflow()
+
+	if filename != "" {
+		content, err := os.ReadFile(filename)
+		if err != nil {
+			return fmt.Errorf("error reading claim workflow file %s: %w", filename, err)
+		}
+
+		w = ClaimWorkflow{}
+		if err := json.Unmarshal(content, &w); err != nil {
+			return fmt.Errorf("error parsing claim workflow file %s: %w", filename, err)
+		}
+	}
+
+	if err := w.Validate(); err != nil {
+		return err
+	}
+
+	claimWorkflow = w
+	return nil
+}
+
+// Validate ensures the workflow refers only to known statuses, actions, roles, and payout options, includes the
+// actions the application depends on, and has an approval rule for every payout amount.
+func (w ClaimWorkflow) Validate() error {
+	seen := map[string]struct{}{}
+	for _, t := range w.Transitions {
+		if _, ok := ValidClaimStatus[t.From]; !ok {
+			return fmt.Errorf("claim workflow has an invalid status %q", t.From)
+		}
+		if _, ok := ValidClaimStatus[t.To]; !ok {
+			return fmt.Errorf("claim workflow has an invalid status %q", t.To)
+		}
+		if t.From == t.To {
+			return fmt.Errorf("claim workflow has a transition from %s to itself", t.From)
+		}
+
+		key := string(t.From) + ">" + string(t.To)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("claim workflow has more than one transition from %s to %s", t.From, t.To)
+		}
+		seen[key] = struct{}{}
+
+		if err := t.validateAction(); err != nil {
+			return err
+		}
+		key = string(t.From) + ":" + string(t.Action)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("claim workflow has more than one transition for %s from %s", t.Action, t.From)
+		}
+		seen[key] = struct{}{}
+
+		if err := validateClaimWorkflowRoles(t.Roles); err != nil {
+			return err
+		}
+		for _, o := range t.PayoutOptions {
+			if _, ok := ValidPayoutOptions[o]; !ok {
+				return fmt.Errorf("claim workflow has an invalid payout option %q", o)
+			}
+		}
+	}
+
+	for _, status := range w.reachableStatuses() {
+		for _, action := range claimWorkflowRequiredActions[status] {
+			if _, ok := w.transitionFor(status, action); !ok {
+				return fmt.Errorf("claim workflow must have a transition for %s from %s", action, status)
+			}
+		}
+	}
+
+	hasBaseRule := false
+	minPayouts := map[api.Currency]struct{}{}
+	for _, r := range w.Approvals {
+		if r.MinPayout < 0 {
+			return fmt.Errorf("claim workflow has a negative approval min_payout %d", r.MinPayout)
+		}
+		if r.MinPayout == 0 {
+			hasBaseRule = true
+		}
+		if _, ok := minPayouts[r.MinPayout]; ok {
+			return fmt.Errorf("claim workflow has more than one approval rule for min_payout %d", r.MinPayout)
+		}
+		minPayouts[r.MinPayout] = struct{}{}
+
+		if r.Approvals < 1 {
+			return fmt.Errorf("claim workflow approval rule for min_payout %d requires no approvals", r.MinPayout)
+		}
+		if len(r.Roles) == 0 {
+			return fmt.Errorf("claim workflow approval rule for min_payout %d has no roles", r.MinPayout)
+		}
+		if err := validateClaimWorkflowRoles(r.Roles); err != nil {
+			return err
+		}
+	}
+	if !hasBaseRule {
+		return fmt.Errorf("claim workflow must have an approval rule with a min_payout of 0")
+	}
+
+	return nil
+}
+
+// validateAction ensures the transition has a known action that may change a Claim to the transition's status. Only
+// an approval from Review3 gives a Claim its final approval.
+func (t ClaimWorkflowTransition) validateAction() error {
+	targets, ok := claimActionTargets[t.Action]
+	if !ok {
+		return fmt.Errorf("claim workflow has an invalid action %q", t.Action)
+	}
+
+	allowed := false
+	for _, s := range targets {
+		if s == t.To {
+			allowed = true
+		}
+	}
+	if t.Action == ClaimActionApprove && (t.From == api.ClaimStatusReview3) != (t.To == api.ClaimStatusApproved) {
+		allowed = false
+	}
+	if !allowed {
+		return fmt.Errorf("claim workflow transition for %s from %s may not go to %s", t.Action, t.From, t.To)
+	}
+	return nil
+}
+
+func validateClaimWorkflowRoles(roles []UserAppRole) error {
+	for _, role := range roles {
+		if _, ok := validUserAppRoles[role]; !ok {
+			return fmt.Errorf("claim workflow has an invalid role %q", role)
+		}
+	}
+	return nil
+}
+
+// transition finds the transition from one status to another
+func (w ClaimWorkflow) transition(from, to api.ClaimStatus) (ClaimWorkflowTransition, bool) {
+	for _, t := range w.Transitions {
+		if t.From == from && t.To == to {
+			return t, true
+		}
+	}
+	return ClaimWorkflowTransition{}, false
+}
+
+// transitionFor finds the transition for an action on a Claim with the given status
+func (w ClaimWorkflow) transitionFor(from api.ClaimStatus, action ClaimAction) (ClaimWorkflowTransition, bool) {
+	for _, t := range w.Transitions {
+		if t.From == from && t.Action == action {
+			return t, true
+		}
+	}
+	return ClaimWorkflowTransition{}, false
+}
+
+// reachableStatuses returns the statuses a Claim can reach from Draft, including Draft
+func (w ClaimWorkflow) reachableStatuses() []api.ClaimStatus {
+	targets := w.targets()
+	reached := map[api.ClaimStatus]struct{}{api.ClaimStatusDraft: {}}
+	statuses := []api.ClaimStatus{api.ClaimStatusDraft}
+	for i := 0; i < len(statuses); i++ {
+		for _, s := range targets[statuses[i]] {
+			if _, ok := reached[s]; !ok {
+				reached[s] = struct{}{}
+				statuses = append(statuses, s)
+			}
+		}
+	}
+	return statuses
+}
+
+// targets returns the statuses to which a claim may change from each status
+func (w ClaimWorkflow) targets() map[api.ClaimStatus][]api.ClaimStatus {
+	targets := map[api.ClaimStatus][]api.ClaimStatus{}
+	for s := range ValidClaimStatus {
+		targets[s] = []api.ClaimStatus{}
+	}
+	for _, t := range w.Transitions {
+		targets[t.From] = append(targets[t.From], t.To)
+	}
+	return targets
+}
+
+// approvalRule finds the rule for the final approval of a claim with the given total payout
+func (w ClaimWorkflow) approvalRule(payout api.Currency) ClaimApprovalRule {
+	rules := make([]ClaimApprovalRule, len(w.Approvals))
+	copy(rules, w.Approvals)
+	sort.Slice(rules, func(i, j int) bool { return rules[i].MinPayout > rules[j].MinPayout })
+
+	for _, r := range rules {
+		if payout >= r.MinPayout {
+			return r
+		}
+	}
+	return ClaimApprovalRule{Approvals: 1, Roles: []UserAppRole{AppRoleSteward, AppRoleSignator}}
+}
+
+// isRoleAllowed returns true if the role may make the change. System changes, which have no roles, are not
+// restricted.
+func (t ClaimWorkflowTransition) isRoleAllowed(role UserAppRole) bool {
+	return len(t.Roles) == 0 || isRoleInList(role, t.Roles)
+}
+
+// isPayoutOptionAllowed returns true if the change is allowed for the payout option
+func (t ClaimWorkflowTransition) isPayoutOptionAllowed(option api.PayoutOption) bool {
+	if len(t.PayoutOptions) == 0 {
+		return true
+	}
+	for _, o := range t.PayoutOptions {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
+func isRoleInList(role UserAppRole, roles []UserAppRole) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
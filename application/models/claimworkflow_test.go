@@ -0,0 +1,261 @@
+package models
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestClaimWorkflow_Validate() {
+	withTransition := func(t ClaimWorkflowTransition) ClaimWorkflow {
+		w := defaultClaimWorkflow()
+		w.Transitions = append(w.Transitions, t)
+		return w
+	}
+	withApprovals := func(rules ...ClaimApprovalRule) ClaimWorkflow {
+		w := defaultClaimWorkflow()
+		w.Approvals = rules
+		return w
+	}
+	without := func(remove func(ClaimWorkflowTransition) bool) ClaimWorkflow {
+		w := defaultClaimWorkflow()
+		var transitions []ClaimWorkflowTransition
+		for _, t := range w.Transitions {
+			if !remove(t) {
+				transitions = append(transitions, t)
+			}
+		}
+		w.Transitions = transitions
+		return w
+	}
+	withoutTransition := func(from, to api.ClaimStatus) ClaimWorkflow {
+		return without(func(t ClaimWorkflowTransition) bool { return t.From == from && t.To == to })
+	}
+	signators := []UserAppRole{AppRoleSignator}
+
+	tests := []struct {
+		name     string
+		workflow ClaimWorkflow
+		wantErr  string
+	}{
+		{
+			name:     "default",
+			workflow: defaultClaimWorkflow(),
+		},
+		{
+			name: "second signator above 5000",
+			workflow: withApprovals(
+				ClaimApprovalRule{Approvals: 1, Roles: signators},
+				ClaimApprovalRule{MinPayout: 500000, Approvals: 2, Roles: signators},
+			),
+		},
+		{
+			name:     "invalid status",
+			workflow: withTransition(ClaimWorkflowTransition{From: api.ClaimStatusDraft, To: "Review4"}),
+			wantErr:  `invalid status "Review4"`,
+		},
+		{
+			name:     "duplicate transition",
+			workflow: withTransition(ClaimWorkflowTransition{From: api.ClaimStatusDraft, Action: ClaimActionSubmit, To: api.ClaimStatusReview1}),
+			wantErr:  "more than one transition from Draft to Review1",
+		},
+		{
+			name:     "invalid action",
+			workflow: withTransition(ClaimWorkflowTransition{From: api.ClaimStatusWithdrawn, Action: "reopen", To: api.ClaimStatusDraft}),
+			wantErr:  `invalid action "reopen"`,
+		},
+		{
+			name:     "action to the wrong status",
+			workflow: withTransition(ClaimWorkflowTransition{From: api.ClaimStatusWithdrawn, Action: ClaimActionDeny, To: api.ClaimStatusDraft}),
+			wantErr:  "for deny from Withdrawn may not go to Draft",
+		},
+		{
+			name:     "final approval before Review3",
+			workflow: withTransition(ClaimWorkflowTransition{From: api.ClaimStatusReview2, Action: ClaimActionApprove, To: api.ClaimStatusApproved}),
+			wantErr:  "for approve from Review2 may not go to Approved",
+		},
+		{
+			name:     "duplicate action",
+			workflow: withTransition(ClaimWorkflowTransition{From: api.ClaimStatusReview1, Action: ClaimActionApprove, To: api.ClaimStatusReview2}),
+			wantErr:  "more than one transition for approve from Review1",
+		},
+		{
+			name:     "invalid role",
+			workflow: withTransition(ClaimWorkflowTransition{From: api.ClaimStatusWithdrawn, Action: ClaimActionEdit, To: api.ClaimStatusDraft, Roles: []UserAppRole{"Auditor"}}),
+			wantErr:  `invalid role "Auditor"`,
+		},
+		{
+			name:     "invalid payout option",
+			workflow: withTransition(ClaimWorkflowTransition{From: api.ClaimStatusWithdrawn, Action: ClaimActionEdit, To: api.ClaimStatusDraft, PayoutOptions: []api.PayoutOption{"Cash"}}),
+			wantErr:  `invalid payout option "Cash"`,
+		},
+		{
+			name:     "missing pay",
+			workflow: withoutTransition(api.ClaimStatusApproved, api.ClaimStatusPaid),
+			wantErr:  "must have a transition for pay from Approved",
+		},
+		{
+			name:     "missing reverse",
+			workflow: withoutTransition(api.ClaimStatusPaid, api.ClaimStatusApproved),
+			wantErr:  "must have a transition for reverse from Paid",
+		},
+		{
+			name:     "missing submit from Receipt",
+			workflow: withoutTransition(api.ClaimStatusReceipt, api.ClaimStatusReview2),
+			wantErr:  "must have a transition for submit from Receipt",
+		},
+		{
+			name:     "missing approve from Review2",
+			workflow: withoutTransition(api.ClaimStatusReview2, api.ClaimStatusReview3),
+			wantErr:  "must have a transition for approve from Review2",
+		},
+		{
+			name:     "missing appeal",
+			workflow: withoutTransition(api.ClaimStatusDenied, api.ClaimStatusReview1),
+			wantErr:  "must have a transition for appeal from Denied",
+		},
+		{
+			name: "no receipts",
+			workflow: without(func(t ClaimWorkflowTransition) bool {
+				return t.To == api.ClaimStatusReceipt || t.From == api.ClaimStatusReceipt
+			}),
+		},
+		{
+			name:     "no base approval rule",
+			workflow: withApprovals(ClaimApprovalRule{MinPayout: 100, Approvals: 1, Roles: signators}),
+			wantErr:  "must have an approval rule with a min_payout of 0",
+		},
+		{
+			name:     "no approvals required",
+			workflow: withApprovals(ClaimApprovalRule{Approvals: 0, Roles: signators}),
+			wantErr:  "requires no approvals",
+		},
+		{
+			name:     "approval rule without roles",
+			workflow: withApprovals(ClaimApprovalRule{Approvals: 1}),
+			wantErr:  "has no roles",
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			err := tt.workflow.Validate()
+			if tt.wantErr != "" {
+				ms.Error(err)
+				ms.Contains(err.Error(), tt.wantErr, "incorrect error")
+				return
+			}
+			ms.NoError(err)
+		})
+	}
+}
+
+func (ms *ModelSuite) TestLoadClaimWorkflow() {
+	defer func() { claimWorkflow = defaultClaimWorkflow() }()
+
+	file, err := os.CreateTemp("", "claim_workflow_*.json")
+	ms.NoError(err)
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString(`{
+		"transitions": [
+			{"from": "Draft", "action": "submit", "to": "Review1", "roles": ["Customer"]},
+			{"from": "Review1", "action": "approve", "to": "Review3", "roles": ["Steward"]},
+			{"from": "Review3", "action": "approve", "to": "Approved", "roles": ["Signator"]},
+			{"from": "Approved", "action": "pay", "to": "Paid"},
+			{"from": "Paid", "action": "reverse", "to": "Approved"}
+		],
+		"approvals": [
+			{"min_payout": 0, "approvals": 1, "roles": ["Signator"]},
+			{"min_payout": 500000, "approvals": 2, "roles": ["Signator"]}
+		]
+	}`)
+	ms.NoError(err)
+	ms.NoError(file.Close())
+
+	ms.NoError(LoadClaimWorkflow(file.Name()))
+	ms.Equal(5, len(claimWorkflow.Transitions), "incorrect number of transitions")
+	ms.Equal(2, claimWorkflow.approvalRule(600000).Approvals, "incorrect approvals above threshold")
+	ms.Equal(1, claimWorkflow.approvalRule(499999).Approvals, "incorrect approvals below threshold")
+
+	valid, err := isClaimTransitionValid(api.ClaimStatusReview1, api.ClaimStatusDenied)
+	ms.NoError(err)
+	ms.False(valid, "transition not in the loaded workflow should not be valid")
+
+	ms.Error(LoadClaimWorkflow(file.Name()+".missing"), "expected an error for a missing file")
+
+	ms.NoError(LoadClaimWorkflow(""))
+	ms.Equal(defaultClaimWorkflow(), claimWorkflow, "empty filename should restore the default workflow")
+
+	// the default workflow requires a second signator only above 5000
+	ms.Equal(1, claimWorkflow.approvalRule(500000).Approvals, "incorrect default approvals at 5000")
+	ms.Equal(2, claimWorkflow.approvalRule(500001).Approvals, "incorrect default approvals above 5000")
+	ms.Equal([]UserAppRole{AppRoleSignator}, claimWorkflow.approvalRule(500001).Roles,
+		"incorrect default roles above 5000")
+}
+
+func (ms *ModelSuite) TestClaim_ApproveWithThreshold() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	admins := CreateAdminUsers(ms.DB)
+	signator2 := CreateAdminUsers(ms.DB)[AppRoleSignator]
+
+	claim := f.Claims[0]
+	claim.ReviewerID = nulls.NewUUID(f.Users[0].ID)
+	claim.TotalPayout = 600000
+	claim = UpdateClaimStatus(ms.DB, claim, api.ClaimStatusReview3, "")
+
+	err := claim.Approve(CreateTestContext(admins[AppRoleSteward]), api.ClaimApproveInput{})
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimInvalidApprover, Category: api.CategoryUser}, err)
+
+	ms.NoError(claim.Approve(CreateTestContext(admins[AppRoleSignator]), api.ClaimApproveInput{}))
+	ms.Equal(api.ClaimStatusReview3, claim.Status, "claim should wait for a second approval")
+	ms.Equal(1, claim.approvalCount(ms.DB), "first approval was not recorded")
+
+	var histories ClaimHistories
+	ms.NoError(ms.DB.Where("claim_id = ? AND field_name = ?", claim.ID, FieldClaimApprovals).All(&histories))
+	ms.Equal(1, len(histories), "first approval is not in the claim history")
+	ms.Equal("1", histories[0].NewValue, "incorrect approval count in the claim history")
+
+	err = claim.Approve(CreateTestContext(admins[AppRoleSignator]), api.ClaimApproveInput{})
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimDuplicateApproval, Category: api.CategoryUser}, err)
+
+	ms.NoError(claim.Approve(CreateTestContext(signator2), api.ClaimApproveInput{}))
+	ms.Equal(api.ClaimStatusApproved, claim.Status, "claim should be approved after the second approval")
+}
+
+func (ms *ModelSuite) TestClaim_UpdateTransitionRole() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	claim := UpdateClaimStatus(ms.DB, f.Claims[0], api.ClaimStatusReview1, "")
+
+	claim.Status = api.ClaimStatusDenied
+	claim.StatusReason = "not covered"
+	err := claim.Update(CreateTestContext(f.Users[0]))
+	ms.EqualAppError(api.AppError{Key: api.ErrorClaimTransitionRole, Category: api.CategoryUser}, err)
+}
+
+func (ms *ModelSuite) TestClaim_SubmitForApprovalWorkflow() {
+	defer func() { claimWorkflow = defaultClaimWorkflow() }()
+
+	w := defaultClaimWorkflow()
+	for i, t := range w.Transitions {
+		if t.From == api.ClaimStatusDraft && t.Action == ClaimActionSubmit {
+			w.Transitions[i].To = api.ClaimStatusReview3
+		}
+	}
+	ms.NoError(w.Validate())
+	claimWorkflow = w
+
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	claim := f.Claims[0]
+	UpdateClaimItems(ms.DB, claim, UpdateClaimItemsParams{
+		PayoutOption:   api.PayoutOptionRepair,
+		IsRepairable:   true,
+		RepairEstimate: 1000,
+		FMV:            2000,
+	})
+
+	ms.NoError(claim.SubmitForApproval(CreateTestContext(f.Users[0])))
+	ms.Equal(api.ClaimStatusReview3, claim.Status, "claim should be submitted to the status given by the workflow")
+}
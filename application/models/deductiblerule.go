@@ -0,0 +1,241 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+var ValidDeductibleTypes = map[api.DeductibleType]struct{}{
+	api.DeductibleTypePercentage: {},
+	api.DeductibleTypeFlat:       {},
+}
+
+type DeductibleRules []DeductibleRule
+
+// DeductibleRule determines the deductible subtracted from the payout of a ClaimItem. Empty IncidentType and
+// PolicyType, and null RiskCategoryID, match any value.
+type DeductibleRule struct {
+	ID             uuid.UUID             `db:"id"`
+	IncidentType   api.ClaimIncidentType `db:"incident_type" validate:"claimIncidentType"`
+	PolicyType     api.PolicyType        `db:"policy_type" validate:"omitempty,policyType"`
+	RiskCategoryID nulls.UUID            `db:"risk_category_id"`
+	Type           api.DeductibleType    `db:"type" validate:"deductibleType"`
+	Percentage     float64               `db:"percentage" validate:"gte=0,lt=1"`
+	FlatAmount     api.Currency          `db:"flat_amount" validate:"min=0"`
+	MinimumAmount  api.Currency          `db:"minimum_amount" validate:"min=0"`
+	MaximumAmount  api.Currency          `db:"maximum_amount" validate:"min=0"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (d *DeductibleRule) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(d), nil
+}
+
+// Create validates and saves a new DeductibleRule
+func (d *DeductibleRule) Create(tx *pop.Connection) error {
+	if err := d.check(tx); err != nil {
+		return err
+	}
+	return create(tx, d)
+}
+
+// Update validates and saves changes to the DeductibleRule. ClaimItems keep the deductible calculated with the
+// previous version of the rule until their payout is recalculated.
+func (d *DeductibleRule) Update(tx *pop.Connection) error {
+	if err := d.check(tx); err != nil {
+		return err
+	}
+	return update(tx, d)
+}
+
+// check ensures the amounts are consistent with the rule type and that no other rule has the same criteria
+func (d *DeductibleRule) check(tx *pop.Connection) error {
+	if d.Type == api.DeductibleTypePercentage && d.Percentage == 0 {
+		err := errors.New("a percentage deductible rule requires a percentage")
+		return api.NewAppError(err, api.ErrorDeductibleRuleAmount, api.CategoryUser)
+	}
+	if d.Type == api.DeductibleTypeFlat && d.FlatAmount == 0 {
+		err := errors.New("a flat deductible rule requires a flat amount")
+		return api.NewAppError(err, api.ErrorDeductibleRuleAmount, api.CategoryUser)
+	}
+	if d.MaximumAmount > 0 && d.MaximumAmount < d.MinimumAmount {
+		err := errors.New("deductible rule maximum amount is less than its minimum amount")
+		return api.NewAppError(err, api.ErrorDeductibleRuleAmount, api.CategoryUser)
+	}
+
+	exists, err := tx.Where("incident_type = ? AND policy_type = ?", d.IncidentType, d.PolicyType).
+		Where("risk_category_id IS NOT DISTINCT FROM ?", d.RiskCategoryID).
+		Where("id != ?", d.ID).
+		Exists(&DeductibleRule{})
+	if err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if exists {
+		err := errors.New("a deductible rule with the same incident type, policy type, and risk category exists")
+		return api.NewAppError(err, api.ErrorDeductibleRuleDuplicate, api.CategoryUser)
+	}
+	return nil
+}
+
+func (d *DeductibleRule) GetID() uuid.UUID {
+	return d.ID
+}
+
+func (d *DeductibleRule) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return tx.Find(d, id)
+}
+
+// IsActorAllowedTo ensure the actor is an admin, since deductible rules are managed by the stewards
+func (d *DeductibleRule) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, r *http.Request) bool {
+	return actor.IsAdmin()
+}
+
+// All finds all DeductibleRules, ordered by their criteria
+func (d *DeductibleRules) All(tx *pop.Connection) error {
+	err := tx.Order("incident_type, policy_type, risk_category_id, created_at").All(d)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+// Amount returns the deductible for the given payout basis, which is the lesser of the claimed value and the
+// coverage amount. The deductible is never more than the payout basis.
+func (d *DeductibleRule) Amount(basis api.Currency) api.Currency {
+	var amount api.Currency
+	switch d.Type {
+	case api.DeductibleTypePercentage:
+		amount = api.Currency(math.Round(float64(basis) * d.Percentage))
+	case api.DeductibleTypeFlat:
+		amount = d.FlatAmount
+	}
+
+	if d.MinimumAmount > 0 && amount < d.MinimumAmount {
+		amount = d.MinimumAmount
+	}
+	if d.MaximumAmount > 0 && amount > d.MaximumAmount {
+		amount = d.MaximumAmount
+	}
+	if amount > basis {
+		amount = basis
+	}
+	return amount
+}
+
+// Description returns a human-readable description of the rule, e.g. "10% (minimum $50.00)"
+func (d *DeductibleRule) Description() string {
+	var limits []string
+	if d.MinimumAmount > 0 {
+		limits = append(limits, "minimum $"+d.MinimumAmount.String())
+	}
+	if d.MaximumAmount > 0 {
+		limits = append(limits, "maximum $"+d.MaximumAmount.String())
+	}
+
+	description := "$" + d.FlatAmount.String()
+	if d.Type == api.DeductibleTypePercentage {
+		description = percentString(d.Percentage)
+	}
+	if len(limits) > 0 {
+		description += " (" + strings.Join(limits, ", ") + ")"
+	}
+	return description
+}
+
+// specificity ranks how specific the rule's criteria are. IncidentType outranks PolicyType, which outranks
+// RiskCategoryID.
+func (d *DeductibleRule) specificity() int {
+	n := 0
+	if d.IncidentType != "" {
+		n += 4
+	}
+	if d.PolicyType != "" {
+		n += 2
+	}
+	if d.RiskCategoryID.Valid {
+		n++
+	}
+	return n
+}
+
+func (d *DeductibleRule) ConvertToAPI() api.DeductibleRule {
+	return api.DeductibleRule{
+		ID:             d.ID,
+		IncidentType:   d.IncidentType,
+		PolicyType:     d.PolicyType,
+		RiskCategoryID: convertUUIDToAPI(d.RiskCategoryID),
+		Type:           d.Type,
+		Percentage:     d.Percentage,
+		FlatAmount:     d.FlatAmount,
+		MinimumAmount:  d.MinimumAmount,
+		MaximumAmount:  d.MaximumAmount,
+		Description:    d.Description(),
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+	}
+}
+
+func (d *DeductibleRules) ConvertToAPI() api.DeductibleRules {
+	rules := make(api.DeductibleRules, len(*d))
+	for i, dd := range *d {
+		rules[i] = dd.ConvertToAPI()
+	}
+	return rules
+}
+
+// NewDeductibleRuleFromApiInput creates a new DeductibleRule from a DeductibleRuleInput. It is not saved.
+func NewDeductibleRuleFromApiInput(input api.DeductibleRuleInput) DeductibleRule {
+	rule := DeductibleRule{
+		IncidentType:  input.IncidentType,
+		PolicyType:    input.PolicyType,
+		Type:          input.Type,
+		Percentage:    input.Percentage,
+		FlatAmount:    input.FlatAmount,
+		MinimumAmount: input.MinimumAmount,
+		MaximumAmount: input.MaximumAmount,
+	}
+	if input.RiskCategoryID != nil {
+		rule.RiskCategoryID = nulls.NewUUID(*input.RiskCategoryID)
+	}
+	return rule
+}
+
+// findDeductibleRule returns the most specific DeductibleRule that matches the given criteria, or false if no rule
+// matches
+func findDeductibleRule(tx *pop.Connection, incidentType api.ClaimIncidentType, policyType api.PolicyType,
+	riskCategoryID uuid.UUID) (DeductibleRule, bool) {
+	var rules DeductibleRules
+	err := tx.Where("incident_type IN (?, '') AND policy_type IN (?, '')", incidentType, policyType).
+		Where("(risk_category_id = ? OR risk_category_id IS NULL)", riskCategoryID).
+		All(&rules)
+	if err != nil {
+		panic("database error finding deductible rules, " + err.Error())
+	}
+
+	best := -1
+	for i := range rules {
+		if best < 0 || rules[i].specificity() > rules[best].specificity() {
+			best = i
+		}
+	}
+	if best < 0 {
+		return DeductibleRule{}, false
+	}
+	return rules[best], true
+}
+
+// percentString formats a fraction as a percentage, e.g. "5%" or "33.33%"
+func percentString(fraction float64) string {
+	return fmt.Sprintf("%.4g%%", fraction*100)
+}
@@ -0,0 +1,252 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestDeductibleRule_Amount() {
+	tests := []struct {
+		name  string
+		rule  DeductibleRule
+		basis api.Currency
+		want  api.Currency
+	}{
+		{
+			name:  "percentage",
+			rule:  DeductibleRule{Type: api.DeductibleTypePercentage, Percentage: 0.1},
+			basis: 10000,
+			want:  1000,
+		},
+		{
+			name:  "percentage rounded",
+			rule:  DeductibleRule{Type: api.DeductibleTypePercentage, Percentage: 0.05},
+			basis: 1010,
+			want:  51,
+		},
+		{
+			name:  "percentage below minimum",
+			rule:  DeductibleRule{Type: api.DeductibleTypePercentage, Percentage: 0.1, MinimumAmount: 2500},
+			basis: 10000,
+			want:  2500,
+		},
+		{
+			name:  "percentage above maximum",
+			rule:  DeductibleRule{Type: api.DeductibleTypePercentage, Percentage: 0.1, MaximumAmount: 500},
+			basis: 10000,
+			want:  500,
+		},
+		{
+			name:  "flat",
+			rule:  DeductibleRule{Type: api.DeductibleTypeFlat, FlatAmount: 5000},
+			basis: 10000,
+			want:  5000,
+		},
+		{
+			name:  "flat limited to basis",
+			rule:  DeductibleRule{Type: api.DeductibleTypeFlat, FlatAmount: 5000},
+			basis: 3000,
+			want:  3000,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			ms.Equal(tt.want, tt.rule.Amount(tt.basis))
+		})
+	}
+}
+
+func (ms *ModelSuite) TestDeductibleRule_Description() {
+	tests := []struct {
+		name string
+		rule DeductibleRule
+		want string
+	}{
+		{
+			name: "percentage",
+			rule: DeductibleRule{Type: api.DeductibleTypePercentage, Percentage: 0.05},
+			want: "5%",
+		},
+		{
+			name: "percentage with limits",
+			rule: DeductibleRule{
+				Type:          api.DeductibleTypePercentage,
+				Percentage:    0.333333333,
+				MinimumAmount: 2500,
+				MaximumAmount: 50000,
+			},
+			want: "33.33% (minimum $25.00, maximum $500.00)",
+		},
+		{
+			name: "flat",
+			rule: DeductibleRule{Type: api.DeductibleTypeFlat, FlatAmount: 5000},
+			want: "$50.00",
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			ms.Equal(tt.want, tt.rule.Description())
+		})
+	}
+}
+
+func (ms *ModelSuite) TestDeductibleRule_Create() {
+	existing := DeductibleRule{
+		IncidentType: api.ClaimIncidentTypeTheft,
+		Type:         api.DeductibleTypeFlat,
+		FlatAmount:   5000,
+	}
+	ms.NoError(existing.Create(ms.DB))
+
+	tests := []struct {
+		name    string
+		rule    DeductibleRule
+		wantErr *api.AppError
+	}{
+		{
+			name: "invalid type",
+			rule: DeductibleRule{Type: "bogus", FlatAmount: 100},
+			wantErr: &api.AppError{
+				Key:      api.ErrorValidation,
+				Category: api.CategoryUser,
+			},
+		},
+		{
+			name: "missing percentage",
+			rule: DeductibleRule{Type: api.DeductibleTypePercentage, FlatAmount: 100},
+			wantErr: &api.AppError{
+				Key:      api.ErrorDeductibleRuleAmount,
+				Category: api.CategoryUser,
+			},
+		},
+		{
+			name: "maximum less than minimum",
+			rule: DeductibleRule{
+				Type:          api.DeductibleTypePercentage,
+				Percentage:    0.1,
+				MinimumAmount: 500,
+				MaximumAmount: 100,
+			},
+			wantErr: &api.AppError{
+				Key:      api.ErrorDeductibleRuleAmount,
+				Category: api.CategoryUser,
+			},
+		},
+		{
+			name: "duplicate",
+			rule: DeductibleRule{
+				IncidentType: api.ClaimIncidentTypeTheft,
+				Type:         api.DeductibleTypePercentage,
+				Percentage:   0.1,
+			},
+			wantErr: &api.AppError{
+				Key:      api.ErrorDeductibleRuleDuplicate,
+				Category: api.CategoryUser,
+			},
+		},
+		{
+			name: "good",
+			rule: DeductibleRule{
+				IncidentType: api.ClaimIncidentTypeTheft,
+				PolicyType:   api.PolicyTypeTeam,
+				Type:         api.DeductibleTypePercentage,
+				Percentage:   0.1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Create(ms.DB)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+		})
+	}
+
+	// updating a rule does not conflict with itself
+	existing.FlatAmount = 7500
+	ms.NoError(existing.Update(ms.DB))
+}
+
+func (ms *ModelSuite) Test_findDeductibleRule() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{})
+	riskCategoryID := f.Items[0].RiskCategoryID
+
+	newRule := func(incidentType api.ClaimIncidentType, policyType api.PolicyType, riskCategoryID nulls.UUID) DeductibleRule {
+		rule := DeductibleRule{
+			IncidentType:   incidentType,
+			PolicyType:     policyType,
+			RiskCategoryID: riskCategoryID,
+			Type:           api.DeductibleTypeFlat,
+			FlatAmount:     100,
+		}
+		ms.NoError(rule.Create(ms.DB))
+		return rule
+	}
+
+	global := newRule("", "", nulls.UUID{})
+	risk := newRule("", "", nulls.NewUUID(riskCategoryID))
+	team := newRule("", api.PolicyTypeTeam, nulls.UUID{})
+	theft := newRule(api.ClaimIncidentTypeTheft, "", nulls.UUID{})
+	theftTeam := newRule(api.ClaimIncidentTypeTheft, api.PolicyTypeTeam, nulls.UUID{})
+
+	tests := []struct {
+		name           string
+		incidentType   api.ClaimIncidentType
+		policyType     api.PolicyType
+		riskCategoryID uuid.UUID
+		want           DeductibleRule
+	}{
+		{
+			name:         "no specific rule",
+			incidentType: api.ClaimIncidentTypeFireDamage,
+			policyType:   api.PolicyTypeHousehold,
+			want:         global,
+		},
+		{
+			name:           "risk category",
+			incidentType:   api.ClaimIncidentTypeFireDamage,
+			policyType:     api.PolicyTypeHousehold,
+			riskCategoryID: riskCategoryID,
+			want:           risk,
+		},
+		{
+			name:           "policy type outranks risk category",
+			incidentType:   api.ClaimIncidentTypeFireDamage,
+			policyType:     api.PolicyTypeTeam,
+			riskCategoryID: riskCategoryID,
+			want:           team,
+		},
+		{
+			name:           "incident type outranks policy type",
+			incidentType:   api.ClaimIncidentTypeTheft,
+			policyType:     api.PolicyTypeHousehold,
+			riskCategoryID: riskCategoryID,
+			want:           theft,
+		},
+		{
+			name:           "incident type and policy type",
+			incidentType:   api.ClaimIncidentTypeTheft,
+			policyType:     api.PolicyTypeTeam,
+			riskCategoryID: riskCategoryID,
+			want:           theftTeam,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, ok := findDeductibleRule(ms.DB, tt.incidentType, tt.policyType, tt.riskCategoryID)
+			ms.True(ok, "no rule found")
+			ms.Equal(tt.want.ID, got.ID, "incorrect rule found")
+		})
+	}
+
+	ms.NoError(ms.DB.Destroy(&global))
+	_, ok := findDeductibleRule(ms.DB, api.ClaimIncidentTypeFireDamage, api.PolicyTypeHousehold, uuid.Nil)
+	ms.False(ok, "found a rule that should not match")
+}
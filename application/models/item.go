@@ -112,10 +112,29 @@ func (i *Item) Update(ctx context.Context) error {
 	}
 
 	if oldItem.CoverageAmount != i.CoverageAmount {
-		amount := i.calculatePremiumChange(time.Now().UTC(), oldItem.CoverageAmount)
+		now := time.Now().UTC()
+		amount := i.calculatePremiumChange(tx, now, oldItem.CoverageAmount)
 		if err := i.CreateLedgerEntry(Tx(ctx), LedgerEntryTypeCoverageChange, amount); err != nil {
 			return err
 		}
+
+		oldCoverage, newCoverage := 0, 0
+		if oldItem.CoverageStatus == api.ItemCoverageStatusApproved {
+			oldCoverage = oldItem.CoverageAmount
+		}
+		if i.CoverageStatus == api.ItemCoverageStatusApproved {
+			newCoverage = i.CoverageAmount
+		}
+		prorate := func(v int) int { return domain.CalculatePartialYearValue(v, now) }
+		if err := i.createPremiumMinimumEntry(tx, oldCoverage, newCoverage, prorate); err != nil {
+			return err
+		}
+	}
+
+	if isItemStatusInQueue(oldItem.CoverageStatus) && !isItemStatusInQueue(i.CoverageStatus) {
+		if err := clearQueueAssignment(tx, "item_id", i.ID); err != nil {
+			return err
+		}
 	}
 
 	return update(tx, i)
@@ -279,7 +298,13 @@ func (i *Item) SafeDeleteOrInactivate(ctx context.Context) error {
 			return nil
 		}
 
-		return i.CreateLedgerEntry(Tx(ctx), LedgerEntryTypeCoverageRefund, i.calculateCancellationCredit(now))
+		tx := Tx(ctx)
+		if err := i.CreateLedgerEntry(tx, LedgerEntryTypeCoverageRefund, i.calculateCancellationCredit(tx, now)); err != nil {
+			return err
+		}
+
+		prorate := func(v int) int { return i.proratedRefund(now, v) }
+		return i.createPremiumMinimumEntry(tx, i.CoverageAmount, 0, prorate)
 
 	case api.ItemCoverageStatusDraft, api.ItemCoverageStatusRevision, api.ItemCoverageStatusPending:
 		tx := Tx(ctx)
@@ -350,6 +375,10 @@ func (i *Item) Inactivate(ctx context.Context) error {
 // IsActorAllowedTo ensure the actor is either an admin, or a member of this policy to perform any permission
 func (i *Item) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, req *http.Request) bool {
 	isAdmin := actor.IsAdmin()
+	if sub == api.ResourceAssignment {
+		return isAdmin
+	}
+
 	if !isItemActionAllowed(isAdmin, i.CoverageStatus, perm, sub) {
 		return false
 	}
@@ -419,6 +448,11 @@ func isItemTransitionValid(status1, status2 api.ItemCoverageStatus) bool {
 //  Otherwise, it checks whether the item can be acted on using a certain action based on its
 //    current coverage status and "sub-resource" (e.g. submit, approve, ...)
 func isItemActionAllowed(actorIsAdmin bool, oldStatus api.ItemCoverageStatus, perm Permission, sub SubResource) bool {
+	// The history of an item can be viewed whatever its status
+	if sub == api.ResourceHistory {
+		return perm == PermissionView
+	}
+
 	switch oldStatus {
 
 	// An item with Draft or Revision coverage status can have an update done on it itself or a create done on its "submit"
@@ -583,8 +617,15 @@ func (i *Item) Approve(ctx context.Context, doEmitEvent bool) error {
 		emitEvent(e)
 	}
 
-	amount := i.CalculateProratedPremium(time.Now().UTC())
-	return i.CreateLedgerEntry(Tx(ctx), LedgerEntryTypeNewCoverage, amount)
+	tx := Tx(ctx)
+	now := time.Now().UTC()
+	amount := i.CalculateProratedPremium(tx, now)
+	if err := i.CreateLedgerEntry(tx, LedgerEntryTypeNewCoverage, amount); err != nil {
+		return err
+	}
+
+	prorate := func(v int) int { return domain.CalculatePartialYearValue(v, now) }
+	return i.createPremiumMinimumEntry(tx, 0, i.CoverageAmount, prorate)
 }
 
 // Deny takes the item from Pending coverage status to Denied.
@@ -672,8 +713,8 @@ func (i *Item) ConvertToAPI(tx *pop.Connection) api.Item {
 		StatusReason:          i.StatusReason,
 		CoverageStartDate:     i.CoverageStartDate.Format(domain.DateFormat),
 		CoverageEndDate:       coverageEndDate,
-		AnnualPremium:         i.CalculateAnnualPremium(),
-		ProratedAnnualPremium: i.CalculateProratedPremium(time.Now().UTC()),
+		AnnualPremium:         i.CalculateAnnualPremium(tx),
+		ProratedAnnualPremium: i.CalculateProratedPremium(tx, time.Now().UTC()),
 		CreatedAt:             i.CreatedAt,
 		UpdatedAt:             i.UpdatedAt,
 	}
@@ -748,6 +789,24 @@ func (i *Items) InactivateApprovedButEnded(ctx context.Context) error {
 	return nil
 }
 
+var itemListOptions = listOptions{
+	table: "items",
+	sortColumns: map[string]string{
+		"created_at":      "created_at",
+		"updated_at":      "updated_at",
+		"name":            "name",
+		"coverage_amount": "coverage_amount",
+		"coverage_status": "coverage_status",
+	},
+	defaultSort:   "created_at",
+	filterColumns: map[string]string{"coverage_status": "coverage_status"},
+}
+
+// ByPolicy finds the items, or a page of the items, on a policy
+func (i *Items) ByPolicy(tx *pop.Connection, policyID uuid.UUID, query api.Query) (api.Meta, error) {
+	return findList(tx.Where("policy_id = ?", policyID), query, itemListOptions, i)
+}
+
 func (i *Items) ConvertToAPI(tx *pop.Connection) api.Items {
 	apiItems := make(api.Items, len(*i))
 	for j, ii := range *i {
@@ -757,66 +816,147 @@ func (i *Items) ConvertToAPI(tx *pop.Connection) api.Items {
 	return apiItems
 }
 
-// CalculateAnnualPremium returns the rounded product of the item's CoverageAmount and the PremiumFactor
-func (i *Item) CalculateAnnualPremium() api.Currency {
-	p := int(math.Round(float64(i.CoverageAmount) * domain.Env.PremiumFactor))
+// CalculateAnnualPremium returns the annual premium of the item for the current year
+func (i *Item) CalculateAnnualPremium(tx *pop.Connection) api.Currency {
+	return i.CalculateAnnualPremiumForYear(tx, time.Now().UTC().Year())
+}
+
+// CalculateAnnualPremiumForYear returns the rounded product of the item's CoverageAmount and the premium rate in
+// effect for the item's coverage in the given year
+func (i *Item) CalculateAnnualPremiumForYear(tx *pop.Connection, year int) api.Currency {
+	rate := premiumFactor(tx, i.CategoryID, i.RiskCategoryID, i.premiumRateDate(year))
+	p := int(math.Round(float64(i.CoverageAmount) * rate))
 	return api.Currency(p)
 }
 
-func (i *Item) CalculateProratedPremium(t time.Time) api.Currency {
-	p := domain.CalculatePartialYearValue(int(i.CalculateAnnualPremium()), t)
+// CalculateProratedPremium returns the annual premium prorated from the given date to the end of the year
+func (i *Item) CalculateProratedPremium(tx *pop.Connection, t time.Time) api.Currency {
+	p := domain.CalculatePartialYearValue(int(i.CalculateAnnualPremiumForYear(tx, t.Year())), t)
 	return api.Currency(p)
 }
 
+// premiumRateDate returns the date that determines the premium rate of the item's coverage in the given year. This
+// is the first day of the year, or the coverage start date if coverage started later, so that a rate change applies
+// at the next renewal and not to coverage already billed.
+func (i *Item) premiumRateDate(year int) time.Time {
+	date := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	if i.CoverageStartDate.After(date) {
+		return i.CoverageStartDate
+	}
+	return date
+}
+
 // True if coverage on the item started in a previous year and the current
 //  month is January.
 func (i *Item) shouldGiveFullYearRefund(t time.Time) bool {
 	return i.CoverageStartDate.Year() < t.Year() && t.Month() == 1
 }
 
-func (i *Item) calculateCancellationCredit(t time.Time) api.Currency {
+func (i *Item) calculateCancellationCredit(tx *pop.Connection, t time.Time) api.Currency {
+	return api.Currency(-1 * i.proratedRefund(t, int(i.CalculateAnnualPremiumForYear(tx, t.Year()))))
+}
+
+// proratedRefund returns the portion of an annual value, such as the annual premium, that is refunded if the
+// coverage is cancelled at the given time
+func (i *Item) proratedRefund(t time.Time, annualValue int) int {
 	// If we're in December already, then no credit
 	if t.Month() == 12 {
 		return 0
 	}
 
-	var premium int
-
 	// If the coverage was from a previous year and today is still in January,
 	//   give a full year's refund.
 	if i.shouldGiveFullYearRefund(t) {
-		premium = int(i.CalculateAnnualPremium())
-		return api.Currency(-1 * premium)
+		return annualValue
 	}
 
 	// Otherwise, give credit for the following calendar months
 
 	// Coverage started previous year, but it is now later than January.
 	// So, a full year's premium would have been charged.
-	if i.CoverageStartDate.Year() < t.Year() {
-		premium = int(i.CalculateAnnualPremium())
+	value := annualValue
 
-		// Coverage started this year, so a partial year's premium would have been charged.
-	} else {
-		premium = int(i.CalculateProratedPremium(i.CoverageStartDate))
+	// Coverage started this year, so a partial year's premium would have been charged.
+	if i.CoverageStartDate.Year() >= t.Year() {
+		value = domain.CalculatePartialYearValue(annualValue, i.CoverageStartDate)
 	}
 
-	credit := domain.CalculateMonthlyRefundValue(premium, t)
-	return api.Currency(-1 * credit)
+	return domain.CalculateMonthlyRefundValue(value, t)
 }
 
-func (i *Item) calculatePremiumChange(t time.Time, oldCoverageAmount int) api.Currency {
-	oldItem := Item{CoverageAmount: oldCoverageAmount}
+func (i *Item) calculatePremiumChange(tx *pop.Connection, t time.Time, oldCoverageAmount int) api.Currency {
+	oldItem := *i
+	oldItem.CoverageAmount = oldCoverageAmount
 
-	oldPremium := oldItem.CalculateAnnualPremium()
+	oldPremium := oldItem.CalculateAnnualPremiumForYear(tx, t.Year())
 	credit := domain.CalculatePartialYearValue(int(oldPremium), t)
 
-	newPremium := i.CalculateAnnualPremium()
+	newPremium := i.CalculateAnnualPremiumForYear(tx, t.Year())
 	charge := domain.CalculatePartialYearValue(int(newPremium), t)
 
 	return api.Currency(charge - credit)
 }
 
+// premiumMinimumTopUp returns the amount needed to bring an annual premium up to the PremiumMinimum. No top-up is
+// needed if nothing is covered.
+func premiumMinimumTopUp(annualPremium api.Currency, isCovered bool) api.Currency {
+	if !isCovered || int(annualPremium) >= domain.Env.PremiumMinimum {
+		return 0
+	}
+	return api.Currency(domain.Env.PremiumMinimum) - annualPremium
+}
+
+// calculatePremiumMinimumChange returns the change in the PremiumMinimum top-up when the item's coverage amount
+// changes from oldCoverageAmount to newCoverageAmount. A coverage amount of zero means the item is not covered.
+// Depending on the PremiumMinimumScope, the minimum applies to the item alone or to all the covered items on the
+// policy. The annual top-up is prorated by the given function.
+func (i *Item) calculatePremiumMinimumChange(tx *pop.Connection, oldCoverageAmount, newCoverageAmount int,
+	prorate func(int) int) api.Currency {
+	oldItem, newItem := *i, *i
+	oldItem.CoverageAmount = oldCoverageAmount
+	newItem.CoverageAmount = newCoverageAmount
+	oldPremium, newPremium := oldItem.CalculateAnnualPremium(tx), newItem.CalculateAnnualPremium(tx)
+	wasCovered, isCovered := oldCoverageAmount > 0, newCoverageAmount > 0
+
+	if domain.Env.PremiumMinimumScope == domain.PremiumMinimumScopePolicy {
+		otherPremium, otherCount := i.otherCoveredPremium(tx)
+		oldPremium += otherPremium
+		newPremium += otherPremium
+		wasCovered = wasCovered || otherCount > 0
+		isCovered = isCovered || otherCount > 0
+	}
+
+	oldTopUp := prorate(int(premiumMinimumTopUp(oldPremium, wasCovered)))
+	newTopUp := prorate(int(premiumMinimumTopUp(newPremium, isCovered)))
+	return api.Currency(newTopUp - oldTopUp)
+}
+
+// createPremiumMinimumEntry creates a ledger entry for the change in the PremiumMinimum top-up, if any, when the
+// item's coverage amount changes. See calculatePremiumMinimumChange.
+func (i *Item) createPremiumMinimumEntry(tx *pop.Connection, oldCoverageAmount, newCoverageAmount int,
+	prorate func(int) int) error {
+	amount := i.calculatePremiumMinimumChange(tx, oldCoverageAmount, newCoverageAmount, prorate)
+	if amount == 0 {
+		return nil
+	}
+	return i.CreateLedgerEntry(tx, LedgerEntryTypePremiumMinimum, amount)
+}
+
+// otherCoveredPremium returns the total annual premium and the number of the other covered items on the item's policy
+func (i *Item) otherCoveredPremium(tx *pop.Connection) (api.Currency, int) {
+	var items Items
+	if err := tx.Where("policy_id = ? AND id != ? AND coverage_status = ?",
+		i.PolicyID, i.ID, api.ItemCoverageStatusApproved).All(&items); err != nil {
+		panic("database error loading policy items, " + err.Error())
+	}
+
+	var premium api.Currency
+	for _, item := range items {
+		premium += item.CalculateAnnualPremium(tx)
+	}
+	return premium, len(items)
+}
+
 // NewItemFromApiInput creates a new `Item` from a `ItemCreate`.
 func NewItemFromApiInput(c buffalo.Context, input api.ItemCreate, policyID uuid.UUID) (Item, error) {
 	item := Item{}
@@ -905,6 +1045,14 @@ func (i *Item) SetAccountablePerson(tx *pop.Connection, id uuid.UUID) error {
 }
 
 func (i *Item) CreateLedgerEntry(tx *pop.Connection, entryType LedgerEntryType, amount api.Currency) error {
+	_, err := i.createLedgerEntry(tx, entryType, amount, nulls.UUID{})
+	return err
+}
+
+// createLedgerEntry creates a ledger entry for the item and returns it. The renewalEntryID links a PremiumMinimum
+// top-up to the coverage renewal entry it was billed with.
+func (i *Item) createLedgerEntry(tx *pop.Connection, entryType LedgerEntryType, amount api.Currency,
+	renewalEntryID nulls.UUID) (LedgerEntry, error) {
 	i.LoadPolicy(tx, false)
 	i.LoadRiskCategory(tx, false)
 	i.Policy.LoadEntityCode(tx, false)
@@ -916,9 +1064,10 @@ func (i *Item) CreateLedgerEntry(tx *pop.Connection, entryType LedgerEntryType,
 	le.Amount = amount
 	le.FirstName = name.First
 	le.LastName = name.Last
+	le.RenewalEntryID = renewalEntryID
 
 	if err := le.Create(tx); err != nil {
-		return err
+		return le, err
 	}
 
 	oldPaidYear := i.PaidThroughYear
@@ -930,10 +1079,10 @@ func (i *Item) CreateLedgerEntry(tx *pop.Connection, entryType LedgerEntryType,
 	}
 
 	if oldPaidYear != i.PaidThroughYear {
-		return update(tx, i)
+		return le, update(tx, i)
 	}
 
-	return nil
+	return le, nil
 }
 
 // GetAccountablePersonName gets the name of the accountable person. In case of error, empty strings
@@ -1009,6 +1158,7 @@ func (i *Item) canBeUpdated(tx *pop.Connection) bool {
 		api.ClaimStatusDraft,
 		api.ClaimStatusPaid,
 		api.ClaimStatusDenied,
+		api.ClaimStatusWithdrawn,
 	}
 
 	var claims Claims
@@ -232,6 +232,22 @@ func (ms *ModelSuite) Test_isItemActionAllowed() {
 			subRes:       api.ResourceDeny,
 			want:         false,
 		},
+		{
+			name:         "inactive with view and history sub resource - YES",
+			actorIsAdmin: false,
+			startStatus:  api.ItemCoverageStatusInactive,
+			permission:   PermissionView,
+			subRes:       api.ResourceHistory,
+			want:         true,
+		},
+		{
+			name:         "draft with create and history sub resource - NO",
+			actorIsAdmin: true,
+			startStatus:  api.ItemCoverageStatusDraft,
+			permission:   PermissionCreate,
+			subRes:       api.ResourceHistory,
+			want:         false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -787,7 +803,7 @@ func (ms *ModelSuite) TestItem_calculateAnnualPremium() {
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
 			item := Item{CoverageAmount: tt.coverage}
-			got := item.CalculateAnnualPremium()
+			got := item.CalculateAnnualPremium(ms.DB)
 			ms.Equal(api.Currency(tt.want), got)
 		})
 	}
@@ -817,7 +833,7 @@ func (ms *ModelSuite) TestItem_calculateProratedPremium() {
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
 			item := Item{CoverageAmount: tt.coverage}
-			got := item.CalculateProratedPremium(now)
+			got := item.CalculateProratedPremium(ms.DB, now)
 			ms.Equal(api.Currency(tt.want), got)
 		})
 	}
@@ -885,7 +901,7 @@ func (ms *ModelSuite) TestItem_calculateCancellationCredit() {
 				CoverageAmount:    tt.coverage,
 				CoverageStartDate: tt.coverageStartDate,
 			}
-			got := item.calculateCancellationCredit(tt.testTime)
+			got := item.calculateCancellationCredit(ms.DB, tt.testTime)
 			ms.Equal(api.Currency(tt.want), got)
 		})
 	}
@@ -925,12 +941,125 @@ func (ms *ModelSuite) TestItem_calculatePremiumChange() {
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
 			item := Item{CoverageAmount: tt.coverage}
-			got := item.calculatePremiumChange(now, tt.oldCoverage)
+			got := item.calculatePremiumChange(ms.DB, now, tt.oldCoverage)
 			ms.Equal(api.Currency(tt.want), got)
 		})
 	}
 }
 
+func (ms *ModelSuite) TestItem_calculatePremiumMinimumChange() {
+	domain.Env.PremiumFactor = 0.02
+	minimum := domain.Env.PremiumMinimum
+	scope := domain.Env.PremiumMinimumScope
+	defer func() {
+		domain.Env.PremiumMinimum = minimum
+		domain.Env.PremiumMinimumScope = scope
+	}()
+	domain.Env.PremiumMinimum = 2500
+
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	other := f.Items[1]
+	other.CoverageAmount = 50000 // premium of 1000
+	ms.NoError(ms.DB.Update(&other))
+	UpdateItemStatus(ms.DB, other, api.ItemCoverageStatusApproved, "")
+
+	fullYear := func(v int) int { return v }
+
+	tests := []struct {
+		name        string
+		scope       string
+		oldCoverage int
+		newCoverage int
+		want        api.Currency
+	}{
+		{
+			name:        "item, new coverage below minimum",
+			scope:       domain.PremiumMinimumScopeItem,
+			oldCoverage: 0,
+			newCoverage: 100000, // premium of 2000
+			want:        500,
+		},
+		{
+			name:        "item, new coverage above minimum",
+			scope:       domain.PremiumMinimumScopeItem,
+			oldCoverage: 0,
+			newCoverage: 200000,
+			want:        0,
+		},
+		{
+			name:        "item, coverage increase",
+			scope:       domain.PremiumMinimumScopeItem,
+			oldCoverage: 50000,
+			newCoverage: 100000,
+			want:        -1000,
+		},
+		{
+			name:        "item, cancellation",
+			scope:       domain.PremiumMinimumScopeItem,
+			oldCoverage: 100000,
+			newCoverage: 0,
+			want:        -500,
+		},
+		{
+			name:        "policy, new coverage below minimum",
+			scope:       domain.PremiumMinimumScopePolicy,
+			oldCoverage: 0,
+			newCoverage: 50000,
+			want:        -1000,
+		},
+		{
+			name:        "policy, new coverage above minimum",
+			scope:       domain.PremiumMinimumScopePolicy,
+			oldCoverage: 0,
+			newCoverage: 100000,
+			want:        -1500,
+		},
+		{
+			name:        "policy, cancellation",
+			scope:       domain.PremiumMinimumScopePolicy,
+			oldCoverage: 50000,
+			newCoverage: 0,
+			want:        1000,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			domain.Env.PremiumMinimumScope = tt.scope
+			got := f.Items[0].calculatePremiumMinimumChange(ms.DB, tt.oldCoverage, tt.newCoverage, fullYear)
+			ms.Equal(tt.want, got)
+		})
+	}
+}
+
+func (ms *ModelSuite) TestItem_Approve_PremiumMinimum() {
+	domain.Env.PremiumFactor = 0.02
+	scope := domain.Env.PremiumMinimumScope
+	defer func() { domain.Env.PremiumMinimumScope = scope }()
+	domain.Env.PremiumMinimumScope = domain.PremiumMinimumScopeItem
+
+	f := CreateItemFixtures(ms.DB, FixturesConfig{})
+	item := f.Items[0]
+	item.CoverageAmount = domain.Env.PremiumMinimum // premium is well below the minimum
+	ms.NoError(ms.DB.Update(&item))
+
+	ctx := CreateTestContext(f.Users[0])
+	ms.NoError(item.Approve(ctx, false))
+
+	var entries LedgerEntries
+	ms.NoError(ms.DB.Where("item_id = ?", item.ID).All(&entries))
+	ms.Equal(2, len(entries), "incorrect number of ledger entries")
+
+	now := time.Now().UTC()
+	wantTopUp := domain.CalculatePartialYearValue(domain.Env.PremiumMinimum-int(item.CalculateAnnualPremium(ms.DB)), now)
+	var topUp LedgerEntry
+	for _, e := range entries {
+		if e.Type == LedgerEntryTypePremiumMinimum {
+			topUp = e
+		}
+	}
+	ms.Equal(api.Currency(wantTopUp), topUp.Amount, "incorrect premium minimum top-up")
+}
+
 func (ms *ModelSuite) TestItem_CreateLedgerEntry() {
 	f := CreateItemFixtures(ms.DB, FixturesConfig{})
 	item := f.Items[0]
@@ -941,7 +1070,7 @@ func (ms *ModelSuite) TestItem_CreateLedgerEntry() {
 	ms.NoError(item.SetAccountablePerson(ms.DB, f.Users[0].ID))
 	ms.NoError(item.Update(ctx))
 
-	amount := item.CalculateProratedPremium(time.Now().UTC())
+	amount := item.CalculateProratedPremium(ms.DB, time.Now().UTC())
 
 	ms.NoError(item.CreateLedgerEntry(ms.DB, LedgerEntryTypeNewCoverage, amount))
 
@@ -1205,8 +1334,8 @@ func (ms *ModelSuite) TestItem_ConvertToAPI() {
 	ms.Equal(item.UpdatedAt, got.UpdatedAt, "UpdatedAt is not correct")
 	ms.Equal(item.Category.ConvertToAPI(ms.DB), got.Category, "Category is not correct")
 	ms.Equal(item.RiskCategory.ConvertToAPI(), got.RiskCategory, "RiskCategory is not correct")
-	ms.Equal(item.CalculateAnnualPremium(), got.AnnualPremium, "AnnualPremium is not correct")
-	ms.Equal(item.CalculateProratedPremium(time.Now().UTC()), got.ProratedAnnualPremium,
+	ms.Equal(item.CalculateAnnualPremium(ms.DB), got.AnnualPremium, "AnnualPremium is not correct")
+	ms.Equal(item.CalculateProratedPremium(ms.DB, time.Now().UTC()), got.ProratedAnnualPremium,
 		"ProratedAnnualPremium is not correct")
 	ms.Equal(item.PolicyDependentID.UUID, got.AccountablePerson.ID, "AccountablePerson ID is not correct")
 	ms.Equal(fixtures.PolicyDependents[0].GetName().String(), got.AccountablePerson.Name,
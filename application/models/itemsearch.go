@@ -0,0 +1,158 @@
+package models
+
+import (
+	"strconv"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+// ItemSearch holds the criteria for a search of the items on all policies. Empty fields are not used.
+type ItemSearch struct {
+	CoverageStatuses  []api.ItemCoverageStatus
+	CategoryID        nulls.UUID
+	RiskCategoryID    nulls.UUID
+	MinCoverageAmount nulls.Int
+	MaxCoverageAmount nulls.Int
+	Country           string
+	InStorage         nulls.Bool
+
+	// CoveredFrom and CoveredTo limit the search to items with coverage at any time from the first date to the last
+	CoveredFrom nulls.Time
+	CoveredTo   nulls.Time
+}
+
+// Search finds a page of the items matching the search criteria
+func (i *Items) Search(tx *pop.Connection, search ItemSearch, query api.Query) (api.Meta, error) {
+	return findPage(search.query(tx), query, itemListOptions, i)
+}
+
+// SearchAll finds all the items matching the search criteria, oldest first, for export. If there are too many to
+// export, an error is returned.
+func (i *Items) SearchAll(tx *pop.Connection, search ItemSearch) error {
+	return findExport(search.query(tx).Order("items.created_at ASC, items.id ASC"), i)
+}
+
+func (s ItemSearch) query(tx *pop.Connection) *pop.Query {
+	q := tx.Q()
+
+	if len(s.CoverageStatuses) > 0 {
+		q = q.Where("items.coverage_status IN (?)", s.CoverageStatuses)
+	}
+	if s.CategoryID.Valid {
+		q = q.Where("items.category_id = ?", s.CategoryID.UUID)
+	}
+	if s.RiskCategoryID.Valid {
+		q = q.Where("items.risk_category_id = ?", s.RiskCategoryID.UUID)
+	}
+	if s.MinCoverageAmount.Valid {
+		q = q.Where("items.coverage_amount >= ?", s.MinCoverageAmount.Int)
+	}
+	if s.MaxCoverageAmount.Valid {
+		q = q.Where("items.coverage_amount <= ?", s.MaxCoverageAmount.Int)
+	}
+	if s.Country != "" {
+		q = q.Where("LOWER(items.country) = LOWER(?)", s.Country)
+	}
+	if s.InStorage.Valid {
+		q = q.Where("items.in_storage = ?", s.InStorage.Bool)
+	}
+	if s.CoveredFrom.Valid {
+		q = q.Where("(items.coverage_end_date IS NULL OR items.coverage_end_date >= ?)", s.CoveredFrom.Time)
+	}
+	if s.CoveredTo.Valid {
+		q = q.Where("items.coverage_start_date <= ?", s.CoveredTo.Time)
+	}
+
+	return q
+}
+
+var itemsCsvHeader = []string{
+	"Name", "Coverage Status", "Category", "Risk Category", "Make", "Model", "Serial Number", "Coverage Amount",
+	"Country", "In Storage", "Coverage Start Date", "Coverage End Date", "Policy", "Entity Code",
+}
+
+// ItemsToCsv returns a list of items in CSV format
+func ItemsToCsv(tx *pop.Connection, items Items) ([]byte, error) {
+	if err := items.loadForCsv(tx); err != nil {
+		return nil, err
+	}
+
+	records := [][]string{itemsCsvHeader}
+	for _, i := range items {
+		coverageEndDate := ""
+		if i.CoverageEndDate.Valid {
+			coverageEndDate = i.CoverageEndDate.Time.Format(domain.DateFormat)
+		}
+
+		records = append(records, []string{
+			i.Name,
+			string(i.CoverageStatus),
+			i.Category.Name,
+			i.RiskCategory.Name,
+			i.Make,
+			i.Model,
+			i.SerialNumber,
+			api.Currency(i.CoverageAmount).String(),
+			i.Country,
+			strconv.FormatBool(i.InStorage),
+			i.CoverageStartDate.Format(domain.DateFormat),
+			coverageEndDate,
+			i.Policy.Name,
+			i.Policy.EntityCode.Code,
+		})
+	}
+
+	return domain.CSV(records), nil
+}
+
+// loadForCsv loads the Category, RiskCategory, and Policy with its EntityCode of each of the Items, with one query
+// for each kind of record rather than for each Item
+func (i Items) loadForCsv(tx *pop.Connection) error {
+	if len(i) == 0 {
+		return nil
+	}
+
+	categoryIDs := make([]uuid.UUID, len(i))
+	riskCategoryIDs := make([]uuid.UUID, len(i))
+	policyIDs := make([]uuid.UUID, len(i))
+	for n := range i {
+		categoryIDs[n] = i[n].CategoryID
+		riskCategoryIDs[n] = i[n].RiskCategoryID
+		policyIDs[n] = i[n].PolicyID
+	}
+
+	var categories ItemCategories
+	if err := tx.Where("id IN (?)", categoryIDs).All(&categories); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	categoriesByID := map[uuid.UUID]ItemCategory{}
+	for _, c := range categories {
+		categoriesByID[c.ID] = c
+	}
+
+	var riskCategories RiskCategories
+	if err := tx.Where("id IN (?)", riskCategoryIDs).All(&riskCategories); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	riskCategoriesByID := map[uuid.UUID]RiskCategory{}
+	for _, r := range riskCategories {
+		riskCategoriesByID[r.ID] = r
+	}
+
+	policies, err := policiesByID(tx, policyIDs)
+	if err != nil {
+		return err
+	}
+
+	for n := range i {
+		i[n].Category = categoriesByID[i[n].CategoryID]
+		i[n].RiskCategory = riskCategoriesByID[i[n].RiskCategoryID]
+		i[n].Policy = policies[i[n].PolicyID]
+	}
+	return nil
+}
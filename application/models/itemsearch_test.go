@@ -0,0 +1,135 @@
+package models
+
+import (
+	"encoding/csv"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestItems_Search() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 3})
+
+	laptop := UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+	ms.NoError(ms.DB.RawQuery(`UPDATE items SET country = ?, coverage_amount = ?, in_storage = true,
+		risk_category_id = ? WHERE id = ?`, "Kenya", 250000, RiskCategoryMobileID(), laptop.ID).Exec())
+
+	camera := UpdateItemStatus(ms.DB, f.Items[1], api.ItemCoverageStatusApproved, "")
+	ms.NoError(ms.DB.RawQuery("UPDATE items SET country = ?, coverage_amount = ? WHERE id = ?",
+		"Uganda", 150000, camera.ID).Exec())
+
+	oldItem := f.Items[2]
+	ms.NoError(ms.DB.RawQuery("UPDATE items SET country = ?, coverage_amount = ?, coverage_end_date = ? WHERE id = ?",
+		"Kenya", 300000, time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC), oldItem.ID).Exec())
+
+	tests := []struct {
+		name    string
+		search  ItemSearch
+		wantIDs []uuid.UUID
+	}{
+		{
+			name:    "all",
+			search:  ItemSearch{},
+			wantIDs: []uuid.UUID{laptop.ID, camera.ID, oldItem.ID},
+		},
+		{
+			name: "approved over 2000 in Kenya",
+			search: ItemSearch{
+				CoverageStatuses:  []api.ItemCoverageStatus{api.ItemCoverageStatusApproved},
+				MinCoverageAmount: nulls.NewInt(200000),
+				Country:           "kenya",
+			},
+			wantIDs: []uuid.UUID{laptop.ID},
+		},
+		{
+			name:    "max coverage amount",
+			search:  ItemSearch{MaxCoverageAmount: nulls.NewInt(200000)},
+			wantIDs: []uuid.UUID{camera.ID},
+		},
+		{
+			name:    "category",
+			search:  ItemSearch{CategoryID: nulls.NewUUID(camera.CategoryID)},
+			wantIDs: []uuid.UUID{camera.ID},
+		},
+		{
+			name:    "risk category",
+			search:  ItemSearch{RiskCategoryID: nulls.NewUUID(RiskCategoryMobileID())},
+			wantIDs: []uuid.UUID{laptop.ID},
+		},
+		{
+			name:    "in storage",
+			search:  ItemSearch{InStorage: nulls.NewBool(false)},
+			wantIDs: []uuid.UUID{camera.ID, oldItem.ID},
+		},
+		{
+			name: "covered in 2020",
+			search: ItemSearch{
+				CoveredFrom: nulls.NewTime(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+				CoveredTo:   nulls.NewTime(time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)),
+			},
+			wantIDs: []uuid.UUID{laptop.ID, camera.ID},
+		},
+		{
+			name:    "covered before the start date",
+			search:  ItemSearch{CoveredTo: nulls.NewTime(time.Date(2009, 12, 31, 0, 0, 0, 0, time.UTC))},
+			wantIDs: []uuid.UUID{},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			var items Items
+			meta, err := items.Search(ms.DB, tt.search, api.NewQuery(url.Values{}))
+			ms.NoError(err)
+			ms.Equal(len(tt.wantIDs), meta.TotalRecords, "incorrect total records")
+
+			ids := make([]uuid.UUID, len(items))
+			for i := range items {
+				ids[i] = items[i].ID
+			}
+			ms.ElementsMatch(tt.wantIDs, ids, "incorrect items found")
+
+			var all Items
+			ms.NoError(all.SearchAll(ms.DB, tt.search))
+			ms.Equal(len(tt.wantIDs), len(all), "incorrect number of items from SearchAll")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestItemsToCsv() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{})
+	item := f.Items[0]
+
+	got, err := ItemsToCsv(ms.DB, Items{item})
+	ms.NoError(err)
+	records, err := csv.NewReader(strings.NewReader(string(got))).ReadAll()
+	ms.NoError(err)
+	ms.Equal(2, len(records), "incorrect number of rows")
+	ms.Equal(itemsCsvHeader, records[0], "incorrect header")
+
+	row := records[1]
+	ms.Equal(item.Name, row[0], "incorrect name")
+	ms.Equal(string(api.ItemCoverageStatusDraft), row[1], "incorrect coverage status")
+	ms.Equal(api.Currency(item.CoverageAmount).String(), row[7], "incorrect coverage amount")
+	ms.Equal(item.Country, row[8], "incorrect country")
+	ms.Equal("false", row[9], "incorrect in storage")
+	ms.Equal("2010-04-01", row[10], "incorrect coverage start date")
+	ms.Equal("", row[11], "incorrect coverage end date")
+	ms.Equal(f.Policies[0].Name, row[12], "incorrect policy name")
+}
+
+func (ms *ModelSuite) TestItems_SearchAll_TooMany() {
+	CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+
+	defer func(max int) { maxExportRecords = max }(maxExportRecords)
+	maxExportRecords = 1
+
+	var items Items
+	err := items.SearchAll(ms.DB, ItemSearch{})
+	ms.EqualAppError(api.AppError{Key: api.ErrorExportTooLarge, Category: api.CategoryUser}, err)
+}
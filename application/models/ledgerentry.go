@@ -3,6 +3,7 @@ package models
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/gobuffalo/nulls"
@@ -17,7 +18,7 @@ import (
 type LedgerEntryType string
 
 func (t LedgerEntryType) IsClaim() bool {
-	if t == LedgerEntryTypeClaim || t == LedgerEntryTypeClaimAdjustment {
+	if t == LedgerEntryTypeClaim || t == LedgerEntryTypeClaimAdjustment || t == LedgerEntryTypeClaimRecovery {
 		return true
 	}
 	return false
@@ -29,10 +30,12 @@ const (
 	LedgerEntryTypeCoverageRefund   = LedgerEntryType("CoverageRefund")
 	LedgerEntryTypeCoverageRenewal  = LedgerEntryType("CoverageRenewal")
 	LedgerEntryTypePolicyAdjustment = LedgerEntryType("PolicyAdjustment")
+	LedgerEntryTypePremiumMinimum   = LedgerEntryType("PremiumMinimum")
 	LedgerEntryTypeClaim            = LedgerEntryType("Claim")
 	LedgerEntryTypeLegacy5          = LedgerEntryType("5")
 	LedgerEntryTypeClaimAdjustment  = LedgerEntryType("ClaimAdjustment")
 	LedgerEntryTypeLegacy20         = LedgerEntryType("20")
+	LedgerEntryTypeClaimRecovery    = LedgerEntryType("ClaimRecovery")
 )
 
 var ValidLedgerEntryTypes = map[LedgerEntryType]struct{}{
@@ -41,10 +44,12 @@ var ValidLedgerEntryTypes = map[LedgerEntryType]struct{}{
 	LedgerEntryTypeCoverageRefund:   {},
 	LedgerEntryTypeCoverageRenewal:  {},
 	LedgerEntryTypePolicyAdjustment: {},
+	LedgerEntryTypePremiumMinimum:   {},
 	LedgerEntryTypeClaim:            {},
 	LedgerEntryTypeLegacy5:          {},
 	LedgerEntryTypeClaimAdjustment:  {},
 	LedgerEntryTypeLegacy20:         {},
+	LedgerEntryTypeClaimRecovery:    {},
 }
 
 type LedgerEntries []LedgerEntry
@@ -70,6 +75,8 @@ type LedgerEntry struct {
 	DateSubmitted    time.Time       `db:"date_submitted"`
 	DateEntered      nulls.Time      `db:"date_entered"`
 	LegacyID         nulls.Int       `db:"legacy_id"`
+	ReversedEntryID  nulls.UUID      `db:"reversed_entry_id"` // set on compensating entries created by a Batch reversal
+	RenewalEntryID   nulls.UUID      `db:"renewal_entry_id"`  // set on PremiumMinimum top-ups billed with a renewal
 
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
@@ -88,27 +95,66 @@ func (le *LedgerEntry) Update(tx *pop.Connection) error {
 // AllForMonth returns all the non-entered entries (date_entered is null) for the month.
 // The provided date must be the first day of the month.
 func (le *LedgerEntries) AllForMonth(tx *pop.Connection, firstDay time.Time) error {
-	lastDay := domain.EndOfMonth(firstDay)
+	return le.AllForPeriod(tx, firstDay, domain.EndOfMonth(firstDay))
+}
 
-	err := tx.Where("date_submitted BETWEEN ? and ?", firstDay, lastDay).
+// AllForPeriod returns all the non-entered entries (date_entered is null) submitted between
+// the start and end dates, inclusive.
+func (le *LedgerEntries) AllForPeriod(tx *pop.Connection, startDate, endDate time.Time) error {
+	err := tx.Where("date_submitted BETWEEN ? and ?", startDate, endDate).
 		Where("date_entered IS NULL").All(le)
 
 	return appErrorFromDB(err, api.ErrorQueryFailure)
 }
 
+// AllForPolicy returns all the entries for the policy, oldest first. Claim recoveries are excluded, since they are
+// not charged to the policy.
+func (le *LedgerEntries) AllForPolicy(tx *pop.Connection, policyID uuid.UUID) error {
+	err := tx.Where("policy_id = ? AND type != ?", policyID, LedgerEntryTypeClaimRecovery).
+		Order("date_submitted asc, created_at asc, id asc").All(le)
+
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
 type TransactionBlocks map[string]LedgerEntries // keyed by account
 
-func (le *LedgerEntries) ToCsv(batchDate time.Time) []byte {
-	sage := fin.NewBatch(fin.ProviderTypeSage, batchDate)
+// ToCsv returns the LedgerEntries formatted as a batch file for the given accounting export provider type
+func (le *LedgerEntries) ToCsv(providerType string, batchDate time.Time) []byte {
+	return le.MakeBatch(providerType, batchDate).BatchToCSV()
+}
+
+// MakeBatch creates a fin.Provider batch of the given provider type and appends a transaction for each LedgerEntry,
+// plus a balancing transaction for each account block. The order is deterministic: blocks are ordered by account,
+// and the entries within a block by date submitted, then by policy ID.
+func (le *LedgerEntries) MakeBatch(providerType string, batchDate time.Time) fin.Provider {
+	batch := fin.NewBatch(providerType, batchDate)
+
+	blocks := le.blockTransactions(batchDate)
+	for _, account := range sortedAccounts(blocks) {
+		for _, t := range blocks[account] {
+			batch.AppendToBatch(t)
+		}
+	}
+
+	return batch
+}
+
+// blockTransactions returns the transactions for each account block, keyed by account. Each block has a
+// transaction for each LedgerEntry, ordered by date submitted and then by policy, followed by a balancing
+// transaction on the block account.
+func (le *LedgerEntries) blockTransactions(batchDate time.Time) map[string][]fin.Transaction {
+	blockTransactions := map[string][]fin.Transaction{}
 
 	blocks := le.MakeBlocks()
 	for account, ledgerEntries := range blocks {
 		if len(ledgerEntries) == 0 {
 			continue
 		}
+		ledgerEntries.sortForExport()
+		transactions := make([]fin.Transaction, 0, len(ledgerEntries)+1)
 		var balance int
 		for _, l := range ledgerEntries {
-			sage.AppendToBatch(fin.Transaction{
+			transactions = append(transactions, fin.Transaction{
 				Account:     domain.Env.ExpenseAccount,
 				Amount:      int(l.Amount),
 				Description: l.transactionDescription(),
@@ -118,16 +164,147 @@ func (le *LedgerEntries) ToCsv(batchDate time.Time) []byte {
 
 			balance -= int(l.Amount)
 		}
-		sage.AppendToBatch(fin.Transaction{
+		transactions = append(transactions, fin.Transaction{
 			Account:     account,
 			Amount:      balance,
 			Description: ledgerEntries[0].balanceDescription(),
 			Reference:   "",
 			Date:        batchDate,
 		})
+		blockTransactions[account] = transactions
+	}
+
+	return blockTransactions
+}
+
+// ValidateForExport checks that the LedgerEntries can be exported as a balanced journal using the given provider
+// type. Problems that would make the journal invalid are reported as errors. Problems that only change the exported
+// data, such as a description truncated by the provider, are reported as warnings.
+func (le *LedgerEntries) ValidateForExport(providerType string, batchDate time.Time) api.BatchValidationReport {
+	report := api.BatchValidationReport{
+		IsValid:         true,
+		NumberOfEntries: len(*le),
+		Problems:        []api.BatchProblem{},
+	}
+
+	if domain.Env.ExpenseAccount == "" {
+		report.AddProblem(api.BatchProblem{
+			Type:    api.BatchProblemMissingAccount,
+			Message: "the expense account is not configured",
+		})
+	}
+
+	for _, e := range *le {
+		id := e.ID
+		if e.IncomeAccount == "" {
+			report.AddProblem(api.BatchProblem{
+				Type:          api.BatchProblemMissingIncomeAccount,
+				LedgerEntryID: &id,
+				Message:       "ledger entry has no income account",
+			})
+		}
+		if e.RiskCategoryCC == "" {
+			report.AddProblem(api.BatchProblem{
+				Type:          api.BatchProblemMissingRiskCategoryCC,
+				LedgerEntryID: &id,
+				Message:       "ledger entry has no risk category cost center",
+			})
+		}
+		if description := e.transactionDescription(); providerType == fin.ProviderTypeSage &&
+			len([]rune(description)) > fin.SageDescriptionLimit {
+			report.AddProblem(api.BatchProblem{
+				Type:          api.BatchProblemDescriptionTruncated,
+				IsWarning:     true,
+				LedgerEntryID: &id,
+				Message: fmt.Sprintf("description will be truncated to %d characters: %s",
+					fin.SageDescriptionLimit, description),
+			})
+		}
+	}
+
+	blocks := le.blockTransactions(batchDate)
+	for _, account := range sortedAccounts(blocks) {
+		if account == "" {
+			report.AddProblem(api.BatchProblem{
+				Type:    api.BatchProblemMissingAccount,
+				Message: "a balancing transaction has no account",
+			})
+		}
+	}
+
+	batch := le.MakeBatch(providerType, batchDate)
+	validateExportedBalance(&report, batch, batch.BatchToCSV(), blocks)
+
+	return report
+}
+
+// validateExportedBalance reads the amounts back from the batch file data and checks that each account block, as
+// written by the provider, balances. The blocks must be the ones the batch was made from. The report net amount is
+// the net of the exported amounts.
+func validateExportedBalance(report *api.BatchValidationReport, batch fin.Provider, data []byte,
+	blocks map[string][]fin.Transaction) {
+	amounts, err := batch.ParseAmounts(data)
+	if err != nil {
+		report.AddProblem(api.BatchProblem{
+			Type:    api.BatchProblemUnbalanced,
+			Message: "exported amounts could not be read: " + err.Error(),
+		})
+		return
 	}
 
-	return sage.BatchToCSV()
+	var want int
+	for _, transactions := range blocks {
+		want += len(transactions)
+	}
+	if len(amounts) != want {
+		report.AddProblem(api.BatchProblem{
+			Type:    api.BatchProblemUnbalanced,
+			Message: fmt.Sprintf("export has %d transactions, expected %d", len(amounts), want),
+		})
+		return
+	}
+
+	var net, start int
+	for _, account := range sortedAccounts(blocks) {
+		end := start + len(blocks[account])
+		var blockNet int
+		for _, amount := range amounts[start:end] {
+			blockNet += amount
+		}
+		if blockNet != 0 {
+			report.AddProblem(api.BatchProblem{
+				Type:    api.BatchProblemUnbalanced,
+				Account: account,
+				Message: fmt.Sprintf("account block does not balance, net amount is %s", api.Currency(blockNet)),
+			})
+		}
+		net += blockNet
+		start = end
+	}
+	report.NetAmount = api.Currency(net)
+}
+
+// sortedAccounts returns the accounts of the transaction blocks in order, to make the export order deterministic
+func sortedAccounts(blocks map[string][]fin.Transaction) []string {
+	accounts := make([]string, 0, len(blocks))
+	for account := range blocks {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+// sortForExport sorts the LedgerEntries by date submitted, then by policy ID, then by ID
+func (le LedgerEntries) sortForExport() {
+	sort.SliceStable(le, func(i, j int) bool {
+		if !le[i].DateSubmitted.Equal(le[j].DateSubmitted) {
+			return le[i].DateSubmitted.Before(le[j].DateSubmitted)
+		}
+		if le[i].PolicyID != le[j].PolicyID {
+			return le[i].PolicyID.String() < le[j].PolicyID.String()
+		}
+		return le[i].ID.String() < le[j].ID.String()
+	})
 }
 
 func (le *LedgerEntries) MakeBlocks() TransactionBlocks {
@@ -152,7 +329,8 @@ func (le *LedgerEntries) Reconcile(ctx context.Context) error {
 }
 
 // Reconcile marks the LedgerEntry as "entered" into the accounting system, and makes any
-// necessary updates to the referenced objects, such as setting Claim status to Paid.
+// necessary updates to the referenced objects, such as setting Claim status to Paid once
+// all of its disbursements have been entered.
 func (le *LedgerEntry) Reconcile(ctx context.Context, now time.Time) error {
 	tx := Tx(ctx)
 
@@ -161,8 +339,13 @@ func (le *LedgerEntry) Reconcile(ctx context.Context, now time.Time) error {
 		return err
 	}
 
+	// a compensating entry does not represent a payment, so the Claim status is not affected
+	if le.ReversedEntryID.Valid {
+		return nil
+	}
+
 	le.LoadClaim(tx)
-	if le.Claim != nil {
+	if le.Claim != nil && le.Claim.Status == api.ClaimStatusApproved && le.Claim.isFullyPaid(tx) {
 		le.Claim.Status = api.ClaimStatusPaid
 		// Use Update instead of UpdateStatus so the ClaimItem(s) get updated as well
 		if err := le.Claim.Update(ctx); err != nil {
@@ -172,6 +355,64 @@ func (le *LedgerEntry) Reconcile(ctx context.Context, now time.Time) error {
 	return nil
 }
 
+// Unreconcile reverses the effect of Reconcile, marking the LedgerEntry as not "entered" into the
+// accounting system and returning a Paid Claim to Approved.
+func (le *LedgerEntry) Unreconcile(ctx context.Context) error {
+	tx := Tx(ctx)
+
+	le.DateEntered = nulls.Time{}
+	if err := le.Update(tx); err != nil {
+		return err
+	}
+
+	if le.ReversedEntryID.Valid {
+		return nil
+	}
+
+	le.LoadClaim(tx)
+	if le.Claim != nil && le.Claim.Status == api.ClaimStatusPaid && !le.Claim.isFullyPaid(tx) {
+		user := CurrentUser(ctx)
+		le.Claim.Status = api.ClaimStatusApproved
+		le.Claim.StatusChange = ClaimStatusChangePaymentReversed + user.Name()
+		if err := le.Claim.Update(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewReversal creates a compensating LedgerEntry that cancels out the amount of this LedgerEntry
+// in the accounting record. The new entry is not saved.
+func (le *LedgerEntry) NewReversal() LedgerEntry {
+	reversal := *le
+	reversal.ID = uuid.Nil
+	reversal.Amount = -le.Amount
+	reversal.DateSubmitted = time.Now().UTC()
+	reversal.DateEntered = nulls.Time{}
+	reversal.LegacyID = nulls.Int{}
+	reversal.ReversedEntryID = nulls.NewUUID(le.ID)
+	reversal.CreatedAt = time.Time{}
+	reversal.UpdatedAt = time.Time{}
+	reversal.Claim = nil
+	return reversal
+}
+
+// ConvertToPolicyLedgerEntry converts the LedgerEntry to an entry on a policy statement of account, with the given
+// running balance
+func (le *LedgerEntry) ConvertToPolicyLedgerEntry(balance api.Currency) api.PolicyLedgerEntry {
+	return api.PolicyLedgerEntry{
+		ID:            le.ID,
+		Type:          string(le.Type),
+		ItemID:        convertUUIDToAPI(le.ItemID),
+		ClaimID:       convertUUIDToAPI(le.ClaimID),
+		Description:   le.transactionDescription(),
+		Amount:        le.Amount,
+		Balance:       balance,
+		DateSubmitted: le.DateSubmitted,
+		DateEntered:   convertTimeToAPI(le.DateEntered),
+	}
+}
+
 // TODO: make a better description format unless it has to be the same as before (which I doubt)
 func (le *LedgerEntry) transactionDescription() string {
 	dateString := le.DateSubmitted.Format("Jan 02, 2006")
@@ -185,7 +426,11 @@ func (le *LedgerEntry) transactionDescription() string {
 			le.RiskCategoryName, le.Type, le.CostCenter, dateString)
 	}
 
-	return fmt.Sprintf("%.60s", description) // truncate to Sage limit of 60 characters
+	if le.ReversedEntryID.Valid {
+		description = "Reversal " + description
+	}
+
+	return description
 }
 
 // Merge HouseholdID and CostCenter into one column
@@ -245,30 +490,177 @@ func (le *LedgerEntry) LoadClaim(tx *pop.Connection) {
 	}
 }
 
-// ProcessAnnualCoverage creates coverage renewal ledger entries for all items covered for the given year.
+// ProcessAnnualCoverage creates coverage renewal ledger entries for all items covered for the given year, plus a
+// PremiumMinimum ledger entry for any top-up needed to reach the PremiumMinimum, linked to the item's renewal entry.
 // Does not create new records for items already processed.
 func ProcessAnnualCoverage(tx *pop.Connection, year int) error {
-	var items Items
-	if err := tx.Where("coverage_status = ?", api.ItemCoverageStatusApproved).
-		Where("paid_through_year < ?", year).
-		All(&items); err != nil {
-		return api.NewAppError(err, api.ErrorQueryFailure, api.CategoryInternal)
+	items, err := itemsForAnnualCoverage(tx, year)
+	if err != nil {
+		return err
 	}
 
-	for _, item := range items {
-		err := item.CreateLedgerEntry(tx, LedgerEntryTypeCoverageRenewal, item.CalculateAnnualPremium())
+	for _, charge := range annualCoverageCharges(tx, items, year) {
+		item := charge.item
+		renewal, err := item.createLedgerEntry(tx, LedgerEntryTypeCoverageRenewal, charge.premium, nulls.UUID{})
 		if err != nil {
 			return err
 		}
+		if charge.premiumMinimum == 0 {
+			continue
+		}
+		if _, err := item.createLedgerEntry(tx, LedgerEntryTypePremiumMinimum, charge.premiumMinimum,
+			nulls.NewUUID(renewal.ID)); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
-// FindCurrentRenewals finds the coverage renewal ledger entries for the given year
+// PreviewAnnualCoverage computes the coverage renewal premium that ProcessAnnualCoverage would bill for the given
+// year, totaled by item, policy and entity code. Nothing is saved.
+func PreviewAnnualCoverage(tx *pop.Connection, year int) (api.AnnualRenewalPreview, error) {
+	items, err := itemsForAnnualCoverage(tx, year)
+	if err != nil {
+		return api.AnnualRenewalPreview{}, err
+	}
+
+	charges := annualCoverageCharges(tx, items, year)
+	preview := api.AnnualRenewalPreview{
+		Year:          year,
+		NumberOfItems: len(charges),
+		Items:         make([]api.AnnualRenewalItem, len(charges)),
+		Policies:      []api.AnnualRenewalPolicy{},
+		EntityCodes:   []api.AnnualRenewalEntityCode{},
+	}
+
+	policies := map[uuid.UUID]int{} // index into preview.Policies
+	entityCodes := map[string]int{} // index into preview.EntityCodes
+
+	for i, charge := range charges {
+		item := charge.item
+		item.LoadPolicy(tx, false)
+		item.Policy.LoadEntityCode(tx, false)
+		entityCode := item.Policy.EntityCode.Code
+		premium := charge.premium + charge.premiumMinimum
+
+		preview.TotalPremium += premium
+		preview.TotalPremiumMinimum += charge.premiumMinimum
+		preview.Items[i] = api.AnnualRenewalItem{
+			ItemID:         item.ID,
+			Name:           item.Name,
+			PolicyID:       item.PolicyID,
+			EntityCode:     entityCode,
+			CoverageAmount: api.Currency(item.CoverageAmount),
+			Premium:        premium,
+			PremiumMinimum: charge.premiumMinimum,
+		}
+
+		p, ok := policies[item.PolicyID]
+		if !ok {
+			p = len(preview.Policies)
+			policies[item.PolicyID] = p
+			preview.Policies = append(preview.Policies, api.AnnualRenewalPolicy{
+				PolicyID:   item.PolicyID,
+				Name:       item.Policy.Name,
+				EntityCode: entityCode,
+			})
+		}
+		preview.Policies[p].NumberOfItems++
+		preview.Policies[p].Premium += premium
+		preview.Policies[p].PremiumMinimum += charge.premiumMinimum
+
+		e, ok := entityCodes[entityCode]
+		if !ok {
+			e = len(preview.EntityCodes)
+			entityCodes[entityCode] = e
+			preview.EntityCodes = append(preview.EntityCodes, api.AnnualRenewalEntityCode{EntityCode: entityCode})
+		}
+		preview.EntityCodes[e].NumberOfItems++
+		preview.EntityCodes[e].Premium += premium
+		preview.EntityCodes[e].PremiumMinimum += charge.premiumMinimum
+	}
+
+	sort.Slice(preview.EntityCodes, func(i, j int) bool {
+		return preview.EntityCodes[i].EntityCode < preview.EntityCodes[j].EntityCode
+	})
+
+	return preview, nil
+}
+
+// annualCoverageCharge is the renewal premium of an item, and the top-up needed to reach the PremiumMinimum
+type annualCoverageCharge struct {
+	item           Item
+	premium        api.Currency
+	premiumMinimum api.Currency
+}
+
+// annualCoverageCharges computes the renewal premium of each item for the given year. Depending on the PremiumMinimumScope, the
+// PremiumMinimum top-up is computed for each item, or for each policy and charged with the policy's first item.
+// The policy's minimum applies to all of its covered items, so the premium of covered items that are already paid
+// through the year counts toward it. The items must be ordered by policy.
+func annualCoverageCharges(tx *pop.Connection, items Items, year int) []annualCoverageCharge {
+	charges := make([]annualCoverageCharge, len(items))
+	for i := range items {
+		charges[i] = annualCoverageCharge{item: items[i], premium: items[i].CalculateAnnualPremiumForYear(tx, year)}
+		if domain.Env.PremiumMinimumScope == domain.PremiumMinimumScopeItem {
+			charges[i].premiumMinimum = premiumMinimumTopUp(charges[i].premium, true)
+		}
+	}
+
+	if domain.Env.PremiumMinimumScope != domain.PremiumMinimumScopePolicy {
+		return charges
+	}
+
+	for first := 0; first < len(charges); {
+		policyPremium := paidCoveredPremium(tx, charges[first].item.PolicyID, year)
+		next := first
+		for ; next < len(charges) && charges[next].item.PolicyID == charges[first].item.PolicyID; next++ {
+			policyPremium += charges[next].premium
+		}
+		charges[first].premiumMinimum = premiumMinimumTopUp(policyPremium, true)
+		first = next
+	}
+
+	return charges
+}
+
+// paidCoveredPremium returns the total premium for the given year of the policy's covered items that are already
+// paid through that year
+func paidCoveredPremium(tx *pop.Connection, policyID uuid.UUID, year int) api.Currency {
+	var items Items
+	if err := tx.Where("policy_id = ? AND coverage_status = ? AND paid_through_year >= ?",
+		policyID, api.ItemCoverageStatusApproved, year).All(&items); err != nil {
+		panic("database error loading policy items, " + err.Error())
+	}
+
+	var premium api.Currency
+	for _, item := range items {
+		premium += item.CalculateAnnualPremiumForYear(tx, year)
+	}
+	return premium
+}
+
+// itemsForAnnualCoverage finds the covered items that have not been paid through the given year, ordered by policy
+func itemsForAnnualCoverage(tx *pop.Connection, year int) (Items, error) {
+	var items Items
+	if err := tx.Where("coverage_status = ?", api.ItemCoverageStatusApproved).
+		Where("paid_through_year < ?", year).
+		Order("policy_id asc, id asc").
+		All(&items); err != nil {
+		return nil, api.NewAppError(err, api.ErrorQueryFailure, api.CategoryInternal)
+	}
+	return items, nil
+}
+
+// FindCurrentRenewals finds the coverage renewal ledger entries for the given year, along with the PremiumMinimum
+// top-ups linked to them by ProcessAnnualCoverage. Top-ups from coverage changes during the year are not linked to a
+// renewal, so they are left for the monthly batch.
 func (le *LedgerEntries) FindCurrentRenewals(tx *pop.Connection, year int) error {
-	if err := tx.Where("type = ?", LedgerEntryTypeCoverageRenewal).
-		Where("EXTRACT(YEAR FROM date_submitted) = ?", year).
+	renewals := "SELECT id FROM ledger_entries WHERE type = ? AND EXTRACT(YEAR FROM date_submitted) = ?"
+	if err := tx.Where("id IN ("+renewals+") OR (type = ? AND renewal_entry_id IN ("+renewals+"))",
+		LedgerEntryTypeCoverageRenewal, year,
+		LedgerEntryTypePremiumMinimum, LedgerEntryTypeCoverageRenewal, year).
 		All(le); err != nil {
 		return api.NewAppError(err, api.ErrorQueryFailure, api.CategoryInternal)
 	}
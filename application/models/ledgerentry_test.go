@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
+	"github.com/silinternational/cover-api/fin"
 )
 
 func (ms *ModelSuite) TestLedgerEntries_AllForMonth() {
@@ -28,7 +30,7 @@ func (ms *ModelSuite) TestLedgerEntries_AllForMonth() {
 		ms.NoError(f.Items[i].Approve(ctx, false))
 
 		entry := LedgerEntry{}
-		ms.NoError(ms.DB.Where("item_id = ?", f.Items[i].ID).First(&entry))
+		ms.NoError(ms.DB.Where("item_id = ? AND type = ?", f.Items[i].ID, LedgerEntryTypeNewCoverage).First(&entry))
 		entry.DateSubmitted = datesSubmitted[i]
 		entry.DateEntered = datesEntered[i]
 		ms.NoError(ms.DB.Update(&entry))
@@ -123,7 +125,7 @@ func (ms *ModelSuite) TestLedgerEntries_ToCsv() {
 	}
 	for _, tt := range tests {
 		ms.T().Run(tt.name, func(t *testing.T) {
-			got := tt.entries.ToCsv(tt.batchDate)
+			got := tt.entries.ToCsv(fin.ProviderTypeSage, tt.batchDate)
 			for _, w := range tt.want {
 				ms.Contains(string(got), w)
 			}
@@ -131,6 +133,219 @@ func (ms *ModelSuite) TestLedgerEntries_ToCsv() {
 	}
 }
 
+func (ms *ModelSuite) TestLedgerEntries_MakeBatch() {
+	march1 := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	march2 := time.Date(2021, 3, 2, 0, 0, 0, 0, time.UTC)
+	domain.Env.ExpenseAccount = "XYZ123"
+
+	policy1 := uuid.FromStringOrNil("00000000-0000-4000-8000-000000000001")
+	policy2 := uuid.FromStringOrNil("00000000-0000-4000-8000-000000000002")
+
+	entries := LedgerEntries{
+		{PolicyID: policy2, IncomeAccount: "44250", RiskCategoryCC: "B", Amount: 1, DateSubmitted: march1},
+		{PolicyID: policy1, IncomeAccount: "40200", RiskCategoryCC: "A", Amount: 2, DateSubmitted: march2},
+		{PolicyID: policy2, IncomeAccount: "40200", RiskCategoryCC: "A", Amount: 3, DateSubmitted: march1},
+		{PolicyID: policy1, IncomeAccount: "40200", RiskCategoryCC: "A", Amount: 4, DateSubmitted: march1},
+	}
+
+	// The journal provider has one row per transaction and no row numbers, so the order is easy to verify
+	batch := entries.MakeBatch(fin.ProviderTypeJournal, march1).(*fin.Journal)
+
+	wantAccounts := []string{"XYZ123", "XYZ123", "XYZ123", "40200A", "XYZ123", "44250B"}
+	wantAmounts := []int{4, 3, 2, -9, 1, -1}
+	ms.Equal(len(wantAccounts), len(batch.Transactions), "incorrect number of transactions")
+	for i, tr := range batch.Transactions {
+		ms.Equal(wantAccounts[i], tr.Account, "incorrect account in transaction %d", i)
+		ms.Equal(wantAmounts[i], tr.Amount, "incorrect amount in transaction %d", i)
+	}
+
+	// Export the same entries in a different order and verify the output is identical
+	reversed := LedgerEntries{entries[3], entries[2], entries[1], entries[0]}
+	ms.Equal(
+		fin.Checksum(entries.ToCsv(fin.ProviderTypeSage, march1)),
+		fin.Checksum(reversed.ToCsv(fin.ProviderTypeSage, march1)),
+		"exports of the same entries are not identical",
+	)
+}
+
+func (ms *ModelSuite) TestLedgerEntries_ValidateForExport() {
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	domain.Env.ExpenseAccount = "XYZ123"
+
+	good := LedgerEntry{
+		ID:               domain.GetUUID(),
+		PolicyID:         domain.GetUUID(),
+		RiskCategoryName: "Mobile",
+		RiskCategoryCC:   "MOBILE",
+		Type:             LedgerEntryTypeNewCoverage,
+		PolicyType:       api.PolicyTypeHousehold,
+		FirstName:        "FirstName",
+		LastName:         "LastName",
+		Amount:           100,
+		DateSubmitted:    date,
+		IncomeAccount:    "40200",
+	}
+
+	noAccount := good
+	noAccount.ID = domain.GetUUID()
+	noAccount.IncomeAccount = ""
+	noAccount.RiskCategoryCC = ""
+
+	longName := good
+	longName.ID = domain.GetUUID()
+	longName.LastName = strings.Repeat("x", fin.SageDescriptionLimit)
+
+	// these amounts are too large to be formatted to the cent as floating point numbers
+	large := good
+	large.ID = domain.GetUUID()
+	large.Amount = 16777217
+	small := good
+	small.ID = domain.GetUUID()
+	small.Amount = 1
+
+	tests := []struct {
+		name         string
+		entries      LedgerEntries
+		providerType string
+		wantValid    bool
+		wantProblems []api.BatchProblemType
+	}{
+		{
+			name:         "valid",
+			entries:      LedgerEntries{good},
+			providerType: fin.ProviderTypeSage,
+			wantValid:    true,
+			wantProblems: []api.BatchProblemType{},
+		},
+		{
+			name:         "missing accounts",
+			entries:      LedgerEntries{good, noAccount},
+			providerType: fin.ProviderTypeSage,
+			wantValid:    false,
+			wantProblems: []api.BatchProblemType{
+				api.BatchProblemMissingIncomeAccount,
+				api.BatchProblemMissingRiskCategoryCC,
+				api.BatchProblemMissingAccount,
+			},
+		},
+		{
+			name:         "truncated description",
+			entries:      LedgerEntries{longName},
+			providerType: fin.ProviderTypeSage,
+			wantValid:    true,
+			wantProblems: []api.BatchProblemType{api.BatchProblemDescriptionTruncated},
+		},
+		{
+			name:         "long description not truncated by journal provider",
+			entries:      LedgerEntries{longName},
+			providerType: fin.ProviderTypeJournal,
+			wantValid:    true,
+			wantProblems: []api.BatchProblemType{},
+		},
+		{
+			name:         "large amounts",
+			entries:      LedgerEntries{large, small},
+			providerType: fin.ProviderTypeSage,
+			wantValid:    true,
+			wantProblems: []api.BatchProblemType{},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got := tt.entries.ValidateForExport(tt.providerType, date)
+
+			ms.Equal(tt.wantValid, got.IsValid, "incorrect IsValid")
+			ms.Equal(len(tt.entries), got.NumberOfEntries, "incorrect NumberOfEntries")
+			ms.Equal(api.Currency(0), got.NetAmount, "incorrect NetAmount")
+
+			problems := make([]api.BatchProblemType, len(got.Problems))
+			for i, p := range got.Problems {
+				problems[i] = p.Type
+			}
+			ms.ElementsMatch(tt.wantProblems, problems, "incorrect problems")
+		})
+	}
+}
+
+func (ms *ModelSuite) Test_validateExportedBalance() {
+	date := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	domain.Env.ExpenseAccount = "XYZ123"
+
+	entries := LedgerEntries{{
+		ID:               domain.GetUUID(),
+		PolicyID:         domain.GetUUID(),
+		RiskCategoryName: "Mobile",
+		RiskCategoryCC:   "MOBILE",
+		Type:             LedgerEntryTypeNewCoverage,
+		PolicyType:       api.PolicyTypeHousehold,
+		FirstName:        "FirstName",
+		LastName:         "LastName",
+		Amount:           100,
+		DateSubmitted:    date,
+		IncomeAccount:    "40200",
+	}}
+	blocks := entries.blockTransactions(date)
+
+	tests := []struct {
+		name         string
+		providerType string
+		edit         func(string) string
+		wantValid    bool
+		wantNet      api.Currency
+	}{
+		{
+			name:         "balanced",
+			providerType: fin.ProviderTypeSage,
+			edit:         func(s string) string { return s },
+			wantValid:    true,
+			wantNet:      0,
+		},
+		{
+			name:         "Sage amount changed",
+			providerType: fin.ProviderTypeSage,
+			edit:         func(s string) string { return strings.Replace(s, ",1.00,", ",1.01,", 1) },
+			wantValid:    false,
+			wantNet:      -1,
+		},
+		{
+			name:         "QuickBooks amount changed",
+			providerType: fin.ProviderTypeQuickBooks,
+			edit:         func(s string) string { return strings.Replace(s, "\t-1.00\t", "\t-1.10\t", 1) },
+			wantValid:    false,
+			wantNet:      10,
+		},
+		{
+			name:         "journal row missing",
+			providerType: fin.ProviderTypeJournal,
+			edit:         func(s string) string { return s[:strings.LastIndex(s[:len(s)-1], "\n")+1] },
+			wantValid:    false,
+			wantNet:      0,
+		},
+		{
+			name:         "unreadable amount",
+			providerType: fin.ProviderTypeJournal,
+			edit:         func(s string) string { return strings.Replace(s, "1.00", "1.0", 1) },
+			wantValid:    false,
+			wantNet:      0,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			batch := entries.MakeBatch(tt.providerType, date)
+			data := []byte(tt.edit(string(batch.BatchToCSV())))
+
+			report := api.BatchValidationReport{IsValid: true, Problems: []api.BatchProblem{}}
+			validateExportedBalance(&report, batch, data, blocks)
+
+			ms.Equal(tt.wantValid, report.IsValid, "incorrect IsValid")
+			ms.Equal(tt.wantNet, report.NetAmount, "incorrect NetAmount")
+			for _, p := range report.Problems {
+				ms.Equal(api.BatchProblemUnbalanced, p.Type, "incorrect problem type")
+			}
+		})
+	}
+}
+
 func (ms *ModelSuite) TestLedgerEntries_MakeBlocks() {
 	policy1 := domain.GetUUID()
 	policy2 := domain.GetUUID()
@@ -232,7 +447,8 @@ func (ms *ModelSuite) TestLedgerEntries_Reconcile() {
 	for i := range f.Items {
 		ms.NoError(f.Items[i].Approve(ctx, false))
 
-		ms.NoError(ms.DB.Where("item_id = ?", f.Items[i].ID).First(&itemEntries[i]))
+		ms.NoError(ms.DB.Where("item_id = ? AND type = ?", f.Items[i].ID, LedgerEntryTypeNewCoverage).
+			First(&itemEntries[i]))
 		itemEntries[i].DateSubmitted = datesSubmitted[i]
 		itemEntries[i].DateEntered = datesEntered[i]
 		ms.NoError(ms.DB.Update(&itemEntries[i]))
@@ -241,7 +457,7 @@ func (ms *ModelSuite) TestLedgerEntries_Reconcile() {
 	claimEntries := make(LedgerEntries, len(f.Claims))
 	for i, claim := range f.Claims {
 		claim = UpdateClaimStatus(ms.DB, claim, api.ClaimStatusReview3, "")
-		ms.NoError(claim.Approve(ctx))
+		ms.NoError(claim.Approve(ctx, api.ClaimApproveInput{}))
 
 		ms.NoError(ms.DB.Where("claim_id = ?", claim.ID).First(&claimEntries[i]))
 		claimEntries[i].DateSubmitted = datesSubmitted[i]
@@ -311,3 +527,147 @@ func (ms *ModelSuite) TestProcessAnnualCoverage() {
 	ms.NoError(l2.FindCurrentRenewals(ms.DB, year))
 	ms.Equal(1, len(l2))
 }
+
+func (ms *ModelSuite) TestProcessAnnualCoverage_PremiumMinimum() {
+	year := time.Now().UTC().Year()
+	minimum := domain.Env.PremiumMinimum
+	scope := domain.Env.PremiumMinimumScope
+	defer func() {
+		domain.Env.PremiumMinimum = minimum
+		domain.Env.PremiumMinimumScope = scope
+	}()
+	domain.Env.PremiumMinimumScope = domain.PremiumMinimumScopePolicy
+
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 3})
+
+	f.Items[0].PaidThroughYear = year
+	for i := range f.Items {
+		UpdateItemStatus(ms.DB, f.Items[i], api.ItemCoverageStatusApproved, "")
+	}
+
+	var policyPremium api.Currency
+	for _, i := range f.Items {
+		policyPremium += i.CalculateAnnualPremiumForYear(ms.DB, year)
+	}
+	domain.Env.PremiumMinimum = int(policyPremium) + 1000
+
+	// a top-up from a coverage change is not part of the renewal, even if it was submitted on the same day
+	f.Items[1].LoadPolicy(ms.DB, false)
+	f.Items[1].Policy.LoadEntityCode(ms.DB, false)
+	f.Items[1].LoadRiskCategory(ms.DB, false)
+	unrelated := NewLedgerEntry(f.Items[1].Policy, &f.Items[1], nil)
+	unrelated.Type = LedgerEntryTypePremiumMinimum
+	unrelated.Amount = 100
+	unrelated.DateSubmitted = time.Now().UTC()
+	ms.NoError(unrelated.Create(ms.DB))
+
+	preview, err := PreviewAnnualCoverage(ms.DB, year)
+	ms.NoError(err)
+	ms.Equal(api.Currency(1000), preview.TotalPremiumMinimum,
+		"the minimum should count the premium of the item already paid through the year")
+
+	ms.NoError(ProcessAnnualCoverage(ms.DB, year))
+
+	var renewals LedgerEntries
+	ms.NoError(renewals.FindCurrentRenewals(ms.DB, year))
+	ms.Equal(3, len(renewals), "incorrect number of renewal entries, including the top-up")
+
+	var batchTotal api.Currency
+	for _, e := range renewals {
+		ms.NotEqual(unrelated.ID, e.ID, "an unrelated top-up should not be included in the renewals")
+		if e.Type == LedgerEntryTypePremiumMinimum {
+			ms.True(e.RenewalEntryID.Valid, "the top-up should be linked to its renewal entry")
+		}
+		batchTotal += e.Amount
+	}
+	ms.Equal(preview.TotalPremium, batchTotal, "the renewal entries do not add up to the preview total")
+}
+
+func (ms *ModelSuite) Test_annualCoverageCharges() {
+	domain.Env.PremiumFactor = 0.02
+	minimum := domain.Env.PremiumMinimum
+	scope := domain.Env.PremiumMinimumScope
+	defer func() {
+		domain.Env.PremiumMinimum = minimum
+		domain.Env.PremiumMinimumScope = scope
+	}()
+	domain.Env.PremiumMinimum = 2500
+
+	policy1 := domain.GetUUID()
+	policy2 := domain.GetUUID()
+	items := Items{
+		{PolicyID: policy1, CoverageAmount: 50000},  // premium of 1000
+		{PolicyID: policy1, CoverageAmount: 25000},  // premium of 500
+		{PolicyID: policy2, CoverageAmount: 200000}, // premium of 4000
+	}
+
+	tests := []struct {
+		name  string
+		scope string
+		want  []api.Currency
+	}{
+		{
+			name:  "item",
+			scope: domain.PremiumMinimumScopeItem,
+			want:  []api.Currency{1500, 2000, 0},
+		},
+		{
+			name:  "policy",
+			scope: domain.PremiumMinimumScopePolicy,
+			want:  []api.Currency{1000, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			domain.Env.PremiumMinimumScope = tt.scope
+			got := annualCoverageCharges(ms.DB, items, time.Now().UTC().Year())
+			ms.Equal(len(items), len(got), "incorrect number of charges")
+			for i := range got {
+				ms.Equal(items[i].CalculateAnnualPremium(ms.DB), got[i].premium, "incorrect premium on charge %d", i)
+				ms.Equal(tt.want[i], got[i].premiumMinimum, "incorrect premium minimum on charge %d", i)
+			}
+		})
+	}
+}
+
+func (ms *ModelSuite) TestPreviewAnnualCoverage() {
+	year := time.Now().UTC().Year()
+
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2, ItemsPerPolicy: 2})
+
+	f.Items[0].PaidThroughYear = year
+	UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusApproved, "")
+	UpdateItemStatus(ms.DB, f.Items[1], api.ItemCoverageStatusApproved, "")
+	UpdateItemStatus(ms.DB, f.Items[2], api.ItemCoverageStatusApproved, "")
+
+	var before LedgerEntries
+	ms.NoError(ms.DB.All(&before))
+
+	got, err := PreviewAnnualCoverage(ms.DB, year)
+	ms.NoError(err)
+
+	ms.Equal(year, got.Year, "incorrect Year")
+	ms.Equal(2, got.NumberOfItems, "incorrect NumberOfItems")
+	ms.Equal(2, len(got.Items), "incorrect number of Items")
+	ms.Equal(2, len(got.Policies), "incorrect number of Policies")
+
+	var wantTotal api.Currency
+	for _, i := range []Item{f.Items[1], f.Items[2]} {
+		wantTotal += i.CalculateAnnualPremium(ms.DB)
+	}
+	ms.Equal(wantTotal, got.TotalPremium, "incorrect TotalPremium")
+
+	var entityCodeTotal api.Currency
+	for _, e := range got.EntityCodes {
+		entityCodeTotal += e.Premium
+	}
+	ms.Equal(wantTotal, entityCodeTotal, "entity code totals do not add up to TotalPremium")
+
+	var after LedgerEntries
+	ms.NoError(ms.DB.All(&after))
+	ms.Equal(len(before), len(after), "preview should not create ledger entries")
+
+	var item Item
+	ms.NoError(ms.DB.Find(&item, f.Items[1].ID))
+	ms.Less(item.PaidThroughYear, year, "preview should not update PaidThroughYear")
+}
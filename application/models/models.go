@@ -52,6 +52,10 @@ const (
 	ClaimStatusChangeReview3         = "Submitted for payout approval by "
 	ClaimStatusChangeApproved        = "Approved by "
 	ClaimStatusChangeDenied          = "Denied by "
+	ClaimStatusChangePaymentReversed = "Payment reversed by "
+	ClaimStatusChangeAdjusted        = "Payout adjusted by "
+	ClaimStatusChangeAppealed        = "Appealed by "
+	ClaimStatusChangeWithdrawn       = "Withdrawn by "
 
 	ItemStatusChangeSubmitted    = "Submitted for approval"
 	ItemStatusChangeAutoApproved = "Auto approved"
@@ -71,6 +75,8 @@ const (
 	FieldClaimPaymentDate         = "PaymentDate"
 	FieldClaimTotalPayout         = "TotalPayout"
 	FieldClaimStatusReason        = "StatusReason"
+	FieldClaimAppealReason        = "AppealReason"
+	FieldClaimApprovals           = "Approvals"
 	FieldClaimCity                = "City"
 	FieldClaimState               = "State"
 	FieldClaimCountry             = "Country"
@@ -175,6 +181,10 @@ func init() {
 		log.Fatal(fmt.Errorf("error using crypto/rand ... %v", err))
 	}
 
+	if err = LoadClaimWorkflow(domain.Env.ClaimWorkflowFile); err != nil {
+		log.Fatal(err)
+	}
+
 	// initialize model validation library
 	mValidate = validator.New()
 
@@ -315,8 +325,8 @@ func save(tx *pop.Connection, m interface{}) error {
 	return nil
 }
 
-func update(tx *pop.Connection, m interface{}) error {
-	valErrs, err := tx.ValidateAndUpdate(m)
+func update(tx *pop.Connection, m interface{}, excludeColumns ...string) error {
+	valErrs, err := tx.ValidateAndUpdate(m, excludeColumns...)
 	if err != nil {
 		return appErrorFromDB(err, api.ErrorUpdateFailure)
 	}
@@ -399,6 +409,23 @@ func convertTimeToAPI(t nulls.Time) *time.Time {
 	return nil
 }
 
+// historyUsers finds the users who made the changes recorded in a list of histories, mapped by ID
+func historyUsers(tx *pop.Connection, ids []uuid.UUID) map[uuid.UUID]api.HistoryUser {
+	m := map[uuid.UUID]api.HistoryUser{}
+	if len(ids) == 0 {
+		return m
+	}
+
+	var users Users
+	if err := tx.Where("id IN (?)", ids).All(&users); err != nil {
+		panic("database error finding history users, " + err.Error())
+	}
+	for _, u := range users {
+		m[u.ID] = api.HistoryUser{ID: u.ID, FirstName: u.FirstName, LastName: u.LastName}
+	}
+	return m
+}
+
 func GetV5UUID(seed string) uuid.UUID {
 	return uuid.NewV5(uuidNamespace, seed)
 }
@@ -0,0 +1,207 @@
+package models
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+const (
+	cursorNext = "n"
+	cursorPrev = "p"
+)
+
+// maxExportRecords is the largest number of records that may be exported at once
+var maxExportRecords = 10000
+
+// listOptions defines how the records of a list endpoint may be sorted and filtered
+type listOptions struct {
+	// table is the name of the database table of the records
+	table string
+
+	// sortColumns maps the field names accepted in the sort parameter to database columns. The columns must not be
+	// nullable.
+	sortColumns map[string]string
+
+	// defaultSort is the field by which to sort if none is requested
+	defaultSort string
+
+	// defaultDesc is true if the default sort order is descending
+	defaultDesc bool
+
+	// filterColumns maps the keys accepted in the filter parameter to database columns
+	filterColumns map[string]string
+}
+
+// pageCursor identifies the record at the edge of a page and whether the page after or before it is wanted
+type pageCursor struct {
+	id       uuid.UUID
+	previous bool
+}
+
+// findPage finds a page of the records selected by q, sorted and filtered according to the api.Query. Pages are
+// found by keyset: a cursor holds the ID of the last (or first) record on a page, and the next (or previous) page
+// starts after (or before) that record in the sort order. The records argument must be a pointer to a slice of
+// structs with an ID field.
+func findPage(q *pop.Query, query api.Query, opts listOptions, records interface{}) (api.Meta, error) {
+	q, column, desc, err := opts.apply(q, query)
+	if err != nil {
+		return api.Meta{}, err
+	}
+
+	cursor, err := decodeCursor(query.Cursor())
+	if err != nil {
+		return api.Meta{}, api.NewAppError(err, api.ErrorInvalidCursor, api.CategoryUser)
+	}
+
+	total, err := q.Count(records)
+	if err != nil {
+		return api.Meta{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	// When paging backward, the query runs in the reverse order and the results are reversed afterward
+	descending := desc != cursor.previous
+	op, dir := ">", "ASC"
+	if descending {
+		op, dir = "<", "DESC"
+	}
+
+	if cursor.id != uuid.Nil {
+		q = q.Where(fmt.Sprintf("(%[1]s.%[2]s, %[1]s.id) %[3]s ((SELECT %[2]s FROM %[1]s WHERE id = ?), ?)",
+			opts.table, column, op), cursor.id, cursor.id)
+	}
+
+	limit := query.Limit()
+	q = q.Order(fmt.Sprintf("%[1]s.%[2]s %[3]s, %[1]s.id %[3]s", opts.table, column, dir))
+	if err := q.Limit(limit + 1).All(records); err != nil {
+		return api.Meta{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	list := reflect.ValueOf(records).Elem()
+	hasMore := list.Len() > limit
+	if hasMore {
+		list.Set(list.Slice(0, limit))
+	}
+	if cursor.previous {
+		swap := reflect.Swapper(list.Interface())
+		for i, j := 0, list.Len()-1; i < j; i, j = i+1, j-1 {
+			swap(i, j)
+		}
+	}
+
+	meta := api.Meta{Limit: limit, TotalRecords: total}
+	n := list.Len()
+	if n == 0 {
+		return meta, nil
+	}
+
+	hasPrev := (cursor.previous && hasMore) || (!cursor.previous && cursor.id != uuid.Nil)
+	hasNext := (!cursor.previous && hasMore) || cursor.previous
+	if hasPrev {
+		meta.PrevCursor = encodeCursor(pageCursor{id: recordID(list.Index(0)), previous: true})
+	}
+	if hasNext {
+		meta.NextCursor = encodeCursor(pageCursor{id: recordID(list.Index(n - 1))})
+	}
+	return meta, nil
+}
+
+// findList is like findPage, except that all the records are found, sorted and filtered but not paged, if the
+// api.Query has neither a limit nor a cursor. It is used by the list endpoints that returned all their records
+// before paging was added.
+func findList(q *pop.Query, query api.Query, opts listOptions, records interface{}) (api.Meta, error) {
+	if query.IsPaged() {
+		return findPage(q, query, opts, records)
+	}
+
+	q, column, desc, err := opts.apply(q, query)
+	if err != nil {
+		return api.Meta{}, err
+	}
+
+	dir := "ASC"
+	if desc {
+		dir = "DESC"
+	}
+	q = q.Order(fmt.Sprintf("%[1]s.%[2]s %[3]s, %[1]s.id %[3]s", opts.table, column, dir))
+	if err := q.All(records); err != nil {
+		return api.Meta{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	n := reflect.ValueOf(records).Elem().Len()
+	return api.Meta{Limit: n, TotalRecords: n}, nil
+}
+
+// findExport finds all the records selected by q, to be exported. If there are more than maxExportRecords, an error
+// is returned instead. The records argument must be a pointer to a slice.
+func findExport(q *pop.Query, records interface{}) error {
+	if err := q.Limit(maxExportRecords + 1).All(records); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	if reflect.ValueOf(records).Elem().Len() > maxExportRecords {
+		err := fmt.Errorf("more than %d records to export", maxExportRecords)
+		return api.NewAppError(err, api.ErrorExportTooLarge, api.CategoryUser)
+	}
+	return nil
+}
+
+// apply adds the filters of the api.Query to q and returns the column and direction of the requested sort order
+func (opts listOptions) apply(q *pop.Query, query api.Query) (*pop.Query, string, bool, error) {
+	sortField, desc := query.Sort()
+	if sortField == "" {
+		sortField, desc = opts.defaultSort, opts.defaultDesc
+	}
+	column, ok := opts.sortColumns[sortField]
+	if !ok {
+		err := fmt.Errorf("cannot sort by %q", sortField)
+		return nil, "", false, api.NewAppError(err, api.ErrorInvalidSort, api.CategoryUser)
+	}
+
+	for key, col := range opts.filterColumns {
+		if v := query.Filter(key); v != "" {
+			q = q.Where(opts.table+"."+col+" = ?", v)
+		}
+	}
+	return q, column, desc, nil
+}
+
+func recordID(v reflect.Value) uuid.UUID {
+	return v.FieldByName("ID").Interface().(uuid.UUID)
+}
+
+func encodeCursor(c pageCursor) string {
+	direction := cursorNext
+	if c.previous {
+		direction = cursorPrev
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(direction + ":" + c.id.String()))
+}
+
+func decodeCursor(s string) (pageCursor, error) {
+	if s == "" {
+		return pageCursor{}, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("invalid cursor %q: %w", s, err)
+	}
+
+	parts := strings.SplitN(string(b), ":", 2)
+	if len(parts) != 2 || (parts[0] != cursorNext && parts[0] != cursorPrev) {
+		return pageCursor{}, fmt.Errorf("invalid cursor %q", s)
+	}
+
+	id, err := uuid.FromString(parts[1])
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("invalid cursor %q: %w", s, err)
+	}
+	return pageCursor{id: id, previous: parts[0] == cursorPrev}, nil
+}
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/gobuffalo/buffalo"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestFindPage() {
+	policy := CreatePolicyFixtures(ms.DB, FixturesConfig{}).Policies[0]
+	dependents := CreatePolicyDependentFixtures(ms.DB, policy, 5).PolicyDependents
+	for i := range dependents {
+		dependents[i].Name = fmt.Sprintf("dependent %d", i)
+		ms.NoError(ms.DB.Update(&dependents[i]))
+	}
+
+	find := func(qs string) (PolicyDependents, api.Meta) {
+		values, _ := url.ParseQuery(qs)
+		var got PolicyDependents
+		meta, err := got.ByPolicy(ms.DB, policy.ID, api.NewQuery(buffalo.ParamValues(values)))
+		ms.NoError(err)
+		ms.Equal(5, meta.TotalRecords, "incorrect total")
+		return got, meta
+	}
+	names := func(deps PolicyDependents) []string {
+		n := make([]string, len(deps))
+		for i := range deps {
+			n[i] = deps[i].Name
+		}
+		return n
+	}
+
+	page1, meta1 := find("limit=2")
+	ms.Equal([]string{dependents[0].Name, dependents[1].Name}, names(page1), "incorrect first page")
+	ms.Empty(meta1.PrevCursor, "first page should not have a previous page")
+	ms.NotEmpty(meta1.NextCursor, "first page should have a next page")
+
+	page2, meta2 := find("limit=2&cursor=" + meta1.NextCursor)
+	ms.Equal([]string{dependents[2].Name, dependents[3].Name}, names(page2), "incorrect second page")
+	ms.NotEmpty(meta2.PrevCursor, "second page should have a previous page")
+	ms.NotEmpty(meta2.NextCursor, "second page should have a next page")
+
+	page3, meta3 := find("limit=2&cursor=" + meta2.NextCursor)
+	ms.Equal([]string{dependents[4].Name}, names(page3), "incorrect last page")
+	ms.Empty(meta3.NextCursor, "last page should not have a next page")
+
+	back2, _ := find("limit=2&cursor=" + meta3.PrevCursor)
+	ms.Equal(names(page2), names(back2), "incorrect page going backward")
+
+	back1, backMeta1 := find("limit=2&cursor=" + meta2.PrevCursor)
+	ms.Equal(names(page1), names(back1), "incorrect first page going backward")
+	ms.Empty(backMeta1.PrevCursor, "first page going backward should not have a previous page")
+
+	desc, _ := find("limit=1&sort=-name")
+	ms.Equal([]string{dependents[4].Name}, names(desc), "incorrect descending order")
+}
+
+func (ms *ModelSuite) TestFindList() {
+	policy := CreatePolicyFixtures(ms.DB, FixturesConfig{}).Policies[0]
+	CreatePolicyDependentFixtures(ms.DB, policy, 12)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantCount int
+		wantLimit int
+	}{
+		{
+			name:      "no paging parameters",
+			query:     "",
+			wantCount: 12,
+			wantLimit: 12,
+		},
+		{
+			name:      "sort only",
+			query:     "sort=-name",
+			wantCount: 12,
+			wantLimit: 12,
+		},
+		{
+			name:      "limit",
+			query:     "limit=5",
+			wantCount: 5,
+			wantLimit: 5,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			var got PolicyDependents
+			meta, err := got.ByPolicy(ms.DB, policy.ID, api.NewQuery(buffalo.ParamValues(values)))
+			ms.NoError(err)
+			ms.Len(got, tt.wantCount, "incorrect number of records")
+			ms.Equal(tt.wantLimit, meta.Limit, "incorrect limit")
+			ms.Equal(12, meta.TotalRecords, "incorrect total")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestFindPage_Errors() {
+	policy := CreatePolicyFixtures(ms.DB, FixturesConfig{}).Policies[0]
+
+	tests := []struct {
+		name     string
+		query    string
+		appError api.AppError
+	}{
+		{
+			name:     "invalid sort",
+			query:    "sort=child_birth_year",
+			appError: api.AppError{Key: api.ErrorInvalidSort, Category: api.CategoryUser},
+		},
+		{
+			name:     "invalid cursor",
+			query:    "cursor=abc",
+			appError: api.AppError{Key: api.ErrorInvalidCursor, Category: api.CategoryUser},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			values, _ := url.ParseQuery(tt.query)
+			var got PolicyDependents
+			_, err := got.ByPolicy(ms.DB, policy.ID, api.NewQuery(buffalo.ParamValues(values)))
+			ms.EqualAppError(tt.appError, err)
+		})
+	}
+}
+
+func (ms *ModelSuite) TestDecodeCursor() {
+	want := pageCursor{id: domain.GetUUID(), previous: true}
+
+	got, err := decodeCursor(encodeCursor(want))
+	ms.NoError(err)
+	ms.Equal(want, got, "cursor did not survive encoding")
+
+	got, err = decodeCursor("")
+	ms.NoError(err)
+	ms.Equal(pageCursor{}, got, "empty cursor should give the first page")
+
+	_, err = decodeCursor(encodeCursor(want)[1:])
+	ms.Error(err, "expected an error for a damaged cursor")
+}
@@ -262,12 +262,22 @@ func (p *Policies) All(tx *pop.Connection) error {
 	return appErrorFromDB(tx.All(p), api.ErrorQueryFailure)
 }
 
-func (p *Policies) Query(tx *pop.Connection, query api.Query) error {
-	q := tx.Order("updated_at DESC")
+var policyListOptions = listOptions{
+	table: "policies",
+	sortColumns: map[string]string{
+		"created_at": "created_at",
+		"updated_at": "updated_at",
+		"name":       "name",
+	},
+	defaultSort:   "updated_at",
+	defaultDesc:   true,
+	filterColumns: map[string]string{"type": "type"},
+}
 
-	if query.Limit() > 0 {
-		q.Limit(query.Limit())
-	}
+// Query finds a page of policies, sorted and filtered according to the api.Query. The most recently updated
+// policies are first by default.
+func (p *Policies) Query(tx *pop.Connection, query api.Query) (api.Meta, error) {
+	q := tx.Q()
 
 	if v := query.Search(); v != "" {
 		q.Scope(scopeSearchPolicies(v))
@@ -277,7 +287,7 @@ func (p *Policies) Query(tx *pop.Connection, query api.Query) error {
 		q.Scope(scopeFilterPoliciesByActive(v))
 	}
 
-	return appErrorFromDB(q.All(p), api.ErrorQueryFailure)
+	return findPage(q, query, policyListOptions, p)
 }
 
 func scopeSearchPolicies(searchText string) pop.ScopeFunc {
@@ -416,14 +426,126 @@ func (p *Policy) NewHistory(ctx context.Context, action string, fieldUpdate Fiel
 	}
 }
 
+// calculateAnnualPremium returns the total annual premium of the policy's items, including the top-up needed to
+// reach the PremiumMinimum for each item or for the policy, depending on the PremiumMinimumScope. Only items with
+// approved coverage are subject to the minimum.
 func (p *Policy) calculateAnnualPremium(tx *pop.Connection) api.Currency {
 	p.LoadItems(tx, false)
 	var premium api.Currency
+	var coveredCount int
 	for _, item := range p.Items {
-		premium += item.CalculateAnnualPremium()
+		itemPremium := item.CalculateAnnualPremium(tx)
+		premium += itemPremium
+		isCovered := item.CoverageStatus == api.ItemCoverageStatusApproved
+		if isCovered {
+			coveredCount++
+		}
+		if domain.Env.PremiumMinimumScope == domain.PremiumMinimumScopeItem {
+			premium += premiumMinimumTopUp(itemPremium, isCovered)
+		}
 	}
-	if int(premium) < domain.Env.PremiumMinimum {
-		return api.Currency(domain.Env.PremiumMinimum)
+	if domain.Env.PremiumMinimumScope == domain.PremiumMinimumScopePolicy {
+		premium += premiumMinimumTopUp(premium, coveredCount > 0)
 	}
 	return premium
 }
+
+// Ledger returns the statement of account for the Policy, with a running balance on each LedgerEntry. If year is
+// not zero, only the entries submitted in that year are included, and the balance of all earlier entries is
+// reported as the opening balance.
+func (p *Policy) Ledger(tx *pop.Connection, year int) (api.PolicyLedger, error) {
+	var entries LedgerEntries
+	if err := entries.AllForPolicy(tx, p.ID); err != nil {
+		return api.PolicyLedger{}, err
+	}
+
+	ledger := api.PolicyLedger{
+		PolicyID: p.ID,
+		Year:     year,
+		Entries:  api.PolicyLedgerEntries{},
+	}
+
+	var balance api.Currency
+	for i := range entries {
+		entryYear := entries[i].DateSubmitted.UTC().Year()
+		if year != 0 && entryYear > year {
+			break
+		}
+
+		balance += entries[i].Amount
+		if year != 0 && entryYear < year {
+			ledger.OpeningBalance = balance
+			continue
+		}
+
+		ledger.Entries = append(ledger.Entries, entries[i].ConvertToPolicyLedgerEntry(balance))
+	}
+	ledger.ClosingBalance = balance
+
+	return ledger, nil
+}
+
+var policyLedgerCsvHeader = []string{
+	"Date", "Type", "Description", "Charge", "Credit", "Balance", "Date Entered",
+}
+
+// PolicyLedgerToCsv returns a printable statement of account for the policy ledger in CSV format. Charges and
+// credits are listed in separate columns, preceded by the opening balance and followed by the closing balance.
+func PolicyLedgerToCsv(ledger api.PolicyLedger) []byte {
+	records := [][]string{
+		policyLedgerCsvHeader,
+		{"", "", "Opening Balance", "", "", ledger.OpeningBalance.String(), ""},
+	}
+	for _, e := range ledger.Entries {
+		var charge, credit, dateEntered string
+		if e.Amount < 0 {
+			credit = (-e.Amount).String()
+		} else {
+			charge = e.Amount.String()
+		}
+		if e.DateEntered != nil {
+			dateEntered = e.DateEntered.Format(domain.DateFormat)
+		}
+
+		records = append(records, []string{
+			e.DateSubmitted.Format(domain.DateFormat),
+			e.Type,
+			e.Description,
+			charge,
+			credit,
+			e.Balance.String(),
+			dateEntered,
+		})
+	}
+	records = append(records, []string{"", "", "Closing Balance", "", "", ledger.ClosingBalance.String(), ""})
+
+	return domain.CSV(records)
+}
+
+// policiesByID returns the Policies with the given IDs, with their EntityCodes, mapped by ID
+func policiesByID(tx *pop.Connection, ids []uuid.UUID) (map[uuid.UUID]Policy, error) {
+	var policies Policies
+	if err := tx.Where("id IN (?)", ids).All(&policies); err != nil {
+		return nil, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	codeIDs := make([]uuid.UUID, len(policies))
+	for i := range policies {
+		codeIDs[i] = policies[i].EntityCodeID
+	}
+	var codes EntityCodes
+	if err := tx.Where("id IN (?)", codeIDs).All(&codes); err != nil {
+		return nil, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	codesByID := map[uuid.UUID]EntityCode{}
+	for _, c := range codes {
+		codesByID[c.ID] = c
+	}
+
+	m := map[uuid.UUID]Policy{}
+	for _, p := range policies {
+		p.EntityCode = codesByID[p.EntityCodeID]
+		m[p.ID] = p
+	}
+	return m, nil
+}
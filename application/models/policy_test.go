@@ -2,7 +2,9 @@ package models
 
 import (
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gobuffalo/buffalo"
 	"github.com/gobuffalo/nulls"
@@ -474,7 +476,8 @@ func (ms *ModelSuite) TestPolicy_NewHistory() {
 }
 
 func (ms *ModelSuite) TestPolicy_calculateAnnualPremium() {
-	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2})
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 3})
+	UpdateItemStatus(ms.DB, f.Policies[0].Items[0], api.ItemCoverageStatusApproved, "")
 
 	secondItem := createItemFixture(ms.DB, f.Policies[1].ID, CreateCategoryFixtures(ms.DB, 1).ItemCategories[0].ID)
 	secondItem.CoverageAmount = int(float64(domain.Env.PremiumMinimum) / domain.Env.PremiumFactor)
@@ -488,6 +491,9 @@ func (ms *ModelSuite) TestPolicy_calculateAnnualPremium() {
 	secondPolicy := Policy{ID: f.Policies[1].ID}
 	ms.NoError(ms.DB.Reload(&secondPolicy))
 
+	draftPolicy := Policy{ID: f.Policies[2].ID}
+	ms.NoError(ms.DB.Reload(&draftPolicy))
+
 	tests := []struct {
 		name   string
 		policy Policy
@@ -501,7 +507,12 @@ func (ms *ModelSuite) TestPolicy_calculateAnnualPremium() {
 		{
 			name:   "two items, above minimum",
 			policy: secondPolicy,
-			want:   f.Policies[1].Items[0].CalculateAnnualPremium() + f.Policies[1].Items[1].CalculateAnnualPremium(),
+			want:   f.Policies[1].Items[0].CalculateAnnualPremium(ms.DB) + f.Policies[1].Items[1].CalculateAnnualPremium(ms.DB),
+		},
+		{
+			name:   "no approved items, no minimum",
+			policy: draftPolicy,
+			want:   f.Policies[2].Items[0].CalculateAnnualPremium(ms.DB),
 		},
 	}
 	for _, tt := range tests {
@@ -630,9 +641,103 @@ func (ms *ModelSuite) TestPolicies_Query() {
 			values, _ := url.ParseQuery(tt.query)
 			query := api.NewQuery(buffalo.ParamValues(values))
 
-			err := policies.Query(ms.DB, query)
+			_, err := policies.Query(ms.DB, query)
 			ms.NoError(err)
 			ms.Equal(tt.wantNumberOfPolicies, len(policies), "got wrong number of policies")
 		})
 	}
 }
+
+func (ms *ModelSuite) TestPolicy_Ledger() {
+	f := CreatePolicyFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2})
+	policy := f.Policies[0]
+
+	newEntry := func(policyID uuid.UUID, entryType LedgerEntryType, amount api.Currency, date time.Time) LedgerEntry {
+		entry := LedgerEntry{
+			PolicyID:         policyID,
+			RiskCategoryName: "Mobile",
+			Type:             entryType,
+			PolicyType:       api.PolicyTypeHousehold,
+			Amount:           amount,
+			DateSubmitted:    date,
+		}
+		ms.NoError(entry.Create(ms.DB))
+		return entry
+	}
+
+	newEntry(policy.ID, LedgerEntryTypeNewCoverage, 1000, time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+	renewal := newEntry(policy.ID, LedgerEntryTypeCoverageRenewal, 1200, time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	refund := newEntry(policy.ID, LedgerEntryTypeCoverageRefund, -300, time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC))
+	newEntry(policy.ID, LedgerEntryTypeClaim, -5000, time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC))
+	newEntry(f.Policies[1].ID, LedgerEntryTypeNewCoverage, 700, time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name        string
+		year        int
+		wantIDs     []uuid.UUID
+		wantBalance []api.Currency
+		wantOpening api.Currency
+		wantClosing api.Currency
+	}{
+		{
+			name:        "all years",
+			year:        0,
+			wantBalance: []api.Currency{1000, 2200, 1900, -3100},
+			wantOpening: 0,
+			wantClosing: -3100,
+		},
+		{
+			name:        "2021",
+			year:        2021,
+			wantIDs:     []uuid.UUID{renewal.ID, refund.ID},
+			wantBalance: []api.Currency{2200, 1900},
+			wantOpening: 1000,
+			wantClosing: 1900,
+		},
+		{
+			name:        "no entries",
+			year:        2019,
+			wantBalance: []api.Currency{},
+			wantOpening: 0,
+			wantClosing: 0,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := policy.Ledger(ms.DB, tt.year)
+			ms.NoError(err)
+
+			ms.Equal(policy.ID, got.PolicyID, "incorrect PolicyID")
+			ms.Equal(tt.year, got.Year, "incorrect Year")
+			ms.Equal(tt.wantOpening, got.OpeningBalance, "incorrect OpeningBalance")
+			ms.Equal(tt.wantClosing, got.ClosingBalance, "incorrect ClosingBalance")
+			ms.Equal(len(tt.wantBalance), len(got.Entries), "incorrect number of Entries")
+			for i := range got.Entries {
+				ms.Equal(tt.wantBalance[i], got.Entries[i].Balance, "incorrect Balance on entry %d", i)
+				if tt.wantIDs != nil {
+					ms.Equal(tt.wantIDs[i], got.Entries[i].ID, "incorrect entry %d", i)
+				}
+			}
+		})
+	}
+}
+
+func (ms *ModelSuite) TestPolicyLedgerToCsv() {
+	date := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+	ledger := api.PolicyLedger{
+		OpeningBalance: 1000,
+		ClosingBalance: 700,
+		Entries: api.PolicyLedgerEntries{
+			{Type: "CoverageRefund", Description: "refund", Amount: -300, Balance: 700, DateSubmitted: date},
+		},
+	}
+
+	got := string(PolicyLedgerToCsv(ledger))
+	lines := strings.Split(strings.TrimSpace(got), "\n")
+
+	ms.Equal(4, len(lines), "incorrect number of lines")
+	ms.Equal("Date,Type,Description,Charge,Credit,Balance,Date Entered", lines[0])
+	ms.Equal(",,Opening Balance,,,10.00,", lines[1])
+	ms.Equal("2021-07-01,CoverageRefund,refund,,3.00,7.00,", lines[2])
+	ms.Equal(",,Closing Balance,,,7.00,", lines[3])
+}
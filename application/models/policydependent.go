@@ -119,6 +119,21 @@ func (p *PolicyDependent) ConvertToAPI() api.PolicyDependent {
 	}
 }
 
+var policyDependentListOptions = listOptions{
+	table: "policy_dependents",
+	sortColumns: map[string]string{
+		"created_at": "created_at",
+		"name":       "name",
+	},
+	defaultSort:   "name",
+	filterColumns: map[string]string{"relationship": "relationship"},
+}
+
+// ByPolicy finds the dependents, or a page of the dependents, on a policy
+func (p *PolicyDependents) ByPolicy(tx *pop.Connection, policyID uuid.UUID, query api.Query) (api.Meta, error) {
+	return findList(tx.Where("policy_id = ?", policyID), query, policyDependentListOptions, p)
+}
+
 func (p *PolicyDependents) ConvertToAPI() api.PolicyDependents {
 	deps := make(api.PolicyDependents, len(*p))
 	for i, pp := range *p {
@@ -68,3 +68,50 @@ ORDER BY created_at DESC
 	}
 	return nil
 }
+
+var policyHistoryListOptions = listOptions{
+	table:         "policy_histories",
+	sortColumns:   map[string]string{"created_at": "created_at", "field_name": "field_name"},
+	defaultSort:   "created_at",
+	defaultDesc:   true,
+	filterColumns: map[string]string{"field_name": "field_name", "action": "action"},
+}
+
+// FindByPolicy finds the PolicyHistories, or a page of the PolicyHistories, of a Policy, including those of its
+// Items, newest first by default
+func (p *PolicyHistories) FindByPolicy(tx *pop.Connection, policyID uuid.UUID, query api.Query) (api.Meta, error) {
+	return findList(tx.Where("policy_id = ?", policyID), query, policyHistoryListOptions, p)
+}
+
+// FindByItem finds the PolicyHistories, or a page of the PolicyHistories, of an Item, newest first by default
+func (p *PolicyHistories) FindByItem(tx *pop.Connection, itemID uuid.UUID, query api.Query) (api.Meta, error) {
+	return findList(tx.Where("item_id = ?", itemID), query, policyHistoryListOptions, p)
+}
+
+func (p *PolicyHistories) ConvertToAPI(tx *pop.Connection) api.PolicyHistories {
+	userIDs := make([]uuid.UUID, len(*p))
+	for i, h := range *p {
+		userIDs[i] = h.UserID
+	}
+	users := historyUsers(tx, userIDs)
+
+	histories := make(api.PolicyHistories, len(*p))
+	for i, h := range *p {
+		histories[i] = h.ConvertToAPI(users[h.UserID])
+	}
+	return histories
+}
+
+func (p *PolicyHistory) ConvertToAPI(user api.HistoryUser) api.PolicyHistory {
+	return api.PolicyHistory{
+		ID:        p.ID,
+		PolicyID:  p.PolicyID,
+		ItemID:    convertUUIDToAPI(p.ItemID),
+		Action:    p.Action,
+		FieldName: p.FieldName,
+		OldValue:  p.OldValue,
+		NewValue:  p.NewValue,
+		User:      user,
+		CreatedAt: p.CreatedAt,
+	}
+}
@@ -1,5 +1,11 @@
 package models
 
+import (
+	"net/url"
+
+	"github.com/silinternational/cover-api/api"
+)
+
 func (ms *ModelSuite) TestPolicyHistories_RecentItemStatusChanges() {
 	fixtures := CreatePolicyHistoryFixtures_RecentItemStatusChanges(ms.DB)
 	phFixes := fixtures.PolicyHistories
@@ -19,3 +25,33 @@ func (ms *ModelSuite) TestPolicyHistories_RecentItemStatusChanges() {
 
 	ms.Equal(want, got, "incorrect results")
 }
+
+func (ms *ModelSuite) TestPolicyHistories_FindByItemAndPolicy() {
+	fixtures := CreatePolicyHistoryFixtures_RecentItemStatusChanges(ms.DB)
+	phFixes := fixtures.PolicyHistories
+	query := api.NewQuery(url.Values{})
+
+	var itemHistories PolicyHistories
+	meta, err := itemHistories.FindByItem(ms.DB, fixtures.Items[1].ID, query)
+	ms.NoError(err)
+	ms.Equal(4, meta.TotalRecords, "incorrect item total")
+	ms.Equal(4, len(itemHistories), "incorrect number of item histories")
+	ms.Equal(phFixes[7].ID, itemHistories[0].ID, "item histories are not in the correct order")
+	for _, h := range itemHistories {
+		ms.Equal(fixtures.Items[1].ID, h.ItemID.UUID, "history of the wrong item")
+	}
+
+	var policyHistories PolicyHistories
+	meta, err = policyHistories.FindByPolicy(ms.DB, fixtures.Policies[0].ID, query)
+	ms.NoError(err)
+	ms.Equal(len(phFixes), meta.TotalRecords, "incorrect policy total")
+	ms.Equal(len(phFixes), len(policyHistories), "incorrect number of policy histories")
+
+	var page PolicyHistories
+	pageQuery := api.NewQuery(url.Values{"limit": []string{"2"}})
+	meta, err = page.FindByPolicy(ms.DB, fixtures.Policies[0].ID, pageQuery)
+	ms.NoError(err)
+	ms.Equal(len(phFixes), meta.TotalRecords, "incorrect policy total")
+	ms.Equal(2, len(page), "incorrect number of policy histories on a page")
+	ms.NotEmpty(meta.NextCursor, "expected a next page")
+}
@@ -0,0 +1,231 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+type PremiumRates []PremiumRate
+
+// PremiumRate is the annual premium rate, as a fraction of the coverage amount, for the items in a RiskCategory or
+// an ItemCategory, starting on the EffectiveDate
+type PremiumRate struct {
+	ID             uuid.UUID  `db:"id"`
+	RiskCategoryID nulls.UUID `db:"risk_category_id"`
+	ItemCategoryID nulls.UUID `db:"item_category_id"`
+	Rate           float64    `db:"rate" validate:"gt=0,lte=1"`
+	EffectiveDate  time.Time  `db:"effective_date" validate:"required"`
+	CreatedByID    uuid.UUID  `db:"created_by_id" validate:"required"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (p *PremiumRate) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(p), nil
+}
+
+// Create validates and saves a new PremiumRate. The rate must be for either a RiskCategory or an ItemCategory, and
+// cannot take effect in the past, so that premiums already billed are not changed.
+func (p *PremiumRate) Create(ctx context.Context) error {
+	if p.RiskCategoryID.Valid == p.ItemCategoryID.Valid {
+		err := errors.New("premium rate must be for either a risk category or an item category")
+		return api.NewAppError(err, api.ErrorPremiumRateCategory, api.CategoryUser)
+	}
+
+	if p.EffectiveDate.Before(today()) {
+		err := fmt.Errorf("premium rate effective date %s is in the past", p.EffectiveDate.Format(domain.DateFormat))
+		return api.NewAppError(err, api.ErrorPremiumRateEffectiveDate, api.CategoryUser)
+	}
+
+	tx := Tx(ctx)
+	if err := p.validateCategory(tx); err != nil {
+		return err
+	}
+
+	p.CreatedByID = CurrentUser(ctx).ID
+	if err := create(tx, p); err != nil {
+		return err
+	}
+	clearPremiumRateCache(tx)
+	return nil
+}
+
+// validateCategory checks that the ItemCategory or RiskCategory of the rate exists
+func (p *PremiumRate) validateCategory(tx *pop.Connection) error {
+	var err error
+	if p.ItemCategoryID.Valid {
+		var category ItemCategory
+		err = category.FindByID(tx, p.ItemCategoryID.UUID)
+	} else {
+		var category RiskCategory
+		err = category.FindByID(tx, p.RiskCategoryID.UUID)
+	}
+	if domain.IsOtherThanNoRows(err) {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if err != nil {
+		err = fmt.Errorf("premium rate category not found: %w", err)
+		return api.NewAppError(err, api.ErrorPremiumRateCategory, api.CategoryUser)
+	}
+	return nil
+}
+
+// Destroy deletes the PremiumRate. A rate that has already taken effect cannot be deleted.
+func (p *PremiumRate) Destroy(tx *pop.Connection) error {
+	if !p.EffectiveDate.After(today()) {
+		err := fmt.Errorf("premium rate %s has already taken effect", p.ID)
+		return api.NewAppError(err, api.ErrorPremiumRateInEffect, api.CategoryUser)
+	}
+	if err := destroy(tx, p); err != nil {
+		return err
+	}
+	clearPremiumRateCache(tx)
+	return nil
+}
+
+func (p *PremiumRate) GetID() uuid.UUID {
+	return p.ID
+}
+
+func (p *PremiumRate) FindByID(tx *pop.Connection, id uuid.UUID) error {
+	return tx.Find(p, id)
+}
+
+// IsActorAllowedTo ensure the actor is an admin, since premium rates are managed by the underwriters
+func (p *PremiumRate) IsActorAllowedTo(tx *pop.Connection, actor User, perm Permission, sub SubResource, r *http.Request) bool {
+	return actor.IsAdmin()
+}
+
+// All finds all PremiumRates, ordered by effective date, most recent first
+func (p *PremiumRates) All(tx *pop.Connection) error {
+	err := tx.Order("effective_date desc, created_at desc").All(p)
+	return appErrorFromDB(err, api.ErrorQueryFailure)
+}
+
+func (p *PremiumRate) ConvertToAPI() api.PremiumRate {
+	return api.PremiumRate{
+		ID:             p.ID,
+		RiskCategoryID: convertUUIDToAPI(p.RiskCategoryID),
+		ItemCategoryID: convertUUIDToAPI(p.ItemCategoryID),
+		Rate:           p.Rate,
+		EffectiveDate:  p.EffectiveDate.Format(domain.DateFormat),
+		CreatedByID:    p.CreatedByID,
+		CreatedAt:      p.CreatedAt,
+	}
+}
+
+func (p *PremiumRates) ConvertToAPI() api.PremiumRates {
+	rates := make(api.PremiumRates, len(*p))
+	for i, pp := range *p {
+		rates[i] = pp.ConvertToAPI()
+	}
+	return rates
+}
+
+// NewPremiumRateFromApiInput creates a new PremiumRate from a PremiumRateCreate. It is not saved.
+func NewPremiumRateFromApiInput(input api.PremiumRateCreate) (PremiumRate, error) {
+	effectiveDate, err := time.Parse(domain.DateFormat, input.EffectiveDate)
+	if err != nil {
+		err = fmt.Errorf("invalid premium rate effective date '%s'", input.EffectiveDate)
+		return PremiumRate{}, api.NewAppError(err, api.ErrorPremiumRateEffectiveDate, api.CategoryUser)
+	}
+
+	rate := PremiumRate{
+		Rate:          input.Rate,
+		EffectiveDate: effectiveDate,
+	}
+	if input.RiskCategoryID != nil {
+		rate.RiskCategoryID = nulls.NewUUID(*input.RiskCategoryID)
+	}
+	if input.ItemCategoryID != nil {
+		rate.ItemCategoryID = nulls.NewUUID(*input.ItemCategoryID)
+	}
+	return rate, nil
+}
+
+// premiumFactor returns the premium rate in effect on the given date for an item in the given ItemCategory and
+// RiskCategory. A rate for the ItemCategory overrides a rate for the RiskCategory. If neither category has a rate
+// in effect, the PremiumFactor configuration is used.
+func premiumFactor(tx *pop.Connection, itemCategoryID, riskCategoryID uuid.UUID, date time.Time) float64 {
+	riskCategoryRate := domain.Env.PremiumFactor
+	foundRiskCategoryRate := false
+
+	// the rates are ordered by effective date, most recent first
+	for _, rate := range loadPremiumRates(tx) {
+		if rate.EffectiveDate.After(date) {
+			continue
+		}
+		if rate.ItemCategoryID.Valid && rate.ItemCategoryID.UUID == itemCategoryID {
+			return rate.Rate
+		}
+		if !foundRiskCategoryRate && !rate.ItemCategoryID.Valid && rate.RiskCategoryID.UUID == riskCategoryID {
+			riskCategoryRate = rate.Rate
+			foundRiskCategoryRate = true
+		}
+	}
+	return riskCategoryRate
+}
+
+type premiumRateCacheKey struct{}
+
+// premiumRateCache holds the PremiumRates loaded on a connection, so that a request that calculates the premiums of
+// many items only queries them once
+type premiumRateCache struct {
+	sync.Mutex
+	rates  PremiumRates
+	loaded bool
+}
+
+// WithPremiumRateCache returns a copy of the connection that caches the PremiumRates after they are first loaded.
+// It is intended for the connection of a single request, since changes made on other connections are not seen.
+func WithPremiumRateCache(tx *pop.Connection) *pop.Connection {
+	return tx.WithContext(context.WithValue(tx.Context(), premiumRateCacheKey{}, &premiumRateCache{}))
+}
+
+// loadPremiumRates returns all of the PremiumRates, from the connection's cache if it has one
+func loadPremiumRates(tx *pop.Connection) PremiumRates {
+	cache, ok := tx.Context().Value(premiumRateCacheKey{}).(*premiumRateCache)
+	if !ok {
+		cache = &premiumRateCache{}
+	}
+
+	cache.Lock()
+	defer cache.Unlock()
+	if !cache.loaded {
+		var rates PremiumRates
+		if err := rates.All(tx); err != nil {
+			panic("database error loading premium rates, " + err.Error())
+		}
+		cache.rates, cache.loaded = rates, true
+	}
+	return cache.rates
+}
+
+// clearPremiumRateCache makes the next call to loadPremiumRates on the connection reload the rates
+func clearPremiumRateCache(tx *pop.Connection) {
+	if cache, ok := tx.Context().Value(premiumRateCacheKey{}).(*premiumRateCache); ok {
+		cache.Lock()
+		cache.loaded = false
+		cache.Unlock()
+	}
+}
+
+// today returns the current date in UTC, at midnight
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,256 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestPremiumRate_Create() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{})
+	item := f.Items[0]
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+	tomorrow := today().AddDate(0, 0, 1)
+
+	tests := []struct {
+		name    string
+		rate    PremiumRate
+		wantErr *api.AppError
+	}{
+		{
+			name: "no category",
+			rate: PremiumRate{Rate: 0.03, EffectiveDate: tomorrow},
+			wantErr: &api.AppError{
+				Key:      api.ErrorPremiumRateCategory,
+				Category: api.CategoryUser,
+			},
+		},
+		{
+			name: "both categories",
+			rate: PremiumRate{
+				RiskCategoryID: nulls.NewUUID(item.RiskCategoryID),
+				ItemCategoryID: nulls.NewUUID(item.CategoryID),
+				Rate:           0.03,
+				EffectiveDate:  tomorrow,
+			},
+			wantErr: &api.AppError{
+				Key:      api.ErrorPremiumRateCategory,
+				Category: api.CategoryUser,
+			},
+		},
+		{
+			name: "in the past",
+			rate: PremiumRate{
+				RiskCategoryID: nulls.NewUUID(item.RiskCategoryID),
+				Rate:           0.03,
+				EffectiveDate:  today().AddDate(0, 0, -1),
+			},
+			wantErr: &api.AppError{
+				Key:      api.ErrorPremiumRateEffectiveDate,
+				Category: api.CategoryUser,
+			},
+		},
+		{
+			name: "invalid rate",
+			rate: PremiumRate{
+				RiskCategoryID: nulls.NewUUID(item.RiskCategoryID),
+				Rate:           1.5,
+				EffectiveDate:  tomorrow,
+			},
+			wantErr: &api.AppError{
+				Key:      api.ErrorValidation,
+				Category: api.CategoryUser,
+			},
+		},
+		{
+			name: "item category does not exist",
+			rate: PremiumRate{
+				ItemCategoryID: nulls.NewUUID(domain.GetUUID()),
+				Rate:           0.03,
+				EffectiveDate:  tomorrow,
+			},
+			wantErr: &api.AppError{
+				Key:      api.ErrorPremiumRateCategory,
+				Category: api.CategoryUser,
+			},
+		},
+		{
+			name: "risk category does not exist",
+			rate: PremiumRate{
+				RiskCategoryID: nulls.NewUUID(domain.GetUUID()),
+				Rate:           0.03,
+				EffectiveDate:  tomorrow,
+			},
+			wantErr: &api.AppError{
+				Key:      api.ErrorPremiumRateCategory,
+				Category: api.CategoryUser,
+			},
+		},
+		{
+			name: "good",
+			rate: PremiumRate{
+				ItemCategoryID: nulls.NewUUID(item.CategoryID),
+				Rate:           0.03,
+				EffectiveDate:  today(),
+			},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			err := tt.rate.Create(ctx)
+			if tt.wantErr != nil {
+				ms.EqualAppError(*tt.wantErr, err)
+				return
+			}
+			ms.NoError(err)
+			ms.Equal(CurrentUser(ctx).ID, tt.rate.CreatedByID, "incorrect CreatedByID")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestPremiumRate_Destroy() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{})
+	user := CreateAdminUsers(ms.DB)[AppRoleSteward]
+
+	inEffect := PremiumRate{
+		RiskCategoryID: nulls.NewUUID(f.Items[0].RiskCategoryID),
+		Rate:           0.03,
+		EffectiveDate:  today(),
+		CreatedByID:    user.ID,
+	}
+	ms.NoError(create(ms.DB, &inEffect))
+
+	future := inEffect
+	future.ID = domain.GetUUID()
+	future.EffectiveDate = today().AddDate(0, 0, 1)
+	ms.NoError(create(ms.DB, &future))
+
+	err := inEffect.Destroy(ms.DB)
+	ms.EqualAppError(api.AppError{Key: api.ErrorPremiumRateInEffect, Category: api.CategoryUser}, err)
+
+	ms.NoError(future.Destroy(ms.DB))
+}
+
+func (ms *ModelSuite) Test_loadPremiumRates() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{})
+	ctx := CreateTestContext(CreateAdminUsers(ms.DB)[AppRoleSteward])
+	tx := WithPremiumRateCache(ms.DB)
+	ctx.Set("tx", tx)
+
+	ms.Len(loadPremiumRates(tx), 0)
+
+	// a rate created outside the cached connection is not seen
+	rate := PremiumRate{
+		RiskCategoryID: nulls.NewUUID(f.Items[0].RiskCategoryID),
+		Rate:           0.03,
+		EffectiveDate:  today(),
+		CreatedByID:    CurrentUser(ctx).ID,
+	}
+	ms.NoError(create(ms.DB, &rate))
+	ms.Len(loadPremiumRates(tx), 0)
+	ms.Len(loadPremiumRates(ms.DB), 1)
+
+	// a rate created on the cached connection clears the cache
+	rate2 := PremiumRate{
+		ItemCategoryID: nulls.NewUUID(f.Items[0].CategoryID),
+		Rate:           0.04,
+		EffectiveDate:  today().AddDate(0, 0, 1),
+	}
+	ms.NoError(rate2.Create(ctx))
+	ms.Len(loadPremiumRates(tx), 2)
+
+	ms.NoError(rate2.Destroy(tx))
+	ms.Len(loadPremiumRates(tx), 1)
+}
+
+func (ms *ModelSuite) TestItem_CalculateAnnualPremiumForYear() {
+	domain.Env.PremiumFactor = 0.02
+
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	user := CreateAdminUsers(ms.DB)[AppRoleSteward]
+
+	item := f.Items[0]
+	item.CoverageAmount = 100000
+	item.CoverageStartDate = time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	newRate := func(rate PremiumRate) {
+		rate.CreatedByID = user.ID
+		ms.NoError(create(ms.DB, &rate))
+	}
+
+	// The risk category rate changes in the middle of 2021 and again in 2022
+	newRate(PremiumRate{
+		RiskCategoryID: nulls.NewUUID(item.RiskCategoryID),
+		Rate:           0.03,
+		EffectiveDate:  time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC),
+	})
+	newRate(PremiumRate{
+		RiskCategoryID: nulls.NewUUID(item.RiskCategoryID),
+		Rate:           0.04,
+		EffectiveDate:  time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+
+	tests := []struct {
+		name  string
+		item  Item
+		year  int
+		want  api.Currency
+		setup func()
+	}{
+		{
+			name: "before any rate",
+			item: item,
+			year: 2020,
+			want: 2000,
+		},
+		{
+			name: "rate change is not retroactive",
+			item: item,
+			year: 2021,
+			want: 2000,
+		},
+		{
+			name: "coverage started after the rate change",
+			item: Item{
+				CategoryID:        item.CategoryID,
+				RiskCategoryID:    item.RiskCategoryID,
+				CoverageAmount:    100000,
+				CoverageStartDate: time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC),
+			},
+			year: 2021,
+			want: 3000,
+		},
+		{
+			name: "renewal",
+			item: item,
+			year: 2022,
+			want: 4000,
+		},
+		{
+			name: "item category override",
+			item: item,
+			year: 2022,
+			want: 1000,
+			setup: func() {
+				newRate(PremiumRate{
+					ItemCategoryID: nulls.NewUUID(item.CategoryID),
+					Rate:           0.01,
+					EffectiveDate:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			got := tt.item.CalculateAnnualPremiumForYear(ms.DB, tt.year)
+			ms.Equal(tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,424 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+	"github.com/gobuffalo/pop/v5"
+	"github.com/gobuffalo/validate/v3"
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+// QueueItemStatuses are the item coverage statuses that are awaiting action by a steward
+var QueueItemStatuses = []api.ItemCoverageStatus{
+	api.ItemCoverageStatusPending,
+}
+
+// QueueClaimStatuses are the claim statuses that are awaiting action by a steward or signator
+var QueueClaimStatuses = []api.ClaimStatus{
+	api.ClaimStatusReview1,
+	api.ClaimStatusReview2,
+	api.ClaimStatusReview3,
+}
+
+type QueueAssignments []QueueAssignment
+
+// QueueAssignment assigns an Item or a Claim in the steward queue to a specific admin
+type QueueAssignment struct {
+	ID           uuid.UUID  `db:"id"`
+	ItemID       nulls.UUID `db:"item_id"`
+	ClaimID      nulls.UUID `db:"claim_id"`
+	StewardID    uuid.UUID  `db:"steward_id" validate:"required"`
+	AssignedByID uuid.UUID  `db:"assigned_by_id" validate:"required"`
+
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// QueueFilter limits the entries in the steward queue. Empty fields are not used to filter.
+type QueueFilter struct {
+	Statuses   []string
+	PolicyType api.PolicyType
+	EntityCode string
+	AssignedTo string // an admin's ID, or "none" for entries that are not assigned
+}
+
+// Validate gets run every time you call a "pop.Validate*" (pop.ValidateAndSave, pop.ValidateAndCreate, pop.ValidateAndUpdate) method.
+func (q *QueueAssignment) Validate(tx *pop.Connection) (*validate.Errors, error) {
+	return validateModel(q), nil
+}
+
+func (q *QueueAssignment) Create(tx *pop.Connection) error {
+	return create(tx, q)
+}
+
+// Assign assigns the Item, which must be awaiting review, to the admin with the given ID
+func (i *Item) Assign(ctx context.Context, stewardID uuid.UUID) (QueueAssignment, error) {
+	if i.CoverageStatus != api.ItemCoverageStatusPending {
+		err := fmt.Errorf("item with coverage status %s is not in the steward queue", i.CoverageStatus)
+		return QueueAssignment{}, api.NewAppError(err, api.ErrorQueueStatus, api.CategoryUser)
+	}
+	return assignQueueEntry(ctx, QueueAssignment{ItemID: nulls.NewUUID(i.ID), StewardID: stewardID})
+}
+
+// Unassign removes the Item's assignment
+func (i *Item) Unassign(ctx context.Context) error {
+	return unassignQueueEntry(Tx(ctx), "item_id", i.ID)
+}
+
+// Assign assigns the Claim, which must be awaiting review, to the admin with the given ID
+func (c *Claim) Assign(ctx context.Context, stewardID uuid.UUID) (QueueAssignment, error) {
+	if !isClaimStatusInQueue(c.Status) {
+		err := fmt.Errorf("claim with status %s is not in the steward queue", c.Status)
+		return QueueAssignment{}, api.NewAppError(err, api.ErrorQueueStatus, api.CategoryUser)
+	}
+	return assignQueueEntry(ctx, QueueAssignment{ClaimID: nulls.NewUUID(c.ID), StewardID: stewardID})
+}
+
+// Unassign removes the Claim's assignment
+func (c *Claim) Unassign(ctx context.Context) error {
+	return unassignQueueEntry(Tx(ctx), "claim_id", c.ID)
+}
+
+// assignQueueEntry creates the assignment, unless the entry is already assigned. Assigning an entry to the admin
+// it is already assigned to has no effect.
+func assignQueueEntry(ctx context.Context, a QueueAssignment) (QueueAssignment, error) {
+	tx := Tx(ctx)
+
+	var steward User
+	if err := steward.FindByID(tx, a.StewardID); err != nil {
+		if domain.IsOtherThanNoRows(err) {
+			return QueueAssignment{}, appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		err = fmt.Errorf("user %s not found", a.StewardID)
+		return QueueAssignment{}, api.NewAppError(err, api.ErrorQueueInvalidSteward, api.CategoryUser)
+	}
+	if !steward.IsAdmin() {
+		err := fmt.Errorf("user %s is not an admin", a.StewardID)
+		return QueueAssignment{}, api.NewAppError(err, api.ErrorQueueInvalidSteward, api.CategoryUser)
+	}
+
+	var existing QueueAssignment
+	q := tx.Where("claim_id = ?", a.ClaimID)
+	if a.ItemID.Valid {
+		q = tx.Where("item_id = ?", a.ItemID)
+	}
+	if err := q.First(&existing); domain.IsOtherThanNoRows(err) {
+		return QueueAssignment{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if existing.ID != uuid.Nil {
+		if existing.StewardID == a.StewardID {
+			return existing, nil
+		}
+		err := fmt.Errorf("already assigned to user %s", existing.StewardID)
+		return QueueAssignment{}, api.NewAppError(err, api.ErrorQueueAlreadyAssigned, api.CategoryUser)
+	}
+
+	a.AssignedByID = CurrentUser(ctx).ID
+	if err := a.Create(tx); err != nil {
+		return QueueAssignment{}, err
+	}
+	return a, nil
+}
+
+func unassignQueueEntry(tx *pop.Connection, column string, id uuid.UUID) error {
+	var a QueueAssignment
+	if err := tx.Where(column+" = ?", id).First(&a); err != nil {
+		if domain.IsOtherThanNoRows(err) {
+			return appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		err = fmt.Errorf("%s %s is not assigned", column, id)
+		return api.NewAppError(err, api.ErrorQueueNotAssigned, api.CategoryUser)
+	}
+	return appErrorFromDB(tx.Destroy(&a), api.ErrorQueryFailure)
+}
+
+// clearQueueAssignment removes any assignment of the Item or Claim, once it is no longer in the steward queue
+func clearQueueAssignment(tx *pop.Connection, column string, id uuid.UUID) error {
+	var assignments QueueAssignments
+	if err := tx.Where(column+" = ?", id).All(&assignments); err != nil {
+		return appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	if len(assignments) == 0 {
+		return nil
+	}
+	return appErrorFromDB(tx.Destroy(&assignments), api.ErrorQueryFailure)
+}
+
+func isItemStatusInQueue(status api.ItemCoverageStatus) bool {
+	for _, s := range QueueItemStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func isClaimStatusInQueue(status api.ClaimStatus) bool {
+	for _, s := range QueueClaimStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// StewardQueue lists the Items and Claims that are awaiting action by a steward or signator, oldest first. The
+// policies, submission times, and assignments of the entries are loaded with one query each.
+func StewardQueue(tx *pop.Connection, filter QueueFilter) (api.StewardQueue, error) {
+	itemStatuses, claimStatuses := filter.statuses()
+
+	queue := api.StewardQueue{}
+	now := time.Now().UTC()
+
+	if len(itemStatuses) > 0 {
+		var items Items
+		if err := filter.policyQuery(tx).Where("coverage_status IN (?)", itemStatuses).All(&items); err != nil {
+			return nil, appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		entries, err := items.queueEntries(tx, now)
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, entries...)
+	}
+
+	if len(claimStatuses) > 0 {
+		var claims Claims
+		if err := filter.policyQuery(tx).Where("status IN (?)", claimStatuses).All(&claims); err != nil {
+			return nil, appErrorFromDB(err, api.ErrorQueryFailure)
+		}
+		entries, err := claims.queueEntries(tx, now)
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, entries...)
+	}
+
+	filtered := api.StewardQueue{}
+	for _, entry := range queue {
+		if filter.isAssignmentMatch(entry) {
+			filtered = append(filtered, entry)
+		}
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].SubmittedAt.Before(filtered[j].SubmittedAt) })
+	return filtered, nil
+}
+
+// statuses splits the status filter into item and claim statuses. Statuses not in the queue are ignored.
+func (f QueueFilter) statuses() ([]api.ItemCoverageStatus, []api.ClaimStatus) {
+	if len(f.Statuses) == 0 {
+		return QueueItemStatuses, QueueClaimStatuses
+	}
+
+	var itemStatuses []api.ItemCoverageStatus
+	for _, s := range QueueItemStatuses {
+		if domain.IsStringInSlice(string(s), f.Statuses) {
+			itemStatuses = append(itemStatuses, s)
+		}
+	}
+
+	var claimStatuses []api.ClaimStatus
+	for _, s := range QueueClaimStatuses {
+		if domain.IsStringInSlice(string(s), f.Statuses) {
+			claimStatuses = append(claimStatuses, s)
+		}
+	}
+	return itemStatuses, claimStatuses
+}
+
+// policyQuery returns a query limited to objects on policies matching the policy type and entity code filters
+func (f QueueFilter) policyQuery(tx *pop.Connection) *pop.Query {
+	q := tx.Q()
+	if f.PolicyType != "" {
+		q = q.Where("policy_id IN (SELECT id FROM policies WHERE type = ?)", f.PolicyType)
+	}
+	if f.EntityCode != "" {
+		q = q.Where(`policy_id IN (SELECT p.id FROM policies p
+			JOIN entity_codes e ON e.id = p.entity_code_id WHERE e.code = ?)`, f.EntityCode)
+	}
+	return q
+}
+
+func (f QueueFilter) isAssignmentMatch(entry api.StewardQueueEntry) bool {
+	switch f.AssignedTo {
+	case "":
+		return true
+	case "none":
+		return entry.AssignedToID == nil
+	default:
+		return entry.AssignedToID != nil && entry.AssignedToID.String() == f.AssignedTo
+	}
+}
+
+// queueAssignments returns the QueueAssignments of the Items or Claims with the given IDs, mapped by the ID of the
+// Item or Claim
+func queueAssignments(tx *pop.Connection, column string, ids []uuid.UUID) (map[uuid.UUID]QueueAssignment, error) {
+	var assignments QueueAssignments
+	if err := tx.Where(column+" IN (?)", ids).All(&assignments); err != nil {
+		return nil, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	m := map[uuid.UUID]QueueAssignment{}
+	for _, a := range assignments {
+		if a.ItemID.Valid {
+			m[a.ItemID.UUID] = a
+		} else {
+			m[a.ClaimID.UUID] = a
+		}
+	}
+	return m, nil
+}
+
+// queueEntries converts the Items to steward queue entries
+func (i Items) queueEntries(tx *pop.Connection, now time.Time) (api.StewardQueue, error) {
+	if len(i) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(i))
+	policyIDs := make([]uuid.UUID, len(i))
+	for n := range i {
+		ids[n] = i[n].ID
+		policyIDs[n] = i[n].PolicyID
+	}
+
+	policies, err := policiesByID(tx, policyIDs)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := queueAssignments(tx, "item_id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// the most recent submission of each Item
+	var histories PolicyHistories
+	err = tx.Where("item_id IN (?) AND field_name = ? AND action = ? AND new_value = ?",
+		ids, FieldItemCoverageStatus, api.HistoryActionUpdate, api.ItemCoverageStatusPending).
+		Order("created_at desc").All(&histories)
+	if err != nil {
+		return nil, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	submittedAt := map[uuid.UUID]time.Time{}
+	for _, h := range histories {
+		if _, ok := submittedAt[h.ItemID.UUID]; !ok {
+			submittedAt[h.ItemID.UUID] = h.CreatedAt
+		}
+	}
+
+	entries := make(api.StewardQueue, len(i))
+	for n, item := range i {
+		policy := policies[item.PolicyID]
+		entries[n] = api.StewardQueueEntry{
+			Type:       domain.TypeItem,
+			ID:         item.ID,
+			Name:       item.Name,
+			Status:     string(item.CoverageStatus),
+			PolicyID:   item.PolicyID,
+			PolicyType: policy.Type,
+			EntityCode: policy.EntityCode.Code,
+		}
+		submitted, ok := submittedAt[item.ID]
+		if !ok {
+			submitted = item.UpdatedAt
+		}
+		setQueueAge(&entries[n], submitted, now, domain.Env.QueueItemSLADays)
+		setQueueAssignment(&entries[n], assignments[item.ID])
+	}
+	return entries, nil
+}
+
+// queueEntries converts the Claims to steward queue entries
+func (c Claims) queueEntries(tx *pop.Connection, now time.Time) (api.StewardQueue, error) {
+	if len(c) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]uuid.UUID, len(c))
+	policyIDs := make([]uuid.UUID, len(c))
+	for n := range c {
+		ids[n] = c[n].ID
+		policyIDs[n] = c[n].PolicyID
+	}
+
+	policies, err := policiesByID(tx, policyIDs)
+	if err != nil {
+		return nil, err
+	}
+	assignments, err := queueAssignments(tx, "claim_id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// the first submission of each Claim, as in Claim.SubmittedAt
+	var histories ClaimHistories
+	err = tx.Where("claim_id IN (?) AND field_name = ? AND action = ? AND new_value = ?",
+		ids, FieldClaimStatus, api.HistoryActionUpdate, api.ClaimStatusReview1).
+		Order("created_at asc").All(&histories)
+	if err != nil {
+		return nil, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+	submittedAt := map[uuid.UUID]time.Time{}
+	for _, h := range histories {
+		if _, ok := submittedAt[h.ClaimID]; !ok {
+			submittedAt[h.ClaimID] = h.CreatedAt
+		}
+	}
+
+	entries := make(api.StewardQueue, len(c))
+	for n, claim := range c {
+		policy := policies[claim.PolicyID]
+		entries[n] = api.StewardQueueEntry{
+			Type:       domain.TypeClaim,
+			ID:         claim.ID,
+			Name:       claim.ReferenceNumber,
+			Status:     string(claim.Status),
+			PolicyID:   claim.PolicyID,
+			PolicyType: policy.Type,
+			EntityCode: policy.EntityCode.Code,
+		}
+		submitted, ok := submittedAt[claim.ID]
+		if !ok {
+			submitted = claim.UpdatedAt
+		}
+		setQueueAge(&entries[n], submitted, now, domain.Env.QueueClaimSLADays)
+		setQueueAssignment(&entries[n], assignments[claim.ID])
+	}
+	return entries, nil
+}
+
+// setQueueAge sets the age and service level agreement fields of the queue entry
+func setQueueAge(entry *api.StewardQueueEntry, submittedAt, now time.Time, slaDays int) {
+	entry.SubmittedAt = submittedAt
+	entry.AgeHours = int(now.Sub(submittedAt).Hours())
+	entry.SLADueAt = submittedAt.AddDate(0, 0, slaDays)
+	entry.SLABreached = now.After(entry.SLADueAt)
+}
+
+// setQueueAssignment sets the assignment fields of the queue entry, if it is assigned
+func setQueueAssignment(entry *api.StewardQueueEntry, a QueueAssignment) {
+	if a.ID == uuid.Nil {
+		return
+	}
+	entry.AssignedToID = &a.StewardID
+	entry.AssignedAt = &a.CreatedAt
+}
+
+func (a *QueueAssignment) ConvertToAPI() api.QueueAssignment {
+	return api.QueueAssignment{
+		ID:           a.ID,
+		ItemID:       convertUUIDToAPI(a.ItemID),
+		ClaimID:      convertUUIDToAPI(a.ClaimID),
+		StewardID:    a.StewardID,
+		AssignedByID: a.AssignedByID,
+		CreatedAt:    a.CreatedAt,
+	}
+}
@@ -0,0 +1,211 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+func (ms *ModelSuite) TestItem_Assign() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2})
+	admins := CreateAdminUsers(ms.DB)
+	steward := admins[AppRoleSteward]
+	signator := admins[AppRoleSignator]
+	ctx := CreateTestContext(steward)
+
+	pending := UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusPending, "")
+	draft := f.Items[1]
+
+	tests := []struct {
+		name      string
+		item      Item
+		stewardID uuid.UUID
+		appError  *api.AppError
+	}{
+		{
+			name:      "not in queue",
+			item:      draft,
+			stewardID: steward.ID,
+			appError:  &api.AppError{Key: api.ErrorQueueStatus, Category: api.CategoryUser},
+		},
+		{
+			name:      "not an admin",
+			item:      pending,
+			stewardID: f.Users[0].ID,
+			appError:  &api.AppError{Key: api.ErrorQueueInvalidSteward, Category: api.CategoryUser},
+		},
+		{
+			name:      "no such user",
+			item:      pending,
+			stewardID: domain.GetUUID(),
+			appError:  &api.AppError{Key: api.ErrorQueueInvalidSteward, Category: api.CategoryUser},
+		},
+		{
+			name:      "good",
+			item:      pending,
+			stewardID: steward.ID,
+		},
+		{
+			name:      "same steward again",
+			item:      pending,
+			stewardID: steward.ID,
+		},
+		{
+			name:      "already assigned",
+			item:      pending,
+			stewardID: signator.ID,
+			appError:  &api.AppError{Key: api.ErrorQueueAlreadyAssigned, Category: api.CategoryUser},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := tt.item.Assign(ctx, tt.stewardID)
+			if tt.appError != nil {
+				ms.EqualAppError(*tt.appError, err)
+				return
+			}
+			ms.NoError(err)
+			ms.Equal(tt.stewardID, got.StewardID, "incorrect steward")
+			ms.Equal(steward.ID, got.AssignedByID, "incorrect assigned by")
+			ms.Equal(tt.item.ID, got.ItemID.UUID, "incorrect item")
+
+			n, err := ms.DB.Where("item_id = ?", tt.item.ID).Count(&QueueAssignment{})
+			ms.NoError(err)
+			ms.Equal(1, n, "incorrect number of assignments")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestClaim_Unassign() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ClaimsPerPolicy: 1, ClaimItemsPerClaim: 1})
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+	ctx := CreateTestContext(steward)
+
+	claim := UpdateClaimStatus(ms.DB, f.Claims[0], api.ClaimStatusReview2, "")
+
+	err := claim.Unassign(ctx)
+	ms.EqualAppError(api.AppError{Key: api.ErrorQueueNotAssigned, Category: api.CategoryUser}, err)
+
+	_, err = claim.Assign(ctx, steward.ID)
+	ms.NoError(err)
+
+	ms.NoError(claim.Unassign(ctx))
+
+	n, err := ms.DB.Where("claim_id = ?", claim.ID).Count(&QueueAssignment{})
+	ms.NoError(err)
+	ms.Equal(0, n, "assignment was not removed")
+}
+
+func (ms *ModelSuite) TestStewardQueue() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{NumberOfPolicies: 2, ItemsPerPolicy: 2, ClaimsPerPolicy: 1})
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+	ctx := CreateTestContext(steward)
+
+	oldItem := UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusPending, "")
+	oldItem.UpdatedAt = time.Now().UTC().AddDate(0, 0, -domain.Env.QueueItemSLADays-1)
+	ms.NoError(ms.DB.RawQuery("UPDATE items SET updated_at = ? WHERE id = ?", oldItem.UpdatedAt, oldItem.ID).Exec())
+
+	newItem := UpdateItemStatus(ms.DB, f.Items[2], api.ItemCoverageStatusPending, "")
+	claim := UpdateClaimStatus(ms.DB, f.Claims[1], api.ClaimStatusReview1, "")
+	_ = UpdateItemStatus(ms.DB, f.Items[3], api.ItemCoverageStatusApproved, "")
+
+	_, err := newItem.Assign(ctx, steward.ID)
+	ms.NoError(err)
+
+	tests := []struct {
+		name    string
+		filter  QueueFilter
+		wantIDs []uuid.UUID
+	}{
+		{
+			name:    "all",
+			filter:  QueueFilter{},
+			wantIDs: []uuid.UUID{oldItem.ID, newItem.ID, claim.ID},
+		},
+		{
+			name:    "claims only",
+			filter:  QueueFilter{Statuses: []string{string(api.ClaimStatusReview1), string(api.ClaimStatusReview2)}},
+			wantIDs: []uuid.UUID{claim.ID},
+		},
+		{
+			name:    "assigned",
+			filter:  QueueFilter{AssignedTo: steward.ID.String()},
+			wantIDs: []uuid.UUID{newItem.ID},
+		},
+		{
+			name:    "not assigned",
+			filter:  QueueFilter{AssignedTo: "none", Statuses: []string{string(api.ItemCoverageStatusPending)}},
+			wantIDs: []uuid.UUID{oldItem.ID},
+		},
+		{
+			name:    "policy type with no match",
+			filter:  QueueFilter{PolicyType: api.PolicyTypeTeam},
+			wantIDs: []uuid.UUID{},
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got, err := StewardQueue(ms.DB, tt.filter)
+			ms.NoError(err)
+
+			ids := make([]uuid.UUID, len(got))
+			for i := range got {
+				ids[i] = got[i].ID
+			}
+			ms.Equal(tt.wantIDs, ids, "incorrect queue entries")
+		})
+	}
+
+	queue, err := StewardQueue(ms.DB, QueueFilter{})
+	ms.NoError(err)
+	ms.Equal(3, len(queue))
+
+	ms.Equal(domain.TypeItem, queue[0].Type, "incorrect type")
+	ms.True(queue[0].SLABreached, "old item should have breached the SLA")
+	ms.Nil(queue[0].AssignedToID, "old item should not be assigned")
+
+	ms.False(queue[1].SLABreached, "new item should not have breached the SLA")
+	ms.NotNil(queue[1].AssignedToID, "new item should be assigned")
+	ms.Equal(steward.ID, *queue[1].AssignedToID, "incorrect assignment")
+
+	ms.Equal(domain.TypeClaim, queue[2].Type, "incorrect type")
+	ms.Equal(claim.ReferenceNumber, queue[2].Name, "incorrect claim name")
+	ms.Equal(f.Policies[1].ID, queue[2].PolicyID, "incorrect policy")
+}
+
+func (ms *ModelSuite) TestQueueAssignment_ClearedOnStatusChange() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2, ClaimsPerPolicy: 2, ClaimItemsPerClaim: 1})
+	steward := CreateAdminUsers(ms.DB)[AppRoleSteward]
+	ctx := CreateTestContext(steward)
+
+	deniedItem := UpdateItemStatus(ms.DB, f.Items[0], api.ItemCoverageStatusPending, "")
+	deniedClaim := UpdateClaimStatus(ms.DB, f.Claims[0], api.ClaimStatusReview2, "")
+	reviewedClaim := UpdateClaimStatus(ms.DB, f.Claims[1], api.ClaimStatusReview2, "")
+
+	_, err := deniedItem.Assign(ctx, steward.ID)
+	ms.NoError(err)
+	_, err = deniedClaim.Assign(ctx, steward.ID)
+	ms.NoError(err)
+	_, err = reviewedClaim.Assign(ctx, steward.ID)
+	ms.NoError(err)
+
+	ms.NoError(deniedItem.Deny(ctx, "not covered"))
+	ms.NoError(deniedClaim.Deny(ctx, "not covered"))
+	ms.NoError(reviewedClaim.Approve(ctx, api.ClaimApproveInput{}))
+
+	n, err := ms.DB.Where("item_id = ?", deniedItem.ID).Count(&QueueAssignment{})
+	ms.NoError(err)
+	ms.Equal(0, n, "assignment of a denied item was not removed")
+
+	n, err = ms.DB.Where("claim_id = ?", deniedClaim.ID).Count(&QueueAssignment{})
+	ms.NoError(err)
+	ms.Equal(0, n, "assignment of a denied claim was not removed")
+
+	n, err = ms.DB.Where("claim_id = ?", reviewedClaim.ID).Count(&QueueAssignment{})
+	ms.NoError(err)
+	ms.Equal(1, n, "assignment of a claim still in the queue should be kept")
+}
@@ -0,0 +1,208 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/pop/v5"
+
+	"github.com/silinternational/cover-api/api"
+	"github.com/silinternational/cover-api/domain"
+)
+
+var validReportPeriods = map[string]struct{}{
+	api.ReportPeriodMonth:   {},
+	api.ReportPeriodQuarter: {},
+	api.ReportPeriodYear:    {},
+}
+
+var validReportGroups = map[string]struct{}{
+	api.ReportGroupType:         {},
+	api.ReportGroupRiskCategory: {},
+	api.ReportGroupEntityCode:   {},
+	api.ReportGroupPolicyType:   {},
+}
+
+// DefaultReportGroups are the dimensions used to group a report if none are given
+var DefaultReportGroups = []string{api.ReportGroupRiskCategory, api.ReportGroupEntityCode, api.ReportGroupPolicyType}
+
+// reportGroupColumns are the ledger_entries columns for each report grouping dimension, in the order of the row fields
+var reportGroupColumns = []struct{ group, column string }{
+	{api.ReportGroupType, "type"},
+	{api.ReportGroupRiskCategory, "risk_category_name"},
+	{api.ReportGroupEntityCode, "entity_code"},
+	{api.ReportGroupPolicyType, "policy_type"},
+}
+
+// lossRatioTotals holds the totals for one row of a loss ratio report, as aggregated by the database
+type lossRatioTotals struct {
+	PeriodStart  time.Time      `db:"period_start"`
+	Type         string         `db:"type"`
+	RiskCategory string         `db:"risk_category"`
+	EntityCode   string         `db:"entity_code"`
+	PolicyType   api.PolicyType `db:"policy_type"`
+	Premiums     api.Currency   `db:"premiums"`
+	Refunds      api.Currency   `db:"refunds"`
+	Claims       api.Currency   `db:"claims"`
+	EntryCount   int            `db:"entry_count"`
+	ItemCount    int            `db:"item_count"`
+	ClaimCount   int            `db:"claim_count"`
+}
+
+// lossRatioTotalsQuery selects the totals of the ledger entries submitted between two dates. Refunds and claims are
+// credits to the policy, so their sign is reversed. Only approved claims have ledger entries, so draft and
+// withdrawn claims (see ClaimStatus.IsFiled) are never counted. The %s verbs are replaced by the grouping columns
+// and the GROUP BY clause.
+const lossRatioTotalsQuery = `SELECT %s
+	COALESCE(SUM(amount) FILTER (WHERE kind = 'premium'), 0) AS premiums,
+	COALESCE(-SUM(amount) FILTER (WHERE kind = 'refund'), 0) AS refunds,
+	COALESCE(-SUM(amount) FILTER (WHERE kind = 'claim'), 0) AS claims,
+	COUNT(*) AS entry_count,
+	COUNT(DISTINCT item_id) FILTER (WHERE kind <> 'claim') AS item_count,
+	COUNT(DISTINCT claim_id) FILTER (WHERE kind = 'claim') AS claim_count
+FROM (
+	SELECT *, CASE WHEN type IN (?, ?, ?) THEN 'claim' WHEN type = ? THEN 'refund' ELSE 'premium' END AS kind
+	FROM ledger_entries
+	WHERE date_submitted BETWEEN ? AND ?
+) AS e
+%s`
+
+// LossRatioReport totals the LedgerEntries submitted from startDate to endDate, inclusive, by period and by the
+// groupBy dimensions. Entries are counted as premiums, refunds, or claims according to their type. The totals are
+// aggregated by the database, so the size of the report depends only on the number of rows.
+func LossRatioReport(tx *pop.Connection, startDate, endDate time.Time, period string,
+	groupBy []string) (api.LossRatioReport, error) {
+	if _, ok := validReportPeriods[period]; !ok {
+		err := fmt.Errorf("invalid report period '%s'", period)
+		return api.LossRatioReport{}, api.NewAppError(err, api.ErrorReportInvalidParameter, api.CategoryUser)
+	}
+	for _, g := range groupBy {
+		if _, ok := validReportGroups[g]; !ok {
+			err := fmt.Errorf("invalid report group '%s'", g)
+			return api.LossRatioReport{}, api.NewAppError(err, api.ErrorReportInvalidParameter, api.CategoryUser)
+		}
+	}
+	if endDate.Before(startDate) {
+		err := fmt.Errorf("end date is before start date")
+		return api.LossRatioReport{}, api.NewAppError(err, api.ErrorReportInvalidParameter, api.CategoryUser)
+	}
+
+	args := []interface{}{
+		LedgerEntryTypeClaim, LedgerEntryTypeClaimAdjustment, LedgerEntryTypeClaimRecovery,
+		LedgerEntryTypeCoverageRefund, startDate, endDate,
+	}
+
+	// the period is one of validReportPeriods, which are also valid date_trunc fields
+	columns := fmt.Sprintf("date_trunc('%s', date_submitted)::date AS period_start,", period)
+	groups := []string{"period_start"}
+	for _, c := range reportGroupColumns {
+		if !domain.IsStringInSlice(c.group, groupBy) {
+			columns += fmt.Sprintf(" '' AS %s,", c.group)
+			continue
+		}
+		columns += fmt.Sprintf(" %s AS %s,", c.column, c.group)
+		groups = append(groups, c.group)
+	}
+
+	var rows []lossRatioTotals
+	q := fmt.Sprintf(lossRatioTotalsQuery, columns, "GROUP BY "+strings.Join(groups, ", "))
+	if err := tx.RawQuery(q, args...).All(&rows); err != nil {
+		return api.LossRatioReport{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	var total lossRatioTotals
+	if err := tx.RawQuery(fmt.Sprintf(lossRatioTotalsQuery, "", ""), args...).First(&total); err != nil {
+		return api.LossRatioReport{}, appErrorFromDB(err, api.ErrorQueryFailure)
+	}
+
+	report := api.LossRatioReport{
+		StartDate: startDate.Format(domain.DateFormat),
+		EndDate:   endDate.Format(domain.DateFormat),
+		Period:    period,
+		GroupBy:   groupBy,
+		Rows:      make([]api.LossRatioReportRow, len(rows)),
+		Total:     total.finish(),
+	}
+	for i, r := range rows {
+		report.Rows[i] = r.finish()
+		report.Rows[i].PeriodStart = r.PeriodStart.Format(domain.DateFormat)
+	}
+	sort.Slice(report.Rows, func(i, j int) bool { return lossRatioRowLess(report.Rows[i], report.Rows[j]) })
+
+	return report, nil
+}
+
+// finish calculates the net premiums and loss ratio and returns the completed row, without the period
+func (t lossRatioTotals) finish() api.LossRatioReportRow {
+	row := api.LossRatioReportRow{
+		Type:         t.Type,
+		RiskCategory: t.RiskCategory,
+		EntityCode:   t.EntityCode,
+		PolicyType:   t.PolicyType,
+		Premiums:     t.Premiums,
+		Refunds:      t.Refunds,
+		NetPremiums:  t.Premiums - t.Refunds,
+		Claims:       t.Claims,
+		EntryCount:   t.EntryCount,
+		ItemCount:    t.ItemCount,
+		ClaimCount:   t.ClaimCount,
+	}
+	if row.NetPremiums > 0 {
+		row.LossRatio = math.Round(float64(row.Claims)/float64(row.NetPremiums)*10000) / 10000
+	}
+	return row
+}
+
+func lossRatioRowLess(a, b api.LossRatioReportRow) bool {
+	if a.PeriodStart != b.PeriodStart {
+		return a.PeriodStart < b.PeriodStart
+	}
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	if a.RiskCategory != b.RiskCategory {
+		return a.RiskCategory < b.RiskCategory
+	}
+	if a.EntityCode != b.EntityCode {
+		return a.EntityCode < b.EntityCode
+	}
+	return a.PolicyType < b.PolicyType
+}
+
+var lossRatioCsvHeader = []string{
+	"Period Start", "Type", "Risk Category", "Entity Code", "Policy Type", "Premiums", "Refunds", "Net Premiums",
+	"Claims", "Loss Ratio", "Entry Count", "Item Count", "Claim Count",
+}
+
+// LossRatioReportToCsv returns the rows of the report, followed by the totals, in CSV format
+func LossRatioReportToCsv(report api.LossRatioReport) []byte {
+	records := [][]string{lossRatioCsvHeader}
+	for _, r := range report.Rows {
+		records = append(records, lossRatioRowToCsv(r, r.PeriodStart))
+	}
+	records = append(records, lossRatioRowToCsv(report.Total, "Total"))
+
+	return domain.CSV(records)
+}
+
+func lossRatioRowToCsv(r api.LossRatioReportRow, period string) []string {
+	return []string{
+		period,
+		r.Type,
+		r.RiskCategory,
+		r.EntityCode,
+		string(r.PolicyType),
+		r.Premiums.String(),
+		r.Refunds.String(),
+		r.NetPremiums.String(),
+		r.Claims.String(),
+		strconv.FormatFloat(r.LossRatio, 'f', 4, 64),
+		strconv.Itoa(r.EntryCount),
+		strconv.Itoa(r.ItemCount),
+		strconv.Itoa(r.ClaimCount),
+	}
+}
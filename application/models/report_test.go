@@ -0,0 +1,130 @@
+package models
+
+import (
+	"encoding/csv"
+	"strings"
+	"time"
+
+	"github.com/gobuffalo/nulls"
+
+	"github.com/silinternational/cover-api/api"
+)
+
+func (ms *ModelSuite) TestLossRatioReport() {
+	f := CreateItemFixtures(ms.DB, FixturesConfig{ItemsPerPolicy: 2, ClaimsPerPolicy: 1})
+	policy := f.Policies[0]
+	mobileItem, stationaryItem := f.Items[0], f.Items[1]
+
+	date := func(month time.Month, day int) time.Time { return time.Date(2021, month, day, 0, 0, 0, 0, time.UTC) }
+	newEntry := func(entryType LedgerEntryType, amount api.Currency, submitted time.Time, item Item) LedgerEntry {
+		e := LedgerEntry{
+			PolicyID:         policy.ID,
+			ItemID:           nulls.NewUUID(item.ID),
+			EntityCode:       "EC1",
+			RiskCategoryName: "Mobile",
+			Type:             entryType,
+			PolicyType:       api.PolicyTypeHousehold,
+			Amount:           amount,
+			DateSubmitted:    submitted,
+		}
+		if entryType.IsClaim() {
+			e.ClaimID = nulls.NewUUID(f.Claims[0].ID)
+		}
+		if item.ID == stationaryItem.ID {
+			e.EntityCode = "EC2"
+			e.RiskCategoryName = "Stationary"
+			e.PolicyType = api.PolicyTypeTeam
+		}
+		return e
+	}
+
+	entries := []LedgerEntry{
+		newEntry(LedgerEntryTypeNewCoverage, 10000, date(1, 10), mobileItem),
+		newEntry(LedgerEntryTypeCoverageRefund, -2000, date(2, 5), mobileItem),
+		newEntry(LedgerEntryTypeNewCoverage, 5000, date(2, 20), stationaryItem),
+		newEntry(LedgerEntryTypeClaim, -4000, date(3, 15), mobileItem),
+		newEntry(LedgerEntryTypeClaimRecovery, 1000, date(3, 20), mobileItem),
+		newEntry(LedgerEntryTypeCoverageRenewal, 9999, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), mobileItem),
+	}
+	for i := range entries {
+		MustCreate(ms.DB, &entries[i])
+	}
+
+	start, end := date(1, 1), date(12, 31)
+
+	report, err := LossRatioReport(ms.DB, start, end, api.ReportPeriodQuarter, []string{api.ReportGroupRiskCategory})
+	ms.NoError(err)
+	ms.Equal("2021-01-01", report.StartDate, "incorrect start date")
+	ms.Equal("2021-12-31", report.EndDate, "incorrect end date")
+	ms.Equal([]api.LossRatioReportRow{
+		{
+			PeriodStart:  "2021-01-01",
+			RiskCategory: "Mobile",
+			Premiums:     10000,
+			Refunds:      2000,
+			NetPremiums:  8000,
+			Claims:       3000,
+			LossRatio:    0.375,
+			EntryCount:   4,
+			ItemCount:    1,
+			ClaimCount:   1,
+		},
+		{
+			PeriodStart:  "2021-01-01",
+			RiskCategory: "Stationary",
+			Premiums:     5000,
+			NetPremiums:  5000,
+			EntryCount:   1,
+			ItemCount:    1,
+		},
+	}, report.Rows, "incorrect rows")
+	ms.Equal(api.LossRatioReportRow{
+		Premiums:    15000,
+		Refunds:     2000,
+		NetPremiums: 13000,
+		Claims:      3000,
+		LossRatio:   0.2308,
+		EntryCount:  5,
+		ItemCount:   2,
+		ClaimCount:  1,
+	}, report.Total, "incorrect total")
+
+	report, err = LossRatioReport(ms.DB, start, end, api.ReportPeriodMonth, DefaultReportGroups)
+	ms.NoError(err)
+	ms.Equal(4, len(report.Rows), "incorrect number of monthly rows")
+	ms.Equal("2021-03-01", report.Rows[3].PeriodStart, "incorrect period of the last row")
+	ms.Equal("EC1", report.Rows[3].EntityCode, "incorrect entity code of the last row")
+	ms.Equal(api.PolicyTypeHousehold, report.Rows[3].PolicyType, "incorrect policy type of the last row")
+	ms.Equal(0.0, report.Rows[3].LossRatio, "loss ratio should be 0 without premiums")
+
+	report, err = LossRatioReport(ms.DB, start, end, api.ReportPeriodYear, []string{api.ReportGroupType})
+	ms.NoError(err)
+	ms.Equal(4, len(report.Rows), "incorrect number of rows by type")
+	ms.Equal(string(LedgerEntryTypeClaim), report.Rows[0].Type, "incorrect type of the first row")
+
+	_, err = LossRatioReport(ms.DB, start, end, "week", nil)
+	ms.EqualAppError(api.AppError{Key: api.ErrorReportInvalidParameter, Category: api.CategoryUser}, err)
+
+	_, err = LossRatioReport(ms.DB, start, end, api.ReportPeriodMonth, []string{"country"})
+	ms.EqualAppError(api.AppError{Key: api.ErrorReportInvalidParameter, Category: api.CategoryUser}, err)
+
+	_, err = LossRatioReport(ms.DB, end, start, api.ReportPeriodMonth, nil)
+	ms.EqualAppError(api.AppError{Key: api.ErrorReportInvalidParameter, Category: api.CategoryUser}, err)
+}
+
+func (ms *ModelSuite) TestLossRatioReportToCsv() {
+	report := api.LossRatioReport{
+		Rows: []api.LossRatioReportRow{
+			{PeriodStart: "2021-01-01", RiskCategory: "Mobile", Premiums: 10000, NetPremiums: 10000, Claims: 2500, LossRatio: 0.25},
+		},
+		Total: api.LossRatioReportRow{Premiums: 10000, NetPremiums: 10000, Claims: 2500, LossRatio: 0.25},
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(LossRatioReportToCsv(report)))).ReadAll()
+	ms.NoError(err)
+	ms.Equal(3, len(records), "incorrect number of rows")
+	ms.Equal(lossRatioCsvHeader, records[0], "incorrect header")
+	ms.Equal([]string{"2021-01-01", "", "Mobile", "", "", "100.00", "0.00", "100.00", "25.00", "0.2500", "0", "0", "0"},
+		records[1], "incorrect row")
+	ms.Equal("Total", records[2][0], "incorrect total row")
+}
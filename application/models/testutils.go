@@ -517,10 +517,30 @@ func DestroyAll() {
 	var files Files
 	destroyTable(&files)
 
+	// delete all Batches and BatchLedgerEntries
+	var batches Batches
+	destroyTable(&batches)
+
 	// delete all Ledger Entries
 	var ledgerEntries LedgerEntries
 	destroyTable(&ledgerEntries)
 
+	// delete all ClaimRecoveries
+	var claimRecoveries ClaimRecoveries
+	destroyTable(&claimRecoveries)
+
+	// delete all QueueAssignments
+	var queueAssignments QueueAssignments
+	destroyTable(&queueAssignments)
+
+	// delete all ClaimApprovals
+	var claimApprovals ClaimApprovals
+	destroyTable(&claimApprovals)
+
+	// delete all ClaimAdjustments
+	var claimAdjustments ClaimAdjustments
+	destroyTable(&claimAdjustments)
+
 	// delete all ClaimItems
 	var claimItems ClaimItems
 	destroyTable(&claimItems)
@@ -529,6 +549,14 @@ func DestroyAll() {
 	var claims Claims
 	destroyTable(&claims)
 
+	// delete all DeductibleRules
+	var deductibleRules DeductibleRules
+	destroyTable(&deductibleRules)
+
+	// delete all PremiumRates
+	var premiumRates PremiumRates
+	destroyTable(&premiumRates)
+
 	// delete all Users and UserAccessTokens
 	var users Users
 	destroyTable(&users)
@@ -33,6 +33,13 @@ var validUserAppRoles = map[UserAppRole]struct{}{
 	AppRoleSteward:  {},
 }
 
+// appRoleRanks orders the roles by authority. A Signator outranks a Steward, who outranks a Customer.
+var appRoleRanks = map[UserAppRole]int{
+	AppRoleCustomer: 0,
+	AppRoleSteward:  1,
+	AppRoleSignator: 2,
+}
+
 // Users is a slice of User objects
 type Users []User
 
@@ -51,6 +58,7 @@ type User struct {
 	StaffID       nulls.String `db:"staff_id"`
 	AppRole       UserAppRole  `db:"app_role" validate:"appRole"`
 	PhotoFileID   nulls.UUID   `json:"photo_file_id" db:"photo_file_id"`
+	ApprovalLimit nulls.Int    `db:"approval_limit"` // maximum payout the user may approve, or null for no limit
 
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
@@ -105,6 +113,11 @@ func (u *User) FindByStaffID(tx *pop.Connection, id string) error {
 }
 
 func (u *User) IsActorAllowedTo(tx *pop.Connection, actor User, p Permission, sub SubResource, req *http.Request) bool {
+	// an admin may not set their own approval limit, or the limit of a user whose role outranks their own
+	if sub == api.ResourceApprovalLimit {
+		return actor.IsAdmin() && actor.ID != u.ID && appRoleRanks[actor.AppRole] >= appRoleRanks[u.AppRole]
+	}
+
 	switch p {
 	case PermissionView:
 		return actor.IsAdmin() || actor.ID.String() == u.ID.String()
@@ -122,6 +135,31 @@ func (u *User) IsAdmin() bool {
 	return u.AppRole == AppRoleSteward || u.AppRole == AppRoleSignator
 }
 
+// CanApprove returns true if the amount is within the user's approval limit
+func (u *User) CanApprove(amount api.Currency) bool {
+	return !u.ApprovalLimit.Valid || amount <= api.Currency(u.ApprovalLimit.Int)
+}
+
+// SetApprovalLimit sets the maximum payout the user may approve. A nil limit removes the limit.
+func (u *User) SetApprovalLimit(tx *pop.Connection, limit *api.Currency) error {
+	if limit == nil {
+		u.ApprovalLimit = nulls.Int{}
+		return u.Update(tx)
+	}
+
+	if *limit < 0 {
+		err := fmt.Errorf("approval limit may not be negative: %d", *limit)
+		return api.NewAppError(err, api.ErrorUserApprovalLimit, api.CategoryUser)
+	}
+	if !u.IsAdmin() {
+		err := fmt.Errorf("user %s is not an admin and cannot approve claims", u.ID)
+		return api.NewAppError(err, api.ErrorUserApprovalLimit, api.CategoryUser)
+	}
+
+	u.ApprovalLimit = nulls.NewInt(int(*limit))
+	return u.Update(tx)
+}
+
 func (u *User) FindOrCreateFromAuthUser(tx *pop.Connection, authUser *auth.User) error {
 	isNewUser := false
 
@@ -209,6 +247,14 @@ func (u *Users) FindSignators(tx *pop.Connection) {
 	}
 }
 
+// FindApprovers finds all the users with AppRoleSignator whose approval limit allows them to approve the amount
+func (u *Users) FindApprovers(tx *pop.Connection, amount api.Currency) {
+	if err := tx.Where("app_role = ?", AppRoleSignator).
+		Where("(approval_limit IS NULL OR approval_limit >= ?)", amount).All(u); err != nil {
+		panic("error finding approver users " + err.Error())
+	}
+}
+
 // CreateAccessToken - Create and store new UserAccessToken
 func (u *User) CreateAccessToken(tx *pop.Connection, clientID string) (UserAccessToken, error) {
 	if clientID == "" {
@@ -234,19 +280,6 @@ func (u *User) LoadPolicies(tx *pop.Connection, reload bool) {
 	}
 }
 
-func (u *User) MyClaims(tx *pop.Connection) Claims {
-	if err := tx.Load(u, "Policies.Claims"); err != nil {
-		panic("database error loading User.Policies.Claims, " + err.Error())
-	}
-
-	var claims Claims
-	for _, policy := range u.Policies {
-		claims = append(claims, policy.Claims...)
-	}
-
-	return claims
-}
-
 func (u *User) Name() string {
 	return u.GetName().String()
 }
@@ -365,6 +398,23 @@ func (u *Users) GetAll(tx *pop.Connection) error {
 	return tx.All(u)
 }
 
+var userListOptions = listOptions{
+	table: "users",
+	sortColumns: map[string]string{
+		"created_at": "created_at",
+		"email":      "email",
+		"first_name": "first_name",
+		"last_name":  "last_name",
+	},
+	defaultSort:   "last_name",
+	filterColumns: map[string]string{"app_role": "app_role"},
+}
+
+// Query finds the users, or a page of the users, sorted and filtered according to the api.Query
+func (u *Users) Query(tx *pop.Connection, query api.Query) (api.Meta, error) {
+	return findList(tx.Q(), query, userListOptions, u)
+}
+
 // OwnsFile returns true if the user owns the file identified by the given ID
 func (u *User) OwnsFile(tx *pop.Connection, f File) (bool, error) {
 	if u.ID == uuid.Nil {
@@ -439,6 +489,11 @@ func (u *User) ConvertToAPI(tx *pop.Connection, hydrate bool) api.User {
 		PhotoFileID:   convertUUIDToAPI(u.PhotoFileID),
 	}
 
+	if u.ApprovalLimit.Valid {
+		limit := api.Currency(u.ApprovalLimit.Int)
+		output.ApprovalLimit = &limit
+	}
+
 	if hydrate {
 		u.LoadPolicies(tx, false)
 		output.Policies = u.Policies.ConvertToAPI(tx)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofrs/uuid"
 
+	"github.com/silinternational/cover-api/api"
 	"github.com/silinternational/cover-api/domain"
 )
 
@@ -172,6 +173,115 @@ func (ms *ModelSuite) TestUser_FindSignators() {
 	ms.EqualValues(want, got, "incorrect signator ids")
 }
 
+func (ms *ModelSuite) TestUser_FindApprovers() {
+	CreateUserFixtures(ms.DB, 3)
+	unlimited := CreateAdminUsers(ms.DB)[AppRoleSignator]
+	highLimit := CreateAdminUsers(ms.DB)[AppRoleSignator]
+	lowLimit := CreateAdminUsers(ms.DB)[AppRoleSignator]
+
+	high, low := api.Currency(1000000), api.Currency(100000)
+	ms.NoError(highLimit.SetApprovalLimit(ms.DB, &high))
+	ms.NoError(lowLimit.SetApprovalLimit(ms.DB, &low))
+
+	var users Users
+	users.FindApprovers(ms.DB, 500000)
+	want := map[uuid.UUID]bool{unlimited.ID: true, highLimit.ID: true}
+
+	got := map[uuid.UUID]bool{}
+	for _, s := range users {
+		got[s.ID] = true
+	}
+
+	ms.EqualValues(want, got, "incorrect approver ids")
+}
+
+func (ms *ModelSuite) TestUser_SetApprovalLimit() {
+	customer := CreateUserFixtures(ms.DB, 1).Users[0]
+	signator := CreateAdminUsers(ms.DB)[AppRoleSignator]
+
+	limit := api.Currency(500000)
+	negative := api.Currency(-1)
+
+	tests := []struct {
+		name     string
+		user     User
+		limit    *api.Currency
+		appError *api.AppError
+	}{
+		{
+			name:     "not an admin",
+			user:     customer,
+			limit:    &limit,
+			appError: &api.AppError{Key: api.ErrorUserApprovalLimit, Category: api.CategoryUser},
+		},
+		{
+			name:     "negative",
+			user:     signator,
+			limit:    &negative,
+			appError: &api.AppError{Key: api.ErrorUserApprovalLimit, Category: api.CategoryUser},
+		},
+		{
+			name:  "set limit",
+			user:  signator,
+			limit: &limit,
+		},
+		{
+			name:  "remove limit",
+			user:  signator,
+			limit: nil,
+		},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			err := tt.user.SetApprovalLimit(ms.DB, tt.limit)
+			if tt.appError != nil {
+				ms.EqualAppError(*tt.appError, err)
+				return
+			}
+			ms.NoError(err)
+
+			var user User
+			ms.NoError(user.FindByID(ms.DB, tt.user.ID))
+			if tt.limit == nil {
+				ms.False(user.ApprovalLimit.Valid, "ApprovalLimit was not removed")
+				ms.True(user.CanApprove(limit+1), "user without a limit should be able to approve any amount")
+				return
+			}
+			ms.Equal(int(*tt.limit), user.ApprovalLimit.Int, "incorrect ApprovalLimit")
+			ms.True(user.CanApprove(*tt.limit), "user should be able to approve up to the limit")
+			ms.False(user.CanApprove(*tt.limit+1), "user should not be able to approve over the limit")
+		})
+	}
+}
+
+func (ms *ModelSuite) TestUser_IsActorAllowedTo_ApprovalLimit() {
+	customer := User{ID: domain.GetUUID(), AppRole: AppRoleCustomer}
+	steward := User{ID: domain.GetUUID(), AppRole: AppRoleSteward}
+	steward2 := User{ID: domain.GetUUID(), AppRole: AppRoleSteward}
+	signator := User{ID: domain.GetUUID(), AppRole: AppRoleSignator}
+	signator2 := User{ID: domain.GetUUID(), AppRole: AppRoleSignator}
+
+	tests := []struct {
+		name  string
+		actor User
+		user  User
+		want  bool
+	}{
+		{name: "customer", actor: customer, user: steward, want: false},
+		{name: "own limit", actor: signator, user: signator, want: false},
+		{name: "steward sets a signator's limit", actor: steward, user: signator, want: false},
+		{name: "steward sets a steward's limit", actor: steward, user: steward2, want: true},
+		{name: "signator sets a steward's limit", actor: signator, user: steward, want: true},
+		{name: "signator sets a signator's limit", actor: signator2, user: signator, want: true},
+	}
+	for _, tt := range tests {
+		ms.T().Run(tt.name, func(t *testing.T) {
+			got := tt.user.IsActorAllowedTo(ms.DB, tt.actor, PermissionUpdate, api.ResourceApprovalLimit, nil)
+			ms.Equal(tt.want, got)
+		})
+	}
+}
+
 func (ms *ModelSuite) TestUser_EmailOfChoice() {
 	justEmail := User{Email: "justemail@example.com"}
 	hasOverride := User{Email: "main@example.com", EmailOverride: "override@example.com"}
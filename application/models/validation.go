@@ -19,6 +19,7 @@ var fieldValidators = map[string]func(validator.FieldLevel) bool{
 	"claimIncidentType":             validateClaimIncidentType,
 	"claimStatus":                   validateClaimStatus,
 	"claimFilePurpose":              validateClaimFilePurpose,
+	"deductibleType":                validateDeductibleType,
 	"payoutOption":                  validatePayoutOption,
 	"policyDependentChildBirthYear": validatePolicyDependentChildBirthYear,
 	"policyDependentRelationship":   validatePolicyDependentRelationship,
@@ -76,6 +77,14 @@ func validateClaimFilePurpose(field validator.FieldLevel) bool {
 	return false
 }
 
+func validateDeductibleType(field validator.FieldLevel) bool {
+	if value, ok := field.Field().Interface().(api.DeductibleType); ok {
+		_, valid := ValidDeductibleTypes[value]
+		return valid
+	}
+	return false
+}
+
 func validatePayoutOption(field validator.FieldLevel) bool {
 	if value, ok := field.Field().Interface().(api.PayoutOption); ok {
 		if value == "" {
@@ -155,6 +164,10 @@ func claimStructLevelValidation(sl validator.StructLevel) {
 			sl.ReportError(claim.Status, "review_date", "ReviewDate", "review_date_required", "")
 		}
 	}
+
+	if claim.AppealedAt.Valid && strings.TrimSpace(claim.AppealReason) == "" {
+		sl.ReportError(claim.AppealReason, "appeal_reason", "AppealReason", "appeal_reason_required", "")
+	}
 }
 
 func claimItemStructLevelValidation(sl validator.StructLevel) {